@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+)
+
+// handleBackfill handles the backfill sub-command.
+func (ac *appContext) handleBackfill(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(cCtx.Context, os.Interrupt)
+	defer stop()
+
+	n, err := r.Backfill(ctx, cCtx.Duration("interval"))
+	if err != nil {
+		ac.logger.Warn("backfill stopped, run again to resume", zap.Int("updated", n), zap.Error(err))
+		return errors.Wrap(err, "failed to backfill repository")
+	}
+
+	ac.logger.Info("backfill completed", zap.String("repo", r.ID()), zap.Int("updated", n))
+	return nil
+}
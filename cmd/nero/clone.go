@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// handleClone handles the clone sub-command.
+func (ac *appContext) handleClone(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	n, err := r.Clone(cCtx.Context, cCtx.String("dest"))
+	if err != nil {
+		return errors.Wrap(err, "failed to clone repository")
+	}
+
+	ac.logger.Info("clone completed", zap.String("repo", r.ID()), zap.String("dest", cCtx.String("dest")), zap.Int("cloned", n))
+	return nil
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"github.com/cephxdev/nero"
+	"github.com/cephxdev/nero/config"
 	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"os"
@@ -23,3 +26,34 @@ func (ac *appContext) handleConfig(cCtx *cli.Context) error {
 	ac.logger.Info("example configuration saved successfully", zap.String("path", path))
 	return nil
 }
+
+// openRepo opens a configured repository by its ID, the caller is responsible for closing it.
+// The options derived from the configuration may be adjusted by the opts functions.
+func (ac *appContext) openRepo(cCtx *cli.Context, id string, opts ...func(*repo.Options)) (*repo.Repository, error) {
+	cfg, err := config.ParseWithDefaults(cCtx.String("config"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load config")
+	}
+
+	repoConfig, ok := cfg.Repos[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown repository ID %s", id)
+	}
+
+	shared, err := openSharedStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	repoOpts := repoOptions(id, repoConfig, shared)
+	for _, opt := range opts {
+		opt(repoOpts)
+	}
+
+	r, err := repo.NewFile(id, repoConfig.Path, repoConfig.LockPath, repoConfig.Meta, repoOpts, ac.logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create repository")
+	}
+
+	return r, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// handleFindDuplicates handles the find-duplicates sub-command.
+func (ac *appContext) handleFindDuplicates(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	groups := r.DuplicateMetadata()
+	for _, g := range groups {
+		b, err := json.Marshal(g.Meta)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal metadata")
+		}
+
+		ids := make([]string, len(g.IDs))
+		for i, id := range g.IDs {
+			ids[i] = id.String()
+		}
+
+		ac.logger.Info("found media with duplicate metadata", zap.ByteString("meta", b), zap.Strings("ids", ids))
+	}
+
+	ac.logger.Info("duplicate detection completed", zap.String("repo", r.ID()), zap.Int("groups", len(groups)))
+	return nil
+}
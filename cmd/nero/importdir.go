@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+)
+
+// sidecarExts are the extensions of metadata sidecar files, in lookup order.
+var sidecarExts = []string{".json", ".txt"}
+
+// sidecar is the content of a metadata sidecar file.
+type sidecar struct {
+	Source     string `json:"source"`
+	Artist     string `json:"artist"`
+	ArtistLink string `json:"artist_link"`
+	Name       string `json:"name"`
+}
+
+// metadata maps the sidecar fields to media metadata, anime metadata is used if only a name is present.
+func (s *sidecar) metadata() meta.Metadata {
+	if s.Name != "" && s.Source == "" && s.Artist == "" && s.ArtistLink == "" {
+		return &meta.AnimeMetadata{Name: s.Name}
+	}
+	if s.Source == "" && s.Artist == "" && s.ArtistLink == "" {
+		return nil
+	}
+
+	return &meta.GenericMetadata{Source: s.Source, Artist: s.Artist, ArtistLink: s.ArtistLink}
+}
+
+// handleImportDir handles the import-dir sub-command.
+func (ac *appContext) handleImportDir(cCtx *cli.Context) (err error) {
+	naming := cCtx.String("sidecar")
+	if naming != "stem" && naming != "full" {
+		return fmt.Errorf("unknown sidecar naming convention %q", naming)
+	}
+
+	var (
+		dir   = filepath.Clean(cCtx.String("dir"))
+		batch = cCtx.String("batch")
+	)
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to read directory")
+	}
+
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(cCtx.Context, os.Interrupt)
+	defer stop()
+
+	var (
+		create = r.Create
+		tx     *repo.Transaction
+	)
+	if cCtx.Bool("atomic") {
+		if tx, err = r.Begin(); err != nil {
+			return errors.Wrap(err, "failed to begin transaction")
+		}
+		defer func() {
+			if err0 := tx.Rollback(); err0 != nil {
+				err = multierr.Append(err, errors.Wrap(err0, "failed to roll back transaction"))
+			}
+		}()
+
+		create = tx.Create
+	}
+
+	var ingested, failed int
+	for _, de := range des {
+		if !de.Type().IsRegular() || strings.HasPrefix(de.Name(), ".") || isSidecar(de.Name()) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			ac.logger.Warn("import stopped", zap.Int("ingested", ingested), zap.Int("failed", failed))
+			return err
+		}
+
+		path := filepath.Join(dir, de.Name())
+		if err := importFile(ctx, create, path, naming, batch); err != nil {
+			if tx != nil {
+				return errors.Wrap(err, "failed to import file "+path+", rolled back the import")
+			}
+
+			ac.logger.Warn("failed to import file", zap.String("path", path), zap.Error(err))
+			failed++
+			continue
+		}
+
+		ingested++
+	}
+	if tx != nil {
+		if err := tx.Commit(ctx); err != nil {
+			return errors.Wrap(err, "failed to commit import")
+		}
+	}
+
+	ac.logger.Info(
+		"import completed",
+		zap.String("repo", r.ID()),
+		zap.String("batch", batch),
+		zap.Int("ingested", ingested),
+		zap.Int("failed", failed),
+	)
+	return nil
+}
+
+// createFunc creates media, i.e. repo.Repository.Create or repo.Transaction.Create.
+type createFunc func(ctx context.Context, b []byte, m meta.Metadata, opts *repo.CreateOptions) (*media.Media, error)
+
+// importFile imports a media file along with its sidecar metadata, if any, tagged with an import batch ID if not empty.
+func importFile(ctx context.Context, create createFunc, path, naming, batch string) error {
+	s, err := readSidecar(path, naming)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+
+	var m meta.Metadata
+	if s != nil {
+		m = s.metadata()
+	}
+
+	_, err = create(ctx, b, m, &repo.CreateOptions{Filename: filepath.Base(path), Batch: batch})
+	return err
+}
+
+// isSidecar returns whether a file name has a sidecar extension.
+func isSidecar(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, ext0 := range sidecarExts {
+		if ext == ext0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readSidecar reads the sidecar metadata of a media file, returns nil if there is none.
+// Sidecars are named by the media file name without its extension ("stem") or with it ("full").
+func readSidecar(path, naming string) (*sidecar, error) {
+	base := path
+	if naming == "stem" {
+		base = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+
+	for _, ext := range sidecarExts {
+		b, err := os.ReadFile(base + ext)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read sidecar")
+		}
+
+		var s sidecar
+		if ext == ".json" {
+			if err := json.Unmarshal(b, &s); err != nil {
+				return nil, errors.Wrap(err, "failed to parse sidecar")
+			}
+		} else if err := parseTextSidecar(b, &s); err != nil {
+			return nil, err
+		}
+
+		return &s, nil
+	}
+
+	return nil, nil
+}
+
+// parseTextSidecar parses a sidecar of "key: value" lines, unknown keys are ignored.
+func parseTextSidecar(b []byte, s *sidecar) error {
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("malformed sidecar line %q", line)
+		}
+
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "source":
+			s.Source = value
+		case "artist":
+			s.Artist = value
+		case "artist_link", "artist-link":
+			s.ArtistLink = value
+		case "name":
+			s.Name = value
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return errors.Wrap(err, "failed to read sidecar")
+	}
+
+	return nil
+}
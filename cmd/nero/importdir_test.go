@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportDir(t *testing.T) {
+	var (
+		repoDir = t.TempDir()
+		dir     = t.TempDir()
+		cfgPath = writeTestConfig(t, fmt.Sprintf("[http]\n\n[repos.test]\npath = %q\n", repoDir))
+	)
+
+	files := map[string][]byte{
+		"generic.png":  testPNG(t, 2, 2),
+		"generic.json": []byte(`{"source": "https://example.com/1", "artist": "someone", "artist_link": "https://example.com/someone"}`),
+		"anime.png":    testPNG(t, 3, 3),
+		"anime.txt":    []byte("# curated\nname: Some Anime\n"),
+		"bare.png":     testPNG(t, 4, 4),
+		"broken.png":   testPNG(t, 5, 5),
+		"broken.json":  []byte("{not json"), // skipped along with its media
+	}
+	for name, b := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	if err := runApp(t, "import-dir", "-c", cfgPath, "-r", "test", "-d", dir); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	r, err := repo.NewFile("test", repoDir, filepath.Join(repoDir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer r.Close()
+
+	// the test images are told apart by their width
+	byName := make(map[string]*media.Media)
+	for _, m := range r.Items() {
+		byName[map[int]string{2: "generic.png", 3: "anime.png", 4: "bare.png"}[m.Width]] = m
+	}
+	if len(byName) != 3 || byName[""] != nil {
+		t.Fatalf("imported %d items, want 3", len(r.Items()))
+	}
+
+	if gm, ok := byName["generic.png"].Meta.(*meta.GenericMetadata); !ok || gm.Source != "https://example.com/1" || gm.Artist != "someone" || gm.ArtistLink != "https://example.com/someone" {
+		t.Errorf("JSON sidecar metadata = %#v", byName["generic.png"].Meta)
+	}
+	if am, ok := byName["anime.png"].Meta.(*meta.AnimeMetadata); !ok || am.Name != "Some Anime" {
+		t.Errorf("text sidecar metadata = %#v", byName["anime.png"].Meta)
+	}
+	if m := byName["bare.png"]; m == nil || m.Meta != nil {
+		t.Error("media without a sidecar wasn't imported without metadata")
+	}
+}
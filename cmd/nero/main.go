@@ -4,6 +4,7 @@ import (
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"os"
+	"time"
 )
 
 // main is the application entrypoint.
@@ -11,10 +12,14 @@ func main() {
 	logger, _ := zap.NewProduction()
 	defer logger.Sync()
 
-	appCtx := &appContext{
-		logger: logger,
+	if err := newApp(&appContext{logger: logger}).Run(os.Args); err != nil {
+		logger.Fatal("failed to run cli", zap.Error(err))
 	}
-	app := &cli.App{
+}
+
+// newApp creates the CLI application.
+func newApp(appCtx *appContext) *cli.App {
+	return &cli.App{
 		Name:  "nero",
 		Usage: "CLI interface for the nero server",
 		Commands: []*cli.Command{
@@ -65,6 +70,24 @@ func main() {
 								Usage:    "the uploaded file path or remote url",
 								Required: true,
 							},
+							&cli.StringFlag{
+								Name:  "batch",
+								Usage: "the import batch ID the media is tagged with",
+							},
+							&cli.Int64Flag{
+								Name:  "max-download-size",
+								Usage: "the maximum size of files fetched from a remote url in bytes, 0 disables the limit",
+								Value: 100 << 20,
+							},
+							&cli.DurationFlag{
+								Name:  "expires-in",
+								Usage: "the time until the media expires, overriding the repository default, 0 keeps it forever",
+							},
+							&cli.TimestampFlag{
+								Name:   "expires-at",
+								Usage:  "the time the media expires at in RFC 3339 format, overriding the repository default",
+								Layout: time.RFC3339,
+							},
 						},
 						Subcommands: []*cli.Command{
 							{
@@ -112,7 +135,214 @@ func main() {
 						},
 						Action: appCtx.handleDelete,
 					},
+					{
+						Name:   "selftest",
+						Usage:  "uploads, fetches and deletes a generated image to check a deployment",
+						Action: appCtx.handleSelftest,
+					},
+					{
+						Name:  "sync",
+						Usage: "synchronizes a local directory with the repository",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "dir",
+								Aliases:  []string{"d"},
+								Usage:    "the local directory",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "only report what would be done",
+							},
+							&cli.BoolFlag{
+								Name:  "upload",
+								Usage: "upload local items missing in the repository",
+							},
+							&cli.BoolFlag{
+								Name:  "delete",
+								Usage: "delete local items missing in the repository",
+							},
+						},
+						Action: appCtx.handleSync,
+					},
+				},
+			},
+			{
+				Name:  "backfill",
+				Usage: "computes missing derived data of existing media",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "the minimum interval between processed items",
+					},
+				},
+				Action: appCtx.handleBackfill,
+			},
+			{
+				Name:  "reindex",
+				Usage: "rebuilds the index of a repository from the files in its directory",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
 				},
+				Action: appCtx.handleReindex,
+			},
+			{
+				Name:  "normalize-extensions",
+				Usage: "renames media files of a repository to the canonical extensions of their content types",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "only report what would be renamed",
+					},
+				},
+				Action: appCtx.handleNormalizeExtensions,
+			},
+			{
+				Name:  "reclassify-webp",
+				Usage: "corrects the format of static WebP images stored as animated images by earlier versions",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "only report what would be corrected",
+					},
+				},
+				Action: appCtx.handleReclassifyWebP,
+			},
+			{
+				Name:  "find-duplicates",
+				Usage: "reports groups of media with identical metadata, i.e. the same artist and source or anime name",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
+				},
+				Action: appCtx.handleFindDuplicates,
+			},
+			{
+				Name:  "clone",
+				Usage: "copies a repository into a new directory as an independent repository",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the source repo",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "dest",
+						Aliases:  []string{"d"},
+						Usage:    "the empty or missing destination directory, the index is written to nero.lock in it",
+						Required: true,
+					},
+				},
+				Action: appCtx.handleClone,
+			},
+			{
+				Name:  "import-dir",
+				Usage: "imports media from a directory with metadata sidecar files",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "the configuration path, defaults to config.toml",
+						Value:   "config.toml",
+						EnvVars: []string{"NERO_CONFIG_PATH"},
+					},
+					&cli.StringFlag{
+						Name:     "repo",
+						Aliases:  []string{"r"},
+						Usage:    "the target repo",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "dir",
+						Aliases:  []string{"d"},
+						Usage:    "the imported directory",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "sidecar",
+						Usage: "the sidecar naming convention, stem (a.json for a.png) or full (a.png.json)",
+						Value: "stem",
+					},
+					&cli.StringFlag{
+						Name:  "batch",
+						Usage: "the import batch ID the media is tagged with, for removing the whole import later",
+					},
+					&cli.BoolFlag{
+						Name:  "atomic",
+						Usage: "import all files or none, any failure rolls back the files imported so far",
+					},
+				},
+				Action: appCtx.handleImportDir,
 			},
 			{
 				Name:  "config",
@@ -130,8 +360,4 @@ func main() {
 			},
 		},
 	}
-
-	if err := app.Run(os.Args); err != nil {
-		logger.Fatal("failed to run cli", zap.Error(err))
-	}
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"github.com/cephxdev/nero/repo"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// runApp runs the CLI application with arguments, without the program name.
+func runApp(t *testing.T, args ...string) error {
+	t.Helper()
+
+	return newApp(&appContext{logger: zap.NewNop()}).Run(append([]string{"nero"}, args...))
+}
+
+// newTestRepo creates a file-backed repository in a temporary directory, opts may be nil.
+// The repository is closed when the test finishes.
+func newTestRepo(t *testing.T, id string, opts *repo.Options) *repo.Repository {
+	t.Helper()
+
+	dir := t.TempDir()
+	r, err := repo.NewFile(id, dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Close(); err != nil {
+			t.Errorf("failed to close repository: %v", err)
+		}
+	})
+
+	return r
+}
+
+// testPNG encodes a width×height PNG image.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: uint8(x), A: 0xff}) // keeps differently sized images distinct
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// writeTestConfig writes a configuration file for the duration of the test, returning its path.
+func writeTestConfig(t *testing.T, cfg string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(cfg), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
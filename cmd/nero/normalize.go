@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// handleNormalizeExtensions handles the normalize-extensions sub-command.
+func (ac *appContext) handleNormalizeExtensions(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	dryRun := cCtx.Bool("dry-run")
+
+	changes, err := r.NormalizeExtensions(cCtx.Context, dryRun)
+	if err != nil {
+		return errors.Wrap(err, "failed to normalize extensions")
+	}
+
+	for _, c := range changes {
+		ac.logger.Info(
+			"renamed media file",
+			zap.String("id", c.ID.String()),
+			zap.String("from", c.From),
+			zap.String("to", c.To),
+			zap.Bool("dry_run", dryRun),
+		)
+	}
+
+	ac.logger.Info("extension normalization completed", zap.String("repo", r.ID()), zap.Int("renamed", len(changes)), zap.Bool("dry_run", dryRun))
+	return nil
+}
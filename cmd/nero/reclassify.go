@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// handleReclassifyWebP handles the reclassify-webp sub-command.
+func (ac *appContext) handleReclassifyWebP(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	dryRun := cCtx.Bool("dry-run")
+
+	changes, err := r.ReclassifyWebP(cCtx.Context, dryRun)
+	if err != nil {
+		return errors.Wrap(err, "failed to reclassify media")
+	}
+
+	for _, c := range changes {
+		ac.logger.Info(
+			"corrected media format",
+			zap.String("id", c.ID.String()),
+			zap.Stringer("from", c.From),
+			zap.Stringer("to", c.To),
+			zap.Bool("dry_run", dryRun),
+		)
+	}
+
+	ac.logger.Info("webp reclassification completed", zap.String("repo", r.ID()), zap.Int("corrected", len(changes)), zap.Bool("dry_run", dryRun))
+	return nil
+}
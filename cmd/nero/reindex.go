@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// handleReindex handles the reindex sub-command.
+func (ac *appContext) handleReindex(cCtx *cli.Context) (err error) {
+	r, err := ac.openRepo(cCtx, cCtx.String("repo"), func(opts *repo.Options) {
+		opts.AllowDegraded = true // the index is rebuilt anyway, salvage what's readable
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := r.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+		}
+	}()
+
+	if r.Ephemeral() {
+		return errors.New("repository index is ephemeral, a rebuilt index wouldn't be persisted")
+	}
+
+	n, err := r.Reindex(cCtx.Context)
+	if err != nil {
+		return errors.Wrap(err, "failed to reindex repository")
+	}
+
+	ac.logger.Info("reindex completed", zap.String("repo", r.ID()), zap.Int("indexed", n))
+	return nil
+}
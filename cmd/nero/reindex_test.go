@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cephxdev/nero/repo"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReindex(t *testing.T) {
+	var (
+		repoDir = t.TempDir()
+		id      = uuid.New()
+	)
+	for name, b := range map[string][]byte{
+		id.String() + ".png": testPNG(t, 2, 2),
+		"curated.png":        testPNG(t, 3, 3),
+		"notes.txt":          []byte("not media"),
+	} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), b, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	cfgPath := writeTestConfig(t, fmt.Sprintf("[http]\n\n[repos.test]\npath = %q\n", repoDir))
+	if err := runApp(t, "reindex", "-c", cfgPath, "-r", "test"); err != nil {
+		t.Fatalf("reindex failed: %v", err)
+	}
+
+	// the rebuilt index loads strictly
+	r, err := repo.NewFile("test", repoDir, filepath.Join(repoDir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to load the rebuilt index: %v", err)
+	}
+	defer r.Close()
+
+	if n := len(r.Items()); n != 2 {
+		t.Fatalf("rebuilt index has %d items, want the 2 images", n)
+	}
+	if m := r.Get(id); m == nil || m.Width != 2 {
+		t.Error("media ID wasn't recovered from its file name")
+	}
+	if r.LoadSummary().Warnings() != 0 {
+		t.Errorf("rebuilt index loaded with skipped items: %+v", r.LoadSummary())
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// handleSelftest handles the selftest sub-command.
+// It uploads a generated image, fetches it back, compares the content and deletes it again, reporting every step.
+// The uploaded image is deleted even if a later step fails.
+func (ac *appContext) handleSelftest(cCtx *cli.Context) (err error) {
+	c, err := v1.NewClientWithResponses(cCtx.String("url"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create client")
+	}
+
+	var (
+		ctx    = cCtx.Context
+		repoId = cCtx.String("repo")
+		key    = api.MakeOptString(cCtx.String("key"))
+
+		b  []byte
+		id uuid.UUID
+	)
+	step := func(name string, f func() error) error {
+		if err := f(); err != nil {
+			ac.logger.Error("selftest step failed", zap.String("step", name), zap.Error(err))
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		ac.logger.Info("selftest step passed", zap.String("step", name))
+		return nil
+	}
+
+	if err := step("generate", func() (err error) {
+		b, err = selftestImage()
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := step("upload", func() error {
+		res, err := c.PostRepoWithResponse(ctx, repoId, &v1.PostRepoParams{XNeroKey: key}, v1.ProtoMedia{
+			Data:     base64.StdEncoding.EncodeToString(b),
+			Filename: api.MakeOptString("selftest.png"),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to send request")
+		}
+		if res.JSON200 == nil {
+			return fmt.Errorf("request completed with status code %d: %s", res.StatusCode(), bytes.TrimSpace(res.Body))
+		}
+
+		id = res.JSON200.Id
+		return nil
+	}); err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Append(err, step("delete", func() error {
+			return selftestDelete(ctx, c, repoId, id, key)
+		}))
+	}()
+
+	return step("fetch", func() error {
+		res, err := c.GetRepoId(ctx, repoId, id, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to send request")
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode > 399 {
+			return fmt.Errorf("request completed with status code %d", res.StatusCode)
+		}
+
+		b0, err := io.ReadAll(res.Body)
+		if err != nil {
+			return errors.Wrap(err, "failed to read content")
+		}
+		if !bytes.Equal(b, b0) {
+			return fmt.Errorf("fetched content differs from uploaded content (%d bytes, expected %d)", len(b0), len(b))
+		}
+
+		return nil
+	})
+}
+
+// selftestImage generates a tiny PNG image with random colors, so it never collides with existing content.
+func selftestImage() ([]byte, error) {
+	var pix [12]byte
+	if _, err := rand.Read(pix[:]); err != nil {
+		return nil, errors.Wrap(err, "failed to generate colors")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for i := 0; i < 4; i++ {
+		img.Set(i%2, i/2, color.NRGBA{R: pix[i*3], G: pix[i*3+1], B: pix[i*3+2], A: 0xff})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, errors.Wrap(err, "failed to encode image")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// selftestDelete deletes the uploaded selftest media.
+func selftestDelete(ctx context.Context, c *v1.ClientWithResponses, repoId string, id uuid.UUID, key *string) error {
+	res, err := c.DeleteRepoIdWithResponse(ctx, repoId, id, &v1.DeleteRepoIdParams{XNeroKey: key})
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	if code := res.StatusCode(); code > 399 {
+		return fmt.Errorf("request completed with status code %d: %s", code, bytes.TrimSpace(res.Body))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSelftest(t *testing.T) {
+	r := newTestRepo(t, "test", nil)
+	u := serveTestRepos(t, r)
+
+	if err := runApp(t, "client", "-u", u, "-r", "test", "selftest"); err != nil {
+		t.Fatalf("selftest failed: %v", err)
+	}
+	if n := r.Usage().Items; n != 0 {
+		t.Errorf("selftest left %d items behind", n)
+	}
+}
+
+func TestSelftestCleanup(t *testing.T) {
+	r := newTestRepo(t, "test", nil)
+
+	h, err := server.NewNeroRouter([]*repo.Repository{r}, nil, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+	// fetching the uploaded media fails, after it has been stored
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/api/v1/repos/test/") {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		h.ServeHTTP(w, req)
+	}))
+	t.Cleanup(ts.Close)
+
+	err = runApp(t, "client", "-u", ts.URL+"/api/v1", "-r", "test", "selftest")
+	if err == nil || !strings.Contains(err.Error(), "fetch") {
+		t.Fatalf("selftest error = %v, want a failed fetch step", err)
+	}
+	if n := r.Usage().Items; n != 0 {
+		t.Errorf("failed selftest left %d items behind", n)
+	}
+}
@@ -6,17 +6,27 @@ import (
 	"github.com/cephxdev/nero/config"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media/meta"
 	"github.com/cephxdev/nero/server"
+	"github.com/cephxdev/nero/server/api"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/net/netutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"time"
 )
 
+// shutdownTimeout is the maximum time in-flight requests are waited for when shutting down.
+const shutdownTimeout = 30 * time.Second
+
 type httpServer struct {
 	servers []*http.Server
 	errChan chan error
@@ -24,14 +34,34 @@ type httpServer struct {
 	logger *zap.Logger
 }
 
-func (hs *httpServer) add(s *http.Server) {
+// add starts serving an HTTP server in the background, accepting at most maxConns concurrent connections if positive.
+func (hs *httpServer) add(s *http.Server, maxConns int) {
 	hs.servers = append(hs.servers, s)
 	go func() {
-		hs.logger.Info("listening for http requests", zap.String("addr", s.Addr))
-		hs.errChan <- s.ListenAndServe()
+		hs.logger.Info("listening for http requests", zap.String("addr", s.Addr), zap.Int("max_connections", maxConns))
+		hs.errChan <- listenAndServe(s, maxConns)
 	}()
 }
 
+// listenAndServe is like http.Server.ListenAndServe, but accepts at most maxConns concurrent connections if positive.
+// Further connections are held in the listen backlog until others are closed.
+func listenAndServe(s *http.Server, maxConns int) error {
+	if maxConns <= 0 {
+		return s.ListenAndServe()
+	}
+
+	addr := s.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(netutil.LimitListener(l, maxConns))
+}
+
 func (hs *httpServer) shutdown(ctx context.Context) (err error) {
 	for _, s := range hs.servers {
 		err = multierr.Append(err, s.Shutdown(ctx))
@@ -40,6 +70,166 @@ func (hs *httpServer) shutdown(ctx context.Context) (err error) {
 	return err
 }
 
+// repoOptions creates repository options from its configuration section, shared may be nil.
+func repoOptions(id string, cfg *config.Repo, shared *repo.SharedStore) *repo.Options {
+	opts := &repo.Options{
+		PathCollision:        repo.CollisionPolicy(cfg.PathCollision),
+		Symlinks:             repo.SymlinkPolicy(cfg.Symlinks),
+		RemoveSymlinkTargets: cfg.RemoveSymlinkTargets,
+		Codec:                repo.JSONCodec{UUIDFormat: repo.UUIDFormat(cfg.UUIDFormat)},
+		FormatDirs:           cfg.FormatDirs,
+		ScanExisting:         cfg.ScanExisting,
+		Durability:           repo.Durability(cfg.Durability),
+		EphemeralIndex:       cfg.EphemeralIndex,
+		ReportOrphans:        cfg.ReportOrphans,
+		IndexBackups:         cfg.IndexBackups,
+		CanonicalIndex:       cfg.CanonicalIndex,
+		AllowDegraded:        cfg.AllowDegraded,
+		LoadWarningThreshold: cfg.LoadWarningThreshold,
+		ValidateImages:       cfg.ValidateImages,
+		MinWidth:             cfg.MinWidth,
+		MinHeight:            cfg.MinHeight,
+		MaxWidth:             cfg.MaxWidth,
+		MaxHeight:            cfg.MaxHeight,
+		MaxFrames:            cfg.MaxFrames,
+		SVG:                  repo.SVGPolicy(cfg.SVG),
+		MetaParsing:          repo.MetaParsing(cfg.MetaParsing),
+		StrictUploads:        cfg.StrictUploads,
+		AutoOrient:           cfg.AutoOrient,
+		StoreMIME:            cfg.StoreMIME,
+		KeepExtension:        cfg.KeepExtension,
+		DefaultLimit:         cfg.DefaultLimit,
+		DefaultOrder:         repo.Order(cfg.DefaultOrder),
+		HideUnknown:          cfg.HideUnknown,
+		EmptyRandomNotFound:  cfg.EmptyRandomNotFound,
+		PinnedFirst:          cfg.PinnedFirst,
+		CountDownloads:       cfg.CountDownloads,
+		CounterSaveInterval:  cfg.CounterSaveInterval,
+		TrackAccess:          cfg.TrackAccess,
+		AccessSaveInterval:   cfg.AccessSaveInterval,
+		CacheSize:            cfg.CacheSize,
+		Prefetch:             cfg.Prefetch,
+		ListCacheTTL:         cfg.ListCacheTTL,
+		ListCacheSize:        cfg.ListCacheSize,
+		TTL:                  cfg.TTL,
+		SweepInterval:        cfg.SweepInterval,
+		Quota:                cfg.Quota,
+		MinFreeSpace:         cfg.MinFreeSpace,
+		SoftQuota:            cfg.SoftQuota,
+		SoftItemLimit:        cfg.SoftItemLimit,
+		WriteBufferSize:      cfg.WriteBufferSize,
+		UploadConcurrency:    cfg.UploadConcurrency,
+		UploadQueueTimeout:   cfg.UploadQueueTimeout,
+	}
+	for _, name := range cfg.MetaTypes {
+		type_, _ := meta.ParseType(name) // validated with the configuration
+		opts.MetaTypes = append(opts.MetaTypes, type_)
+	}
+	for _, field := range cfg.IndexedFields {
+		opts.IndexedFields = append(opts.IndexedFields, repo.FacetField(field))
+	}
+	if cfg.Uploader != nil {
+		opts.RecordUploader = true
+		opts.HashUploaderIP = cfg.Uploader.HashIP
+		opts.UploaderIPSalt = cfg.Uploader.Salt
+	}
+	if cfg.Upstream != nil {
+		upstreamRepo := cfg.Upstream.Repo
+		if upstreamRepo == "" {
+			upstreamRepo = id
+		}
+
+		opts.Upstream = &repo.HTTPUpstream{
+			BaseURL: cfg.Upstream.URL,
+			Repo:    upstreamRepo,
+			Client:  &http.Client{Timeout: cfg.Upstream.Timeout},
+		}
+	}
+	if cfg.Normalize != nil {
+		opts.Normalization = &repo.Normalization{
+			Trim:               cfg.Normalize.Trim,
+			CollapseWhitespace: cfg.Normalize.CollapseWhitespace,
+			Lowercase:          cfg.Normalize.Lowercase,
+		}
+	}
+	if cfg.Transcode != nil {
+		opts.Transcoder = &repo.FFmpegTranscoder{Path: cfg.Transcode.FFmpeg}
+		opts.TranscodeThreshold = cfg.Transcode.Threshold
+		opts.KeepOriginal = cfg.Transcode.KeepOriginal
+	}
+	if cfg.WebP != nil {
+		opts.WebP = &repo.FFmpegWebPEncoder{Path: cfg.WebP.FFmpeg, Quality: cfg.WebP.Quality}
+		opts.WebPCacheSize = cfg.WebP.CacheSize
+	}
+	if cfg.Resize != nil {
+		opts.ResizeMaxSize = cfg.Resize.MaxSize
+		opts.ResizeSizes = cfg.Resize.Sizes
+		opts.ResizeCacheSize = cfg.Resize.CacheSize
+	}
+	if cfg.Thumbnail != nil {
+		opts.FrameExtractor = &repo.FFmpegFrameExtractor{Path: cfg.Thumbnail.FFmpeg}
+		opts.ThumbnailCacheSize = cfg.Thumbnail.CacheSize
+	}
+	if cfg.Dedup {
+		opts.Shared = shared // validated with the configuration
+	}
+	if cfg.Hook != nil && len(cfg.Hook.Command) > 0 {
+		opts.Hook = repo.NewHook(cfg.Hook.Command, cfg.Hook.Concurrency, cfg.Hook.Timeout)
+	}
+
+	return opts
+}
+
+// logLoadSummary logs a one-line summary of loading the index of a repository, according to its verbosity.
+func logLoadSummary(logger *zap.Logger, r *repo.Repository, verbosity string) {
+	ls := r.LoadSummary()
+	if verbosity == "off" || (verbosity == "warnings" && ls.Warnings() == 0) {
+		return
+	}
+
+	logger.Info(
+		"loaded repository index",
+		zap.String("repo", r.ID()),
+		zap.Int("items", r.Usage().Items),
+		zap.Int("duplicates", ls.Duplicates),
+		zap.Int("collisions", ls.Collisions),
+		zap.Int("missing", ls.Missing),
+		zap.Int("unreadable", ls.Unreadable),
+		zap.Int("symlinks", ls.Symlinks),
+		zap.Int("orphans", ls.Orphans),
+		zap.Bool("degraded", r.Degraded()),
+	)
+}
+
+// openSharedStore opens the shared store of cross-repository deduplication, nil if it isn't configured.
+func openSharedStore(cfg *config.Config) (*repo.SharedStore, error) {
+	if cfg.Dedup == nil || cfg.Dedup.Path == "" {
+		return nil, nil
+	}
+
+	ss, err := repo.OpenSharedStore(cfg.Dedup.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open shared store")
+	}
+	return ss, nil
+}
+
+// newHTTPServer creates an HTTP server from its configuration section.
+func newHTTPServer(cfg *config.HTTPServer, handler http.Handler) *http.Server {
+	if cfg.H2C {
+		handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: cfg.IdleTimeout})
+	}
+
+	s := &http.Server{
+		Addr:        cfg.Host,
+		Handler:     handler,
+		IdleTimeout: cfg.IdleTimeout,
+	}
+	s.SetKeepAlivesEnabled(!cfg.DisableKeepAlives)
+
+	return s
+}
+
 // handleServer handles the server sub-command.
 func (ac *appContext) handleServer(cCtx *cli.Context) (err error) {
 	cfg, err := config.ParseWithDefaults(cCtx.String("config"))
@@ -47,13 +237,26 @@ func (ac *appContext) handleServer(cCtx *cli.Context) (err error) {
 		return errors.Wrap(err, "failed to load config")
 	}
 
+	shared, err := openSharedStore(cfg)
+	if err != nil {
+		return err
+	}
+
 	repos0 := make(map[string]*repo.Repository, len(cfg.Repos))
+	defer func() { // also closes the repositories opened before a failure
+		for _, r := range repos0 {
+			if err0 := r.Close(); err0 != nil {
+				err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
+			}
+		}
+	}()
+
 	for repoId, repoConfig := range cfg.Repos {
 		if _, ok := repos0[repoId]; ok {
 			return fmt.Errorf("duplicate repository ID %s, path %s", repoId, repoConfig.Path)
 		}
 
-		r, err := repo.NewFile(repoId, repoConfig.Path, repoConfig.LockPath, repoConfig.Meta, ac.logger)
+		r, err := repo.NewFile(repoId, repoConfig.Path, repoConfig.LockPath, repoConfig.Meta, repoOptions(repoId, repoConfig, shared), ac.logger)
 		if err != nil {
 			return errors.Wrap(err, "failed to create repository")
 		}
@@ -65,29 +268,51 @@ func (ac *appContext) handleServer(cCtx *cli.Context) (err error) {
 			zap.String("repo", repoId),
 			zap.String("path", repoConfig.Path),
 		)
+		logLoadSummary(ac.logger, r, repoConfig.LoadSummary)
+	}
+
+	var indexMemory int64
+	for _, r := range repos0 {
+		indexMemory += r.IndexMemory()
+	}
+	if limit := cfg.Limits.IndexMemoryWarning; limit > 0 && indexMemory > limit {
+		ac.logger.Warn(
+			"estimated index memory exceeds the configured threshold",
+			zap.Int64("estimated", indexMemory),
+			zap.Int64("threshold", limit),
+			zap.Int("repos", len(repos0)),
+		)
 	}
-	defer func() {
-		for _, r := range repos0 {
-			if err0 := r.Close(); err0 != nil {
-				err = multierr.Append(err, errors.Wrap(err0, "failed to close repository"))
-			}
-		}
-	}()
 
 	var (
 		repos   = maps.Values(repos0)
+		drain   = &api.Drain{}
 		httpSrv = &httpServer{
 			errChan: make(chan error),
 			logger:  ac.logger,
 		}
 	)
 	if cfg.HTTP.Nero.Enabled() {
-		handler, err := server.NewNeroRouter(repos, ac.logger)
+		var baseURL *url.URL
+		if cfg.HTTP.Nero.BaseURL != "" {
+			if baseURL, err = url.Parse(cfg.HTTP.Nero.BaseURL); err != nil {
+				return errors.Wrap(err, "failed to parse nero api base url")
+			}
+		}
+
+		handler, err := server.NewNeroRouter(repos, baseURL, cfg.HTTP.Nero.Hosts, drain, ac.logger)
 		if err != nil {
 			return errors.Wrap(err, "failed to create nero api router")
 		}
+		if cfg.HTTP.Nero.Metrics {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", server.NewMetricsHandler(repos))
+			mux.Handle("/", handler)
+
+			handler = mux
+		}
 
-		httpSrv.add(&http.Server{Addr: cfg.HTTP.Nero.Host, Handler: handler})
+		httpSrv.add(newHTTPServer(cfg.HTTP.Nero, handler), cfg.HTTP.Nero.MaxConnections)
 	}
 	if cfg.HTTP.Nekos.Enabled() {
 		var baseURL *url.URL
@@ -102,7 +327,7 @@ func (ac *appContext) handleServer(cCtx *cli.Context) (err error) {
 			return errors.Wrap(err, "failed to create nekos api router")
 		}
 
-		httpSrv.add(&http.Server{Addr: cfg.HTTP.Nekos.Host, Handler: handler})
+		httpSrv.add(newHTTPServer(cfg.HTTP.Nekos, handler), cfg.HTTP.Nekos.MaxConnections)
 	}
 
 	ctx, stop := signal.NotifyContext(cCtx.Context, os.Interrupt)
@@ -110,8 +335,25 @@ func (ac *appContext) handleServer(cCtx *cli.Context) (err error) {
 
 	select {
 	case <-ctx.Done():
+		if d := cfg.HTTP.LameDuck; d > 0 {
+			ac.logger.Info("rejecting new uploads before shutting down", zap.Duration("lame_duck", d))
+			drain.Start()
+
+			select {
+			case <-drain.Wait():
+			case <-time.After(d):
+			case err = <-httpSrv.errChan:
+				return errors.Wrap(err, "http server errored")
+			}
+		}
+
 		ac.logger.Info("shutting down gracefully")
-		if err = httpSrv.shutdown(ctx); err != nil {
+
+		// ctx is done already, the servers get a fresh deadline to finish their requests
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err = httpSrv.shutdown(shutdownCtx); err != nil {
 			err = errors.Wrap(err, "failed to shutdown http server")
 		}
 	case err = <-httpSrv.errChan:
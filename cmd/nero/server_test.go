@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/cephxdev/nero/config"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/net/http2"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveTest serves an HTTP server on a random local port until the test finishes, returning its address.
+func serveTest(t *testing.T, s *http.Server) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	return l.Addr().String()
+}
+
+func TestNewHTTPServerH2C(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+	addr := serveTest(t, newHTTPServer((&config.HTTPServer{H2C: true}).Defaults(), handler))
+
+	// prior knowledge h2c, without an upgrade from HTTP/1.1
+	c := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+
+	res, err := c.Get("http://" + addr + "/")
+	if err != nil {
+		t.Fatalf("h2c request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if res.StatusCode != http.StatusOK || string(b) != "HTTP/2.0" {
+		t.Errorf("got status %d over %s, want 200 over HTTP/2.0", res.StatusCode, b)
+	}
+}
+
+func TestNewHTTPServerKeepAlives(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {})
+	addr := serveTest(t, newHTTPServer((&config.HTTPServer{DisableKeepAlives: true}).Defaults(), handler))
+
+	res, err := http.Get("http://" + addr + "/")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = res.Body.Close()
+
+	if !res.Close {
+		t.Error("connection is kept alive, want it closed after the request")
+	}
+}
+
+func TestServerMaxRepos(t *testing.T) {
+	cfg := "[http]\n\n[limits]\nmax_repos = 1\n\n"
+	for _, id := range []string{"a", "b"} {
+		cfg += fmt.Sprintf("[repos.%s]\npath = %q\n\n", id, t.TempDir())
+	}
+
+	err := runApp(t, "server", "-c", writeTestConfig(t, cfg))
+	if err == nil || !strings.Contains(err.Error(), "2 repositories configured, exceeding the limit of 1") {
+		t.Errorf("starting with too many repositories failed with %v, want a repository limit error", err)
+	}
+}
+
+func TestListenAndServeMaxConnections(t *testing.T) {
+	// reserve a free port for the server
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	var (
+		started = make(chan struct{}, 2)
+		release = make(chan struct{})
+	)
+	s := &http.Server{Addr: addr, Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+	})}
+	go func() {
+		_ = listenAndServe(s, 1)
+	}()
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	c := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	get := func() <-chan error {
+		errs := make(chan error, 1)
+		go func() {
+			for i := 0; ; i++ {
+				res, err := c.Get("http://" + addr + "/")
+				if err != nil && i < 50 {
+					time.Sleep(10 * time.Millisecond) // the server may not be listening yet
+					continue
+				}
+				if err == nil {
+					_ = res.Body.Close()
+				}
+				errs <- err
+				return
+			}
+		}()
+		return errs
+	}
+
+	first := get()
+	select {
+	case <-started:
+	case err := <-first:
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	second := get()
+	select {
+	case <-started:
+		t.Fatal("connection beyond the limit was served concurrently")
+	case err := <-second:
+		t.Fatalf("connection beyond the limit was rejected: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	for _, errs := range []<-chan error{first, second} {
+		if err := <-errs; err != nil {
+			t.Errorf("request failed: %v", err)
+		}
+	}
+}
+
+func TestLogLoadSummary(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "nero.lock")
+
+	r, err := repo.NewFile("test", dir, lockPath, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	var missing *media.Media
+	for i := 1; i <= 3; i++ {
+		if missing, err = r.Create(context.Background(), testPNG(t, i, i), nil, nil); err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("failed to close repository: %v", err)
+	}
+
+	// craft an index with a duplicate, an unreadable line and an item without a file
+	b, err := os.ReadFile(lockPath)
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if line != "" && !strings.Contains(line, missing.ID.String()) { // a missing duplicate would be a path collision
+			b = append(b, line...)
+			break
+		}
+	}
+	b = append(b, "{not json\n"...)
+	if err := os.WriteFile(lockPath, b, 0644); err != nil {
+		t.Fatalf("failed to write index file: %v", err)
+	}
+	if err := os.Remove(missing.Path); err != nil {
+		t.Fatalf("failed to remove content file: %v", err)
+	}
+
+	r, err = repo.NewFile("test", dir, lockPath, nil, &repo.Options{AllowDegraded: true}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer r.Close()
+
+	for _, verbosity := range []string{"", "always", "warnings", "off"} {
+		core, logs := observer.New(zap.InfoLevel)
+		logLoadSummary(zap.New(core), r, verbosity)
+
+		if verbosity == "off" {
+			if logs.Len() != 0 {
+				t.Errorf("load summary logged with verbosity %q", verbosity)
+			}
+			continue
+		}
+		if logs.Len() != 1 {
+			t.Fatalf("verbosity %q: logged %d lines, want 1", verbosity, logs.Len())
+		}
+
+		fields := logs.All()[0].ContextMap()
+		for key, want := range map[string]int64{"items": 2, "duplicates": 1, "missing": 1, "unreadable": 1, "collisions": 0} {
+			if got := fields[key]; got != want {
+				t.Errorf("verbosity %q: %s = %v, want %d", verbosity, key, got, want)
+			}
+		}
+	}
+
+	// a clean repository is only summarized with the default verbosity
+	clean := newTestRepo(t, "clean", nil)
+	for verbosity, want := range map[string]int{"always": 1, "warnings": 0} {
+		core, logs := observer.New(zap.InfoLevel)
+		logLoadSummary(zap.New(core), clean, verbosity)
+		if logs.Len() != want {
+			t.Errorf("clean repository with verbosity %q: logged %d lines, want %d", verbosity, logs.Len(), want)
+		}
+	}
+}
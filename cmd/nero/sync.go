@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	mime "github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localFile is a file in a synchronized directory.
+type localFile struct {
+	path, hash string
+	id         uuid.UUID // uuid.Nil if the file name isn't an ID
+}
+
+// handleSync handles the sync sub-command.
+func (ac *appContext) handleSync(cCtx *cli.Context) error {
+	if cCtx.Bool("upload") && cCtx.Bool("delete") {
+		return errors.New("upload and delete are mutually exclusive")
+	}
+
+	c, err := v1.NewClientWithResponses(cCtx.String("url"))
+	if err != nil {
+		return errors.Wrap(err, "failed to create client")
+	}
+
+	dir := filepath.Clean(cCtx.String("dir"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to make directories")
+	}
+
+	entries, err := fetchManifest(cCtx.Context, c, cCtx.String("repo"), cCtx.String("key"))
+	if err != nil {
+		return err
+	}
+
+	files, err := scanDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var (
+		dryRun = cCtx.Bool("dry-run")
+
+		byHash = make(map[string]*localFile, len(files))
+		byID   = make(map[uuid.UUID]*localFile, len(files))
+		known  = make(map[*localFile]struct{}, len(files))
+
+		downloaded, uploaded, deleted, skipped int
+	)
+	for _, f := range files {
+		byHash[f.hash] = f
+		if f.id != uuid.Nil {
+			byID[f.id] = f
+		}
+	}
+
+	for _, e := range entries {
+		f, ok := byHash[api.MakeString(e.Hash)]
+		if !ok {
+			f, ok = byID[e.Id]
+		}
+		if ok {
+			known[f] = struct{}{}
+			skipped++
+			continue
+		}
+
+		ac.logger.Info("downloading item", zap.String("id", e.Id.String()), zap.Bool("dry_run", dryRun))
+		if !dryRun {
+			if err := downloadItem(cCtx.Context, c, cCtx.String("repo"), e, dir); err != nil {
+				return err
+			}
+		}
+		downloaded++
+	}
+
+	for _, f := range files {
+		if _, ok := known[f]; ok {
+			continue
+		}
+
+		switch {
+		case cCtx.Bool("upload"):
+			ac.logger.Info("uploading local item", zap.String("path", f.path), zap.Bool("dry_run", dryRun))
+			if !dryRun {
+				b, err := os.ReadFile(f.path)
+				if err != nil {
+					return errors.Wrap(err, "failed to read file")
+				}
+				if err := ac.postMedia(cCtx, c, b, nil, filepath.Base(f.path)); err != nil {
+					return err
+				}
+			}
+			uploaded++
+		case cCtx.Bool("delete"):
+			ac.logger.Info("deleting local item", zap.String("path", f.path), zap.Bool("dry_run", dryRun))
+			if !dryRun {
+				if err := os.Remove(f.path); err != nil {
+					return errors.Wrap(err, "failed to delete file")
+				}
+			}
+			deleted++
+		default:
+			skipped++
+		}
+	}
+
+	ac.logger.Info(
+		"sync completed",
+		zap.Int("downloaded", downloaded),
+		zap.Int("uploaded", uploaded),
+		zap.Int("deleted", deleted),
+		zap.Int("skipped", skipped),
+		zap.Bool("dry_run", dryRun),
+	)
+	return nil
+}
+
+// fetchManifest fetches and decodes the manifest of a remote repository, key may be empty.
+func fetchManifest(ctx context.Context, c *v1.ClientWithResponses, repo, key string) ([]v1.ManifestEntry, error) {
+	res, err := c.GetRepoManifest(ctx, repo, &v1.GetRepoManifestParams{XNeroKey: api.MakeOptString(key)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 399 {
+		return nil, fmt.Errorf("manifest request completed with error status code %d", res.StatusCode)
+	}
+
+	var (
+		entries []v1.ManifestEntry
+		s       = bufio.NewScanner(res.Body)
+	)
+	for s.Scan() {
+		if s.Text() == "" {
+			continue // skip empty lines
+		}
+
+		var e v1.ManifestEntry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, errors.Wrap(err, "failed to read manifest entry")
+		}
+
+		entries = append(entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+
+	return entries, nil
+}
+
+// downloadItem downloads a remote item into a directory, named by its ID.
+func downloadItem(ctx context.Context, c *v1.ClientWithResponses, repo string, e v1.ManifestEntry, dir string) error {
+	res, err := c.GetRepoId(ctx, repo, e.Id, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 399 {
+		return fmt.Errorf("item %s request completed with error status code %d", e.Id, res.StatusCode)
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read item")
+	}
+
+	if hash := api.MakeString(e.Hash); hash != "" {
+		sum := sha256.Sum256(b)
+		if !strings.EqualFold(hash, hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("item %s checksum mismatch", e.Id)
+		}
+	}
+
+	path := filepath.Join(dir, e.Id.String()+mime.Detect(b).Extension())
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrap(err, "failed to write item")
+	}
+
+	return nil
+}
+
+// scanDir hashes all regular files in a directory.
+func scanDir(dir string) ([]*localFile, error) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read directory")
+	}
+
+	var files []*localFile
+	for _, de := range des {
+		if !de.Type().IsRegular() {
+			continue
+		}
+
+		path := filepath.Join(dir, de.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read file")
+		}
+
+		sum := sha256.Sum256(b)
+		f := &localFile{path: path, hash: hex.EncodeToString(sum[:])}
+		if id, ok := media.ParseIDFromFilename(de.Name()); ok {
+			f.id = id
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// serveTestRepos serves the nero API of repositories until the test finishes, returning the v1 API URL.
+func serveTestRepos(t *testing.T, repos ...*repo.Repository) string {
+	t.Helper()
+
+	h, err := server.NewNeroRouter(repos, nil, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	return ts.URL + "/api/v1"
+}
+
+func TestSyncDownload(t *testing.T) {
+	r := newTestRepo(t, "test", nil)
+	u := serveTestRepos(t, r)
+
+	var (
+		local  = testPNG(t, 2, 2)
+		remote = testPNG(t, 3, 3)
+		dir    = t.TempDir()
+	)
+	for _, b := range [][]byte{local, remote} {
+		if _, err := r.Create(context.Background(), b, nil, nil); err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+	}
+	// present locally under another name, matched by its hash
+	if err := os.WriteFile(filepath.Join(dir, "local.png"), local, 0644); err != nil {
+		t.Fatalf("failed to write local file: %v", err)
+	}
+
+	if err := runApp(t, "client", "-u", u, "-r", "test", "sync", "-d", dir, "--dry-run"); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if des, _ := os.ReadDir(dir); len(des) != 1 {
+		t.Fatalf("dry run changed the directory, %d files", len(des))
+	}
+
+	if err := runApp(t, "client", "-u", u, "-r", "test", "sync", "-d", dir); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	files, err := scanDir(dir)
+	if err != nil {
+		t.Fatalf("failed to scan directory: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("directory has %d files, want the local one and the downloaded one", len(files))
+	}
+	for _, f := range files {
+		if filepath.Base(f.path) == "local.png" {
+			continue
+		}
+
+		b, err := os.ReadFile(f.path)
+		if err != nil {
+			t.Fatalf("failed to read downloaded file: %v", err)
+		}
+		if !bytes.Equal(b, remote) {
+			t.Error("downloaded file differs from the remote item")
+		}
+		if r.Get(f.id) == nil {
+			t.Errorf("downloaded file %s isn't named by the item ID", f.path)
+		}
+	}
+
+	// everything is present now
+	if err := runApp(t, "client", "-u", u, "-r", "test", "sync", "-d", dir); err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+	if des, _ := os.ReadDir(dir); len(des) != 2 {
+		t.Errorf("second sync downloaded again, %d files", len(des))
+	}
+}
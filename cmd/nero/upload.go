@@ -1,7 +1,9 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/server/api"
@@ -13,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // handleUploadGeneric handles the upload generic sub-command.
@@ -44,8 +47,9 @@ func (ac *appContext) handleUpload(cCtx *cli.Context, m *v1.ProtoMedia_Meta) err
 	}
 
 	var (
-		path = cCtx.String("path")
-		data io.ReadCloser
+		path  = cCtx.String("path")
+		data  io.ReadCloser
+		limit int64 // maximum size of remote files, unlimited if not positive
 	)
 	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
 		ac.logger.Info("treating path as remote url", zap.String("path", path))
@@ -56,9 +60,16 @@ func (ac *appContext) handleUpload(cCtx *cli.Context, m *v1.ProtoMedia_Meta) err
 		}
 
 		if res.StatusCode > 399 {
+			_ = res.Body.Close()
 			return fmt.Errorf("remote url request returned error status code %d", res.StatusCode)
 		}
 
+		limit = cCtx.Int64("max-download-size")
+		if limit > 0 && res.ContentLength > limit {
+			_ = res.Body.Close()
+			return fmt.Errorf("remote file size of %d bytes exceeds the maximum download size of %d bytes", res.ContentLength, limit)
+		}
+
 		data = res.Body
 	} else {
 		if data, err = os.Open(filepath.Clean(path)); err != nil {
@@ -66,20 +77,51 @@ func (ac *appContext) handleUpload(cCtx *cli.Context, m *v1.ProtoMedia_Meta) err
 		}
 	}
 
-	b, err := io.ReadAll(data)
+	var rd io.Reader = data
+	if limit > 0 {
+		rd = io.LimitReader(data, limit+1) // one byte over the limit to tell oversized content apart
+	}
+
+	b, err := io.ReadAll(rd)
 	if err != nil {
+		_ = data.Close()
 		return errors.Wrap(err, "failed to read file")
 	}
+	if limit > 0 && int64(len(b)) > limit {
+		_ = data.Close()
+		return fmt.Errorf("remote file exceeds the maximum download size of %d bytes", limit)
+	}
 
 	if err = data.Close(); err != nil {
 		return errors.Wrap(err, "failed to close data stream")
 	}
 
+	return ac.postMedia(cCtx, c, b, m, filepath.Base(path))
+}
+
+// postMedia uploads media to the repository selected by the client command flags.
+func (ac *appContext) postMedia(cCtx *cli.Context, c *v1.ClientWithResponses, b []byte, m *v1.ProtoMedia_Meta, filename string) error {
+	pm := v1.ProtoMedia{
+		Data:     base64.StdEncoding.EncodeToString(b),
+		Meta:     m,
+		Filename: api.MakeOptString(filename),
+		Batch:    api.MakeOptString(cCtx.String("batch")),
+	}
+	if cCtx.IsSet("expires-in") {
+		secs := int64((cCtx.Duration("expires-in") + time.Second - 1) / time.Second) // sub-second expiries don't mean forever
+		pm.ExpiresIn = &secs
+	}
+	pm.ExpiresAt = cCtx.Timestamp("expires-at")
+
+	sum := sha256.Sum256(b)
 	res, err := c.PostRepoWithResponse(
 		cCtx.Context,
 		cCtx.String("repo"),
-		&v1.PostRepoParams{XNeroKey: api.MakeOptString(cCtx.String("key"))},
-		v1.ProtoMedia{Data: base64.StdEncoding.EncodeToString(b), Meta: m},
+		&v1.PostRepoParams{
+			XNeroKey:       api.MakeOptString(cCtx.String("key")),
+			XContentSHA256: api.MakeOptString(hex.EncodeToString(sum[:])),
+		},
+		pm,
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to send request")
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUploadMaxDownloadSize(t *testing.T) {
+	var (
+		r = newTestRepo(t, "test", nil)
+		u = serveTestRepos(t, r)
+		b = testPNG(t, 8, 8)
+	)
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/chunked.png" {
+			// without a content length, the size is only known while reading
+			_, _ = w.Write(b[:len(b)/2])
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(b[len(b)/2:])
+			return
+		}
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(remote.Close)
+
+	upload := func(path string, limit int) error {
+		return runApp(t, "client", "-u", u, "-r", "test", "upload", "-f", remote.URL+path, "--max-download-size", strconv.Itoa(limit), "generic")
+	}
+
+	for _, path := range []string{"/sized.png", "/chunked.png"} {
+		if err := upload(path, len(b)-1); err == nil || !strings.Contains(err.Error(), "maximum download size") {
+			t.Errorf("oversized download of %s error = %v, want the size cap exceeded", path, err)
+		}
+	}
+	if n := r.Usage().Items; n != 0 {
+		t.Fatalf("oversized downloads stored %d items", n)
+	}
+
+	if err := upload("/chunked.png", len(b)); err != nil {
+		t.Fatalf("download at the size cap failed: %v", err)
+	}
+	if n := r.Usage().Items; n != 1 {
+		t.Errorf("stored %d items, want the download at the size cap", n)
+	}
+}
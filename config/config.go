@@ -1,8 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"github.com/BurntSushi/toml"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"os"
 	"path/filepath"
+	"time"
 )
 
 // Section is a section of the configuration file.
@@ -18,11 +23,16 @@ type Config struct {
 	HTTP *HTTP `toml:"http"`
 	// Repos is the collection of repository configuration, keyed by their ID.
 	Repos map[string]*Repo `toml:"repos"`
+	// Limits is the "limits" configuration section.
+	Limits *Limits `toml:"limits"`
+	// Dedup is the "dedup" configuration section, cross-repository deduplication is disabled if nil.
+	Dedup *Dedup `toml:"dedup"`
 }
 
 // Defaults completes the configuration with default values.
 func (c *Config) Defaults() *Config {
 	c.HTTP = c.HTTP.Defaults()
+	c.Limits = c.Limits.Defaults()
 	for k, v := range c.Repos {
 		c.Repos[k] = v.Defaults()
 	}
@@ -30,12 +40,92 @@ func (c *Config) Defaults() *Config {
 	return c
 }
 
+// Validate checks the configuration against its limits.
+func (c *Config) Validate() error {
+	if c.Limits != nil && c.Limits.MaxRepos > 0 && len(c.Repos) > c.Limits.MaxRepos {
+		return fmt.Errorf("%d repositories configured, exceeding the limit of %d (limits.max_repos)", len(c.Repos), c.Limits.MaxRepos)
+	}
+	if c.HTTP != nil && c.HTTP.Nero != nil {
+		for host, id := range c.HTTP.Nero.Hosts {
+			if _, ok := c.Repos[id]; !ok {
+				return fmt.Errorf("host %s is mapped to unknown repository %s", host, id)
+			}
+		}
+	}
+	for id, r := range c.Repos {
+		if r.Dedup && (c.Dedup == nil || c.Dedup.Path == "") {
+			return fmt.Errorf("repository %s uses deduplication, but no shared store path is configured (dedup.path)", id)
+		}
+		for _, name := range r.MetaTypes {
+			if _, ok := meta.ParseType(name); !ok {
+				return fmt.Errorf("unknown metadata type %s in repository %s", name, id)
+			}
+		}
+		switch r.LoadSummary {
+		case "", "always", "warnings", "off":
+		default:
+			return fmt.Errorf("unknown load summary verbosity %s in repository %s", r.LoadSummary, id)
+		}
+		if r.Resize != nil {
+			for _, size := range r.Resize.Sizes {
+				if size <= 0 || size > r.Resize.MaxSize {
+					return fmt.Errorf("resize size %d out of range in repository %s", size, id)
+				}
+			}
+		}
+		if r.IndexDir {
+			// an existing index isn't moved, the repository would start out empty otherwise
+			legacyPath := filepath.Join(r.Path, "nero.lock")
+			if _, err := os.Stat(legacyPath); err == nil && r.LockPath != legacyPath {
+				if _, err := os.Stat(r.LockPath); errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf(
+						"repository %s has an index file at %s, move it along with its sidecar files to %s",
+						id, legacyPath, filepath.Dir(r.LockPath),
+					)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dedup is a cross-repository deduplication configuration section of the configuration file.
+type Dedup struct {
+	// Path is the path of the shared store directory, it must be on the same filesystem as the repositories using it.
+	Path string `toml:"path"`
+}
+
+// Limits is a resource limit configuration section of the configuration file.
+type Limits struct {
+	// MaxRepos is the maximum amount of configured repositories, unlimited if zero.
+	MaxRepos int `toml:"max_repos"`
+	// IndexMemoryWarning is the estimated total index memory in bytes above which a warning is logged,
+	// defaults to 1 GiB, disabled if negative.
+	IndexMemoryWarning int64 `toml:"index_memory_warning"`
+}
+
+// Defaults completes the section with default values.
+func (l *Limits) Defaults() *Limits {
+	if l == nil {
+		l = &Limits{}
+	}
+	if l.IndexMemoryWarning == 0 {
+		l.IndexMemoryWarning = 1 << 30
+	}
+
+	return l
+}
+
 // HTTP is an HTTP configuration section of the configuration file.
 type HTTP struct {
 	// Nero is the nero API configuration section.
 	Nero *HTTPServer `toml:"nero"`
 	// Nekos is the nekos API configuration section.
 	Nekos *HTTPServer `toml:"nekos"`
+	// LameDuck is the duration new uploads are rejected for before shutting down,
+	// while in-flight uploads finish and other requests are still served, disabled if zero.
+	LameDuck time.Duration `toml:"lame_duck"`
 }
 
 // Defaults completes the section with default values.
@@ -52,6 +142,22 @@ type HTTPServer struct {
 	Host string `toml:"host"`
 	// BaseURL is the base URL of the server, guessed if empty.
 	BaseURL string `toml:"base_url"`
+	// H2C is whether HTTP/2 should be served over cleartext TCP connections (h2c).
+	H2C bool `toml:"h2c"`
+	// DisableKeepAlives is whether HTTP keep-alives should be disabled, connections are closed after each request.
+	DisableKeepAlives bool `toml:"disable_keep_alives"`
+	// IdleTimeout is the maximum amount of time to wait for the next request on a kept-alive connection,
+	// falls back to the read timeout of the server (none) if zero.
+	IdleTimeout time.Duration `toml:"idle_timeout"`
+	// MaxConnections is the maximum amount of concurrently served connections, unlimited if zero.
+	// Further connections wait to be accepted until others are closed, including idle kept-alive connections.
+	MaxConnections int `toml:"max_connections"`
+	// Hosts maps host names to IDs of repositories served directly on that host, outside of the API base path,
+	// e.g. "anime.example.com" = "anime" serves /random like /api/v1/repos/anime/random. Only supported by the nero API.
+	Hosts map[string]string `toml:"hosts"`
+	// Metrics is whether repository metrics should be served at /metrics in the Prometheus text exposition format.
+	// Only supported by the nero API.
+	Metrics bool `toml:"metrics"`
 }
 
 // Defaults completes the section with default values.
@@ -64,25 +170,321 @@ func (hs *HTTPServer) Enabled() bool {
 	return hs.Host != ""
 }
 
+// IndexDirName is the name of the index subdirectory of repository directories, see Repo.IndexDir.
+const IndexDirName = ".nero"
+
 // Repo is a base repository configuration.
 type Repo struct {
 	// Path is the relative or absolute path of the repository's directory.
 	Path string `toml:"path"`
-	// LockPath is the relative or absolute path of the repository's lock file.
+	// LockPath is the relative or absolute path of the repository's lock file,
+	// nero.lock in the repository's directory (or its index directory, see IndexDir) if empty.
 	LockPath string `toml:"lock_path"`
+	// IndexDir is whether the lock file and its sidecar files should be kept in the dedicated .nero subdirectory
+	// of the repository's directory by default, separate from media. Has no effect if LockPath is set.
+	IndexDir bool `toml:"index_dir"`
 	// Meta is the repository metadata.
 	Meta map[string]string `toml:"meta"`
+	// Symlinks is the policy for media paths that are symbolic links, "follow" (default) or "reject".
+	Symlinks string `toml:"symlinks"`
+	// RemoveSymlinkTargets is whether deleting media stored as a symbolic link should also remove the link target.
+	RemoveSymlinkTargets bool `toml:"remove_symlink_targets"`
+	// UUIDFormat is the format of media IDs written to the index file, "dashed" (default), "compact" or "upper".
+	UUIDFormat string `toml:"uuid_format"`
+	// ReportOrphans is whether files in the repository directory not referenced by the index should be reported on load.
+	ReportOrphans bool `toml:"report_orphans"`
+	// Durability is the durability level of index and media file writes, "none" (default), "fsync" or "fsync+dir".
+	// "none" leaves flushing to the operating system, "fsync" flushes written files
+	// and "fsync+dir" also flushes their directories after creating or renaming them.
+	Durability string `toml:"durability"`
+	// EphemeralIndex is whether index changes should only be kept in memory, leaving the index file untouched.
+	EphemeralIndex bool `toml:"ephemeral_index"`
+	// FormatDirs is whether new media files should be stored in per-format subdirectories, e.g. "images" and "videos".
+	FormatDirs bool `toml:"format_dirs"`
+	// ScanExisting is whether files in the repository directory should be indexed if there is no index file yet.
+	ScanExisting bool `toml:"scan_existing"`
+	// AllowDegraded is whether the repository should start with the readable items of a partially corrupt index.
+	AllowDegraded bool `toml:"allow_degraded"`
+	// LoadWarningThreshold is the amount of items skipped while loading the index, e.g. because of missing files,
+	// above which the repository is reported as degraded, disabled if zero.
+	LoadWarningThreshold int `toml:"load_warning_threshold"`
+	// LoadSummary is the verbosity of the index load summary logged by the server at startup,
+	// "always" (default), "warnings", which logs it only if items were skipped, or "off".
+	LoadSummary string `toml:"load_summary"`
+	// IndexBackups is the amount of timestamped index file backups kept across saves, disabled if zero.
+	IndexBackups int `toml:"index_backups"`
+	// CanonicalIndex is whether the index file should be written deterministically, ordered by creation time and ID,
+	// so it can be diffed, e.g. when committed to version control.
+	CanonicalIndex bool `toml:"canonical_index"`
+	// PathCollision is the policy for index items sharing a file path, "keep_first" (default) or "error".
+	PathCollision string `toml:"path_collision"`
+	// ValidateImages is whether uploaded images should be fully decoded to reject corrupt ones.
+	ValidateImages bool `toml:"validate_images"`
+	// MinWidth is the minimum width of uploaded images in pixels, unlimited if zero.
+	MinWidth int `toml:"min_width"`
+	// MinHeight is the minimum height of uploaded images in pixels, unlimited if zero.
+	MinHeight int `toml:"min_height"`
+	// MaxWidth is the maximum width of uploaded images in pixels, unlimited if zero.
+	MaxWidth int `toml:"max_width"`
+	// MaxHeight is the maximum height of uploaded images in pixels, unlimited if zero.
+	MaxHeight int `toml:"max_height"`
+	// MaxFrames is the maximum frame count of uploaded animated images, unlimited if zero.
+	MaxFrames int `toml:"max_frames"`
+	// SVG is the policy for uploaded SVG images, "inline" (default), "sanitize" or "attachment".
+	SVG string `toml:"svg"`
+	// AutoOrient is whether uploaded JPEG images should be rotated upright according to their EXIF orientation.
+	AutoOrient bool `toml:"auto_orient"`
+	// StoreMIME is whether the detected MIME type of new media should be stored in the index and served.
+	StoreMIME bool `toml:"store_mime"`
+	// KeepExtension is whether the extension of an uploaded file name should be kept if it matches the content type.
+	KeepExtension bool `toml:"keep_extension"`
+	// DefaultLimit is the amount of media listed when no query parameters are given, unlimited if zero.
+	DefaultLimit int `toml:"default_limit"`
+	// DefaultOrder is the sort order of media listed when no query parameters are given,
+	// "created_asc" (default), "created_desc" or "popular".
+	DefaultOrder string `toml:"default_order"`
+	// MetaParsing is the policy for parsing metadata sent by clients, "strict" (default) or "lenient",
+	// which ignores unknown fields and stores metadata of unknown types as generic metadata.
+	MetaParsing string `toml:"meta_parsing"`
+	// StrictUploads is whether upload requests with unknown fields should be rejected, instead of ignoring the fields.
+	StrictUploads bool `toml:"strict_uploads"`
+	// MetaTypes are the metadata types allowed in the repository, "generic" or "anime", all types are allowed if empty.
+	MetaTypes []string `toml:"meta_types"`
+	// IndexedFields are the metadata fields media is indexed by for exact lookups, "artist", "source" or "name".
+	IndexedFields []string `toml:"indexed_fields"`
+	// HideUnknown is whether media of an unknown format should be left out of listings and random picks by default.
+	HideUnknown bool `toml:"hide_unknown"`
+	// EmptyRandomNotFound is whether random picks without any eligible media should be served as not found (404),
+	// instead of an empty list.
+	EmptyRandomNotFound bool `toml:"empty_random_not_found"`
+	// PinnedFirst is whether pinned media should be listed before other media.
+	PinnedFirst bool `toml:"pinned_first"`
+	// CountDownloads is whether served media should be counted, enabling the "popular" sort order.
+	CountDownloads bool `toml:"count_downloads"`
+	// CounterSaveInterval is the interval of persisting download counters, defaults to 1 minute.
+	CounterSaveInterval time.Duration `toml:"counter_save_interval"`
+	// TrackAccess is whether the last access time of served media should be recorded.
+	TrackAccess bool `toml:"track_access"`
+	// AccessSaveInterval is the interval of persisting last access times, defaults to 1 minute.
+	AccessSaveInterval time.Duration `toml:"access_save_interval"`
+	// CacheSize is the maximum size of the in-memory media cache in bytes, caching is disabled if zero.
+	CacheSize int64 `toml:"cache_size"`
+	// Dedup is whether new media content should be moved into the shared store (see Config.Dedup),
+	// storing content identical across the repositories using it once.
+	Dedup bool `toml:"dedup"`
+	// Prefetch is the amount of media following served media, e.g. the next pages of a manga chapter,
+	// loaded into the media cache in the background, disabled if zero, at most 16. Requires CacheSize.
+	Prefetch int `toml:"prefetch"`
+	// ListCacheTTL is the time listings are cached for, caching is disabled if zero.
+	// Cached listings are dropped on any change of the repository.
+	ListCacheTTL time.Duration `toml:"list_cache_ttl"`
+	// ListCacheSize is the maximum amount of cached listings, defaults to 64.
+	ListCacheSize int `toml:"list_cache_size"`
+	// Upstream is the pull-through upstream configuration section, disabled if nil.
+	Upstream *Upstream `toml:"upstream"`
+	// TTL is the time media is kept for after its creation before it's removed, unless overridden on upload.
+	// Media is kept forever by default.
+	TTL time.Duration `toml:"ttl"`
+	// SweepInterval is the interval of removing expired media, defaults to 1 minute, disabled if negative.
+	SweepInterval time.Duration `toml:"sweep_interval"`
+	// Quota is the maximum total size of the media content in bytes, unlimited if zero.
+	Quota int64 `toml:"quota"`
+	// MinFreeSpace is the free disk space in bytes below which uploads are rejected, disabled if zero.
+	MinFreeSpace int64 `toml:"min_free_space"`
+	// SoftQuota is the total size of the media content in bytes at which a usage warning is reported, disabled if zero.
+	SoftQuota int64 `toml:"soft_quota"`
+	// SoftItemLimit is the amount of media at which a usage warning is reported, disabled if zero.
+	SoftItemLimit int `toml:"soft_item_limit"`
+	// Normalize is the metadata normalization configuration section, normalization is disabled if nil.
+	Normalize *Normalize `toml:"normalize"`
+	// Transcode is the animated image transcoding configuration section, transcoding is disabled if nil.
+	Transcode *Transcode `toml:"transcode"`
+	// WebP is the WebP conversion configuration section, conversion is disabled if nil.
+	WebP *WebP `toml:"webp"`
+	// Resize is the on-the-fly resizing configuration section, resizing is disabled if nil.
+	Resize *Resize `toml:"resize"`
+	// Thumbnail is the thumbnail configuration section, animated WebP images have no thumbnails if nil.
+	Thumbnail *Thumbnail `toml:"thumbnail"`
+	// Hook is the upload hook configuration section, the hook is disabled if nil.
+	Hook *Hook `toml:"hook"`
+	// Uploader is the uploader client info recording configuration section, recording is disabled if nil.
+	Uploader *Uploader `toml:"uploader"`
+	// WriteBufferSize is the size of the copy buffer of streamed uploads in bytes, 256 KiB if zero.
+	WriteBufferSize int `toml:"write_buffer_size"`
+	// UploadConcurrency is the maximum amount of concurrently handled uploads, unlimited if zero.
+	UploadConcurrency int `toml:"upload_concurrency"`
+	// UploadQueueTimeout is the maximum time an upload waits for a free slot, uploads are rejected immediately if zero.
+	UploadQueueTimeout time.Duration `toml:"upload_queue_timeout"`
 }
 
 // Defaults completes the configuration with default values.
 func (r *Repo) Defaults() *Repo {
 	if r.LockPath == "" {
-		r.LockPath = filepath.Join(r.Path, "nero.lock")
+		if r.IndexDir {
+			r.LockPath = filepath.Join(r.Path, IndexDirName, "nero.lock")
+		} else {
+			r.LockPath = filepath.Join(r.Path, "nero.lock")
+		}
+	}
+	if r.Transcode != nil {
+		r.Transcode = r.Transcode.Defaults()
+	}
+	if r.WebP != nil {
+		r.WebP = r.WebP.Defaults()
+	}
+	if r.Resize != nil {
+		r.Resize = r.Resize.Defaults()
+	}
+	if r.Thumbnail != nil {
+		r.Thumbnail = r.Thumbnail.Defaults()
+	}
+	if r.Hook != nil {
+		r.Hook = r.Hook.Defaults()
+	}
+	if r.Upstream != nil {
+		r.Upstream = r.Upstream.Defaults()
+	}
+
+	return r
+}
+
+// Hook is an upload hook configuration section of a repository.
+type Hook struct {
+	// Command is the executable and its leading arguments, the media ID, path and format are appended on invocation.
+	Command []string `toml:"command"`
+	// Concurrency is the maximum amount of concurrently running commands, defaults to 1.
+	Concurrency int `toml:"concurrency"`
+	// Timeout is the maximum run time of a command, defaults to 1 minute.
+	Timeout time.Duration `toml:"timeout"`
+}
+
+// Defaults completes the section with default values.
+func (h *Hook) Defaults() *Hook {
+	if h.Concurrency <= 0 {
+		h.Concurrency = 1
+	}
+	if h.Timeout == 0 {
+		h.Timeout = time.Minute
+	}
+
+	return h
+}
+
+// WebP is an on-the-fly WebP conversion configuration section of a repository.
+// Still PNG and JPEG images are served as WebP to clients accepting it.
+type WebP struct {
+	// FFmpeg is the path of the ffmpeg executable, defaults to looking up "ffmpeg" in PATH.
+	FFmpeg string `toml:"ffmpeg"`
+	// Quality is the lossy compression quality from 0 to 100, the encoder default if zero.
+	Quality int `toml:"quality"`
+	// CacheSize is the maximum size of the in-memory cache of conversions in bytes, defaults to 64 MiB.
+	CacheSize int64 `toml:"cache_size"`
+}
+
+// Defaults completes the section with default values.
+func (w *WebP) Defaults() *WebP {
+	if w.FFmpeg == "" {
+		w.FFmpeg = "ffmpeg"
+	}
+
+	return w
+}
+
+// Resize is an on-the-fly resizing configuration section of a repository.
+// Still images are served resized to the width and height requested in the query.
+type Resize struct {
+	// MaxSize is the maximum width and height of resized images in pixels, defaults to 2048.
+	MaxSize int `toml:"max_size"`
+	// Sizes are the allowed widths and heights of resized images, any size up to MaxSize if empty.
+	Sizes []int `toml:"sizes"`
+	// CacheSize is the maximum size of the in-memory cache of resized images in bytes, defaults to 64 MiB.
+	CacheSize int64 `toml:"cache_size"`
+}
+
+// Defaults completes the section with default values.
+func (r *Resize) Defaults() *Resize {
+	if r.MaxSize <= 0 {
+		r.MaxSize = 2048
 	}
 
 	return r
 }
 
+// Thumbnail is a thumbnail rendering configuration section of a repository.
+type Thumbnail struct {
+	// FFmpeg is the path of the ffmpeg executable rendering the first frames of animated WebP images,
+	// defaults to looking up "ffmpeg" in PATH.
+	FFmpeg string `toml:"ffmpeg"`
+	// CacheSize is the maximum size of the in-memory cache of thumbnails in bytes, defaults to 16 MiB.
+	CacheSize int64 `toml:"cache_size"`
+}
+
+// Defaults completes the section with default values.
+func (t *Thumbnail) Defaults() *Thumbnail {
+	if t.FFmpeg == "" {
+		t.FFmpeg = "ffmpeg"
+	}
+
+	return t
+}
+
+// Transcode is an animated image to video transcoding configuration section of a repository.
+type Transcode struct {
+	// FFmpeg is the path of the ffmpeg executable, defaults to looking up "ffmpeg" in PATH.
+	FFmpeg string `toml:"ffmpeg"`
+	// Threshold is the size in bytes an animated image needs to exceed to be transcoded.
+	Threshold int64 `toml:"threshold"`
+	// KeepOriginal is whether the original animated image should be kept alongside the video.
+	KeepOriginal bool `toml:"keep_original"`
+}
+
+// Defaults completes the section with default values.
+func (t *Transcode) Defaults() *Transcode {
+	if t.FFmpeg == "" {
+		t.FFmpeg = "ffmpeg"
+	}
+
+	return t
+}
+
+// Upstream is a pull-through upstream configuration section of a repository.
+type Upstream struct {
+	// URL is the base URL of the upstream nero v1 API, i.e. https://example.com/api/v1.
+	URL string `toml:"url"`
+	// Repo is the ID of the upstream repository, defaults to the local repository ID.
+	Repo string `toml:"repo"`
+	// Timeout is the maximum duration of a single fetch, defaults to 5 minutes.
+	Timeout time.Duration `toml:"timeout"`
+}
+
+// Defaults completes the section with default values.
+func (u *Upstream) Defaults() *Upstream {
+	if u.Timeout == 0 {
+		u.Timeout = 5 * time.Minute
+	}
+
+	return u
+}
+
+// Uploader is an uploader client info recording configuration section of a repository.
+// The IP address and user agent of uploaders are stored with new media, for investigating abuse.
+type Uploader struct {
+	// HashIP is whether IP addresses should be stored as salted SHA-256 hashes.
+	HashIP bool `toml:"hash_ip"`
+	// Salt is the salt of hashed IP addresses.
+	Salt string `toml:"salt"`
+}
+
+// Normalize is a metadata normalization configuration section of a repository.
+type Normalize struct {
+	// Trim is whether leading and trailing whitespace should be removed.
+	Trim bool `toml:"trim"`
+	// CollapseWhitespace is whether runs of whitespace should be replaced with a single space, implies trimming.
+	CollapseWhitespace bool `toml:"collapse_whitespace"`
+	// Lowercase is whether names should be converted to lower case.
+	Lowercase bool `toml:"lowercase"`
+}
+
 // Parse parses the configuration from a file.
 func Parse(path string) (*Config, error) {
 	var cfg Config
@@ -93,12 +495,18 @@ func Parse(path string) (*Config, error) {
 	return &cfg, nil
 }
 
-// ParseWithDefaults parses the configuration from a file and completes it with default values (Section.Defaults).
+// ParseWithDefaults parses the configuration from a file, completes it with default values (Section.Defaults)
+// and validates it.
 func ParseWithDefaults(path string) (*Config, error) {
 	cfg, err := Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return cfg.Defaults(), nil
+	cfg = cfg.Defaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
@@ -13,6 +13,9 @@ require (
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/exp v0.0.0-20240416160154-fe59bbe5cc7f
+	golang.org/x/image v0.18.0
+	golang.org/x/net v0.24.0
+	golang.org/x/sync v0.7.0
 )
 
 require (
@@ -20,5 +23,5 @@ require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.4 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/xrash/smetrics v0.0.0-20240312152122-5f08fbb34913 // indirect
-	golang.org/x/net v0.24.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
 )
@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DefaultAccessSaveInterval is the default interval of persisting last access times.
+const DefaultAccessSaveInterval = time.Minute
+
+// accessTimes is a set of per-media last access times, persisted to a file next to the index file.
+type accessTimes = ledger[time.Time]
+
+// loadAccessTimes loads the last access times persisted at a path, if it exists.
+func loadAccessTimes(path string) (*accessTimes, error) {
+	return loadLedger[time.Time](path, "last access times")
+}
+
+// Touch updates the last access time of media to now, does nothing if access tracking is disabled.
+// The change is persisted in the background, see Options.AccessSaveInterval.
+func (r *Repository) Touch(id uuid.UUID) {
+	if r.accessTimes == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	r.accessTimes.update(id, func(t time.Time) (time.Time, bool) {
+		return now, now.After(t)
+	})
+}
+
+// LastAccessed returns the last access time of media, zero if it is unknown or access tracking is disabled.
+func (r *Repository) LastAccessed(id uuid.UUID) time.Time {
+	if r.accessTimes == nil {
+		return time.Time{}
+	}
+	return r.accessTimes.get(id)
+}
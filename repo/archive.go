@@ -0,0 +1,494 @@
+package repo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveIndexName is the name of the index entry in repository archives, always the first entry.
+const ArchiveIndexName = "index.ndjson"
+
+// ArchiveFormat is a repository archive file format.
+type ArchiveFormat string
+
+const (
+	// ArchiveTar is an uncompressed tar archive.
+	ArchiveTar ArchiveFormat = "tar"
+	// ArchiveZip is a zip archive.
+	ArchiveZip ArchiveFormat = "zip"
+)
+
+// archiveWriter is a sequential writer of archive entries.
+type archiveWriter interface {
+	// create starts a new entry, the returned writer is valid until the next call.
+	create(name string, size int64, modTime time.Time) (io.Writer, error)
+	// Close finishes the archive, without closing the underlying writer.
+	Close() error
+}
+
+type tarWriter struct {
+	*tar.Writer
+}
+
+func (tw *tarWriter) create(name string, size int64, modTime time.Time) (io.Writer, error) {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+	})
+	return tw.Writer, err
+}
+
+type zipWriter struct {
+	*zip.Writer
+}
+
+func (zw *zipWriter) create(name string, _ int64, modTime time.Time) (io.Writer, error) {
+	return zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Store, // media is compressed already
+		Modified: modTime,
+	})
+}
+
+// Export streams media files into an archive, preceded by an index entry (ArchiveIndexName)
+// holding one JSON item per line, with paths relative to the archive root.
+func (r *Repository) Export(w io.Writer, format ArchiveFormat, items []*media.Media) (err error) {
+	var aw archiveWriter
+	switch format {
+	case ArchiveTar:
+		aw = &tarWriter{tar.NewWriter(w)}
+	case ArchiveZip:
+		aw = &zipWriter{zip.NewWriter(w)}
+	default:
+		return fmt.Errorf("unknown archive format %q", format)
+	}
+	defer func() {
+		if err0 := aw.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to finish archive"))
+		}
+	}()
+
+	var (
+		index bytes.Buffer
+		codec JSONCodec
+	)
+	for _, m := range items {
+		m0 := *m
+		m0.Path = archiveName(m)
+		m0.Original = "" // originals aren't exported
+
+		b, err := codec.Marshal(&m0)
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize index item")
+		}
+
+		index.Write(append(b, '\n'))
+	}
+
+	ew, err := aw.create(ArchiveIndexName, int64(index.Len()), time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to create index entry")
+	}
+	if _, err = index.WriteTo(ew); err != nil {
+		return errors.Wrap(err, "failed to write index entry")
+	}
+
+	for _, m := range items {
+		if err = exportFile(aw, archiveName(m), m.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archiveName returns the name of the archive entry of media, its ID followed by the extension of its content file.
+// Shared content files are named by their content hash, which isn't unique across media.
+func archiveName(m *media.Media) string {
+	return m.ID.String() + filepath.Ext(m.Path)
+}
+
+// exportFile copies a file into an archive entry.
+func exportFile(aw archiveWriter, name, path string) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open media")
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close media"))
+		}
+	}()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to stat media")
+	}
+
+	ew, err := aw.create(name, fi.Size(), fi.ModTime())
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive entry")
+	}
+	if _, err = io.Copy(ew, f); err != nil {
+		return errors.Wrap(err, "failed to write archive entry")
+	}
+
+	return nil
+}
+
+// archiveReader is a sequential reader of the regular file entries of an archive.
+type archiveReader interface {
+	// next returns the name and content of the next entry, the content is valid until the next call.
+	// Returns io.EOF at the end of the archive.
+	next() (string, io.Reader, error)
+	// Close releases the last entry, without closing the underlying reader.
+	Close() error
+}
+
+type tarReader struct {
+	*tar.Reader
+}
+
+func (tr *tarReader) next() (string, io.Reader, error) {
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			return "", nil, err
+		}
+		if h.Typeflag == tar.TypeReg {
+			return h.Name, tr.Reader, nil
+		}
+	}
+}
+
+func (tr *tarReader) Close() error {
+	return nil
+}
+
+type zipReader struct {
+	files []*zip.File
+	entry io.ReadCloser // nil before the first entry
+}
+
+func (zr *zipReader) next() (string, io.Reader, error) {
+	if err := zr.Close(); err != nil {
+		return "", nil, err
+	}
+
+	for len(zr.files) > 0 {
+		f := zr.files[0]
+		zr.files = zr.files[1:]
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", nil, err
+		}
+		zr.entry = rc
+		return f.Name, rc, nil
+	}
+
+	return "", nil, io.EOF
+}
+
+func (zr *zipReader) Close() error {
+	if zr.entry == nil {
+		return nil
+	}
+
+	err := zr.entry.Close()
+	zr.entry = nil
+	return err
+}
+
+// openArchive opens an archive for reading its entries sequentially.
+// Zip archives are indexed at their end, so they're spooled to a temporary file in the repository directory first,
+// which is removed by the returned cleanup function.
+func (r *Repository) openArchive(rd io.Reader, format ArchiveFormat) (archiveReader, func() error, error) {
+	switch format {
+	case ArchiveTar:
+		return &tarReader{tar.NewReader(rd)}, func() error { return nil }, nil
+	case ArchiveZip:
+	default:
+		return nil, nil, fmt.Errorf("unknown archive format %q", format)
+	}
+
+	if err := r.ensureDir(); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.CreateTemp(r.path, ".upload-*")
+	if err != nil {
+		return nil, nil, storageError(errors.Wrap(err, "failed to create temporary file"))
+	}
+	cleanup := func() error {
+		err := f.Close()
+		if err0 := os.Remove(f.Name()); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to remove temporary file"))
+		}
+		return err
+	}
+
+	size, err := io.Copy(f, rd)
+	if err != nil {
+		return nil, nil, multierr.Append(storageError(errors.Wrap(err, "failed to write temporary file")), cleanup())
+	}
+	zr, err := zip.NewReader(f, size)
+	if err != nil {
+		return nil, nil, multierr.Append(&ErrInvalidMedia{Reason: "malformed archive", Err: err}, cleanup())
+	}
+
+	return &zipReader{files: zr.File}, cleanup, nil
+}
+
+// ImportPolicy is a policy for handling archive items whose ID is already in the repository.
+type ImportPolicy string
+
+const (
+	// ImportSkip skips the archive item, the default.
+	ImportSkip ImportPolicy = "skip"
+	// ImportOverwrite replaces the existing item.
+	ImportOverwrite ImportPolicy = "overwrite"
+	// ImportNewID imports the archive item under a new ID.
+	ImportNewID ImportPolicy = "new_id"
+)
+
+// Import reads an archive produced by Export and inserts the contained media into the repository.
+// Archive files without an index item are skipped.
+// Returns the amount of imported and skipped media.
+func (r *Repository) Import(ctx context.Context, rd io.Reader, format ArchiveFormat, policy ImportPolicy) (imported, skipped int, err error) {
+	if r.path == "" {
+		return 0, 0, errors.ErrUnsupported
+	}
+
+	switch policy {
+	case "", ImportSkip, ImportOverwrite, ImportNewID:
+	default:
+		return 0, 0, fmt.Errorf("unknown import policy %q", policy)
+	}
+
+	ar, cleanup, err := r.openArchive(rd, format)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err0 := multierr.Append(ar.Close(), cleanup()); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close archive"))
+		}
+	}()
+	defer func() {
+		if imported == 0 {
+			return
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		// persist imported items even if the import was cancelled
+		if err0 := r.save(context.Background()); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to save imported items"))
+		}
+	}()
+
+	var items map[string]*media.Media // keyed by archive file name
+	for {
+		if err := ctx.Err(); err != nil {
+			return imported, skipped, err
+		}
+
+		name, er, err := ar.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imported, skipped, &ErrInvalidMedia{Reason: "malformed archive", Err: err}
+		}
+
+		if items == nil {
+			if name != ArchiveIndexName {
+				return imported, skipped, &ErrInvalidMedia{Reason: "archive doesn't start with an index"}
+			}
+			if items, err = readArchiveIndex(er); err != nil {
+				return imported, skipped, err
+			}
+			continue
+		}
+
+		m, ok := items[name]
+		if !ok {
+			r.logger.Warn("skipping unindexed archive file", zap.String("repo", r.id), zap.String("name", name))
+			skipped++
+			continue
+		}
+
+		b, err := io.ReadAll(er)
+		if err != nil {
+			return imported, skipped, &ErrInvalidMedia{Reason: "malformed archive", Err: err}
+		}
+
+		ok, err = r.importItem(ctx, m, filepath.Ext(name), b, policy)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if ok {
+			imported++
+		} else {
+			skipped++
+		}
+	}
+
+	return imported, skipped, nil
+}
+
+// readArchiveIndex reads the index entry of an archive.
+func readArchiveIndex(rd io.Reader) (map[string]*media.Media, error) {
+	var (
+		items = make(map[string]*media.Media)
+		codec JSONCodec
+		s     = bufio.NewScanner(rd)
+	)
+	for s.Scan() {
+		if s.Text() == "" {
+			continue // skip empty lines
+		}
+
+		var m media.Media
+		if err := codec.Unmarshal(s.Bytes(), &m); err != nil {
+			return nil, &ErrInvalidMedia{Reason: "malformed archive index item", Err: err}
+		}
+
+		items[m.Path] = &m
+	}
+	if err := s.Err(); err != nil {
+		return nil, &ErrInvalidMedia{Reason: "malformed archive index", Err: err}
+	}
+
+	return items, nil
+}
+
+// overwrite replaces media with its replacement staged under a temporary ID, renaming the content files of the replacement
+// to the ID of the media, without persisting the index. The content files of the replaced media are removed afterwards.
+func (r *Repository) overwrite(old, m0 *media.Media, id uuid.UUID) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cur, ok := r.items[id]; ok && cur != old { // overwritten concurrently
+		return multierr.Append(&ErrDuplicateID{ID: id.String(), Repo: r.id}, r.removeContent(m0))
+	}
+
+	m1 := *m0
+	m1.ID = id
+	shared := r.opts.Shared.contains(m0.Path)
+	if m0.Original != "" {
+		if m1.Original, err = renameID(m0.Original, m0.ID, id); err != nil {
+			return multierr.Append(err, r.removeContent(m0))
+		}
+	}
+	if !shared {
+		if m1.Path, err = renameID(m0.Path, m0.ID, id); err != nil {
+			m0.Original = m1.Original
+			return multierr.Append(err, r.removeContent(m0))
+		}
+	}
+
+	if cur, ok := r.items[id]; ok {
+		r.drop(cur)
+	}
+	r.items[id] = &m1
+	r.index(&m1)
+
+	// files of the replacement may have taken the place of the old ones already
+	if r.opts.Shared.contains(old.Path) {
+		err = r.opts.Shared.release(r.id, old.ID, old.Path)
+	} else if old.Path != m1.Path {
+		err = removeFiles(old.Path)
+	}
+	if old.Original != m1.Original {
+		err = multierr.Append(err, removeFiles(old.Original))
+	}
+	if shared {
+		err = multierr.Append(err, r.opts.Shared.rename(r.id, m0.ID, id, m1.Path))
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to remove overwritten media")
+	}
+	return nil
+}
+
+// renameID renames a content file named by a media ID to another ID, returning the new path.
+func renameID(path string, from, to uuid.UUID) (string, error) {
+	newPath := filepath.Join(filepath.Dir(path), strings.Replace(filepath.Base(path), from.String(), to.String(), 1))
+	if err := os.Rename(path, newPath); err != nil {
+		return "", storageError(errors.Wrap(err, "failed to rename media file"))
+	}
+	return newPath, nil
+}
+
+// importItem stages the content of an archive item like Create and inserts it into the repository
+// without persisting the index. The ID, metadata, creation time, expiry and collection (if it exists) of the item are kept,
+// the rest is derived from the content again. Returns false if the item was skipped due to the policy.
+func (r *Repository) importItem(ctx context.Context, m *media.Media, ext string, b []byte, policy ImportPolicy) (bool, error) {
+	opts := &CreateOptions{Filename: m.ID.String() + ext, Expiry: m.ExpiresAt, id: m.ID}
+	if m.Collection != "" && r.checkCollection(m.Collection) == nil {
+		opts.Collection = m.Collection
+	}
+
+	existing := r.Get(m.ID)
+	if existing != nil {
+		switch policy {
+		case ImportOverwrite:
+			// staged under a temporary ID, the existing item is only replaced once its replacement is stored
+			opts.id = uuid.Nil
+		case ImportNewID:
+			opts.id = uuid.Nil
+			existing = nil
+		default:
+			return false, nil
+		}
+	}
+
+	m0, err := r.stage(ctx, b, m.Meta, opts, 0)
+	if err != nil {
+		return false, err
+	}
+	defer r.releaseID(m0.ID)
+
+	if !m.CreatedAt.IsZero() {
+		m0.CreatedAt = m.CreatedAt
+	}
+	if existing != nil {
+		return true, r.overwrite(existing, m0, m.ID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[m0.ID]; ok { // imported concurrently
+		return false, multierr.Append(&ErrDuplicateID{ID: m0.ID.String(), Repo: r.id}, r.removeContent(m0))
+	}
+	if r.items == nil {
+		r.items = make(map[uuid.UUID]*media.Media, 1)
+	}
+
+	r.items[m0.ID] = m0
+	r.index(m0)
+	return true, nil
+}
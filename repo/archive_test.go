@@ -0,0 +1,291 @@
+package repo
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testArchive writes a tar archive with an index of media and their content, keyed by the archive file name.
+func testArchive(t *testing.T, items []*media.Media, files map[string][]byte) []byte {
+	t.Helper()
+
+	var index bytes.Buffer
+	for _, m := range items {
+		b, err := JSONCodec{}.Marshal(m)
+		if err != nil {
+			t.Fatalf("failed to serialize media: %v", err)
+		}
+		index.Write(append(b, '\n'))
+	}
+
+	var (
+		buf bytes.Buffer
+		tw  = tar.NewWriter(&buf)
+	)
+	write := func(name string, b []byte) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(b)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write archive entry: %v", err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			t.Fatalf("failed to write archive entry: %v", err)
+		}
+	}
+
+	write(ArchiveIndexName, index.Bytes())
+	for name, b := range files {
+		write(name, b)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to finish archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImport(t *testing.T) {
+	var (
+		src = newTestRepo(t, nil)
+		a   = mustCreate(t, src, testPNG(t, 2, 2), nil)
+		b   = mustCreate(t, src, testPNG(t, 3, 3), nil)
+	)
+
+	// client-supplied serving attributes aren't trusted
+	b0 := *b
+	b0.ContentType = "text/html"
+	b0.MIME = "text/html"
+	b0.Pinned = true
+	b0.Uploader = &media.Uploader{IP: "127.0.0.1"}
+	b0.CreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf, ArchiveTar, []*media.Media{a, &b0}); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	archive := buf.Bytes()
+
+	dst := newTestRepo(t, nil)
+	imported, skipped, err := dst.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportSkip)
+	if err != nil || imported != 2 || skipped != 0 {
+		t.Fatalf("Import = %d imported, %d skipped (%v), want 2 imported", imported, skipped, err)
+	}
+
+	m := dst.Get(b.ID)
+	if m == nil {
+		t.Fatal("imported media isn't in the repository")
+	}
+	if m.ContentType != "" || m.MIME != "" || m.Pinned || m.Uploader != nil {
+		t.Errorf("imported media kept client-supplied attributes: %+v", m)
+	}
+	if m.Hash != b.Hash || m.Width != 3 || !m.CreatedAt.Equal(b0.CreatedAt) {
+		t.Errorf("imported media = %+v, want the hash and dimensions of %s created at %s", m, b.ID, b0.CreatedAt)
+	}
+
+	imported, skipped, err = dst.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportSkip)
+	if err != nil || imported != 0 || skipped != 2 {
+		t.Errorf("second Import = %d imported, %d skipped (%v), want 2 skipped", imported, skipped, err)
+	}
+}
+
+func TestImportZip(t *testing.T) {
+	var (
+		src = newTestRepo(t, nil)
+		a   = mustCreate(t, src, testPNG(t, 2, 2), nil)
+		b   = mustCreate(t, src, testGIF(t, 2, 2, 2), nil)
+	)
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf, ArchiveZip, []*media.Media{a, b}); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	dst := newTestRepo(t, nil)
+	imported, skipped, err := dst.Import(context.Background(), bytes.NewReader(buf.Bytes()), ArchiveZip, ImportSkip)
+	if err != nil || imported != 2 || skipped != 0 {
+		t.Fatalf("Import = %d imported, %d skipped (%v), want 2 imported", imported, skipped, err)
+	}
+	for _, m := range []*media.Media{a, b} {
+		if m0 := dst.Get(m.ID); m0 == nil || m0.Hash != m.Hash {
+			t.Errorf("imported media = %+v, want the content of %s", m0, m.ID)
+		}
+	}
+
+	// the spooled archive is removed
+	entries, err := os.ReadDir(dst.Path())
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".upload-") {
+			t.Errorf("temporary file %s was left behind", e.Name())
+		}
+	}
+
+	var eim *ErrInvalidMedia
+	if _, _, err := dst.Import(context.Background(), strings.NewReader("not a zip archive"), ArchiveZip, ImportSkip); !errors.As(err, &eim) {
+		t.Errorf("importing a malformed zip archive failed with %v, want ErrInvalidMedia", err)
+	}
+}
+
+func TestImportOverwrite(t *testing.T) {
+	var (
+		r = newTestRepo(t, &Options{CacheSize: 1 << 20})
+		m = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+
+	read := func() []byte {
+		f, err := r.Open(context.Background(), r.Get(m.ID))
+		if err != nil {
+			t.Fatalf("failed to open media: %v", err)
+		}
+		defer f.Close()
+
+		b, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatalf("failed to read media: %v", err)
+		}
+		return b
+	}
+	read() // cache the old content
+
+	b := testPNG(t, 5, 5)
+	archive := testArchive(t, []*media.Media{{ID: m.ID, Format: media.FormatImage, Path: m.ID.String() + ".png"}}, map[string][]byte{
+		m.ID.String() + ".png": b,
+	})
+	if _, _, err := r.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportOverwrite); err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+
+	if !bytes.Equal(read(), b) {
+		t.Error("overwritten media is served with its old content")
+	}
+	if r.GetByHash(m.Hash) != nil {
+		t.Error("overwritten media is still found by its old hash")
+	}
+	if m0 := r.Get(m.ID); m0 == nil || m0.Width != 5 {
+		t.Errorf("overwritten media = %+v, want the imported 5x5 image", m0)
+	}
+}
+
+func TestImportOverwriteFailure(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, &Options{ValidateImages: true})
+		m   = mustCreate(t, r, testPNG(t, 2, 2), nil)
+		b   = testPNG(t, 16, 16)
+	)
+	old, err := os.ReadFile(m.Path)
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+
+	// a replacement failing validation keeps the existing item
+	archive := testArchive(t, []*media.Media{{ID: m.ID, Format: media.FormatImage, Path: m.ID.String() + ".png"}}, map[string][]byte{
+		m.ID.String() + ".png": b[:len(b)/2],
+	})
+	if _, _, err := r.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportOverwrite); err == nil {
+		t.Fatal("importing an invalid replacement succeeded")
+	}
+	if r.Get(m.ID) != m {
+		t.Error("existing item was dropped")
+	}
+	if b, err := os.ReadFile(m.Path); err != nil || !bytes.Equal(b, old) {
+		t.Errorf("existing content was changed: %v", err)
+	}
+	if n := len(openTestRepo(t, dir, nil).Items()); n != 1 {
+		t.Errorf("expected the existing item to be persisted, got %d items", n)
+	}
+
+	// a replacement with another extension removes the old content file, leaving no temporary files behind
+	archive = testArchive(t, []*media.Media{{ID: m.ID, Format: media.FormatAnimatedImage, Path: m.ID.String() + ".gif"}}, map[string][]byte{
+		m.ID.String() + ".gif": testGIF(t, 2, 2, 2),
+	})
+	if _, _, err := r.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportOverwrite); err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	m0 := r.Get(m.ID)
+	if m0 == nil || m0.Path != filepath.Join(filepath.Dir(m0.Path), m.ID.String()+".gif") {
+		t.Fatalf("overwritten media = %+v, want the imported GIF named by its ID", m0)
+	}
+	if _, err := os.Stat(m.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("old content file wasn't removed: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(m0.Path))
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	for _, e := range entries {
+		if id, ok := media.ParseIDFromFilename(e.Name()); ok && id != m.ID {
+			t.Errorf("unexpected content file %s", e.Name())
+		}
+	}
+}
+
+func TestImportOverwriteShared(t *testing.T) {
+	ss, err := OpenSharedStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open shared store: %v", err)
+	}
+	var (
+		r = newTestRepo(t, &Options{Shared: ss})
+		b = testPNG(t, 2, 2)
+		m = mustCreate(t, r, b, nil)
+	)
+
+	for _, b := range [][]byte{b, testPNG(t, 3, 3)} {
+		archive := testArchive(t, []*media.Media{{ID: m.ID, Format: media.FormatImage, Path: m.ID.String() + ".png"}}, map[string][]byte{
+			m.ID.String() + ".png": b,
+		})
+		if _, _, err := r.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportOverwrite); err != nil {
+			t.Fatalf("failed to import: %v", err)
+		}
+
+		m0 := r.Get(m.ID)
+		if m0 == nil {
+			t.Fatal("overwritten media is missing")
+		}
+		if n := ss.References(m0.Hash); n != 1 {
+			t.Errorf("expected 1 reference to the replacement, got %d", n)
+		}
+		if _, err := os.Stat(m0.Path); err != nil {
+			t.Errorf("replacement content is missing: %v", err)
+		}
+	}
+	if n := ss.References(m.Hash); n != 0 {
+		t.Errorf("expected the old content to be released, got %d references", n)
+	}
+	if _, err := os.Stat(m.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("old shared file wasn't removed: %v", err)
+	}
+}
+
+func TestImportValidated(t *testing.T) {
+	var (
+		r  = newTestRepo(t, &Options{ValidateImages: true})
+		b  = testPNG(t, 16, 16)
+		id = uuid.New()
+		m  = &media.Media{ID: id, Format: media.FormatImage, Path: id.String() + ".png"}
+	)
+
+	archive := testArchive(t, []*media.Media{m}, map[string][]byte{m.Path: b[:len(b)/2]})
+	_, _, err := r.Import(context.Background(), bytes.NewReader(archive), ArchiveTar, ImportSkip)
+
+	var eim *ErrInvalidMedia
+	if !errors.As(err, &eim) {
+		t.Fatalf("Import error = %v, want ErrInvalidMedia", err)
+	}
+	if r.Get(m.ID) != nil {
+		t.Error("invalid archive item was imported")
+	}
+	if _, err := os.Stat(filepath.Join(r.Path(), m.Path)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("invalid archive item left its content file behind")
+	}
+}
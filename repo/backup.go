@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// backupTimeLayout is the time layout of index backup file names, sortable lexically.
+const backupTimeLayout = "20060102T150405.000000000Z"
+
+// backupIndex keeps the current index file as a timestamped backup, removing all but the newest backups.
+// The caller must hold the write lock.
+func (r *Repository) backupIndex() error {
+	var (
+		dir, base = filepath.Split(r.lockPath)
+		prefix    = base + "."
+		suffix    = ".bak"
+	)
+	if err := os.Link(r.lockPath, r.lockPath+"."+time.Now().UTC().Format(backupTimeLayout)+suffix); err != nil {
+		return errors.Wrap(err, "failed to back up index file")
+	}
+
+	des, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return errors.Wrap(err, "failed to list index file backups")
+	}
+
+	var names []string
+	for _, de := range des {
+		if name := de.Name(); strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	for len(names) > r.opts.IndexBackups {
+		if err := os.Remove(filepath.Join(dir, names[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "failed to remove index file backup")
+		}
+		names = names[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIndexBackups(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, &Options{IndexBackups: 2})
+	)
+	for i := 1; i <= 5; i++ {
+		mustCreate(t, r, testPNG(t, i, i), nil)
+	}
+
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list repository directory: %v", err)
+	}
+
+	var backups []string
+	for _, de := range des {
+		if strings.HasPrefix(de.Name(), "nero.lock.") && strings.HasSuffix(de.Name(), ".bak") {
+			backups = append(backups, de.Name())
+		}
+	}
+	if len(backups) != 2 {
+		t.Fatalf("index backups = %v, want the 2 newest", backups)
+	}
+
+	// the newest backup is the index before the last save
+	newest, err := os.ReadFile(filepath.Join(dir, backups[1]))
+	if err != nil {
+		t.Fatalf("failed to read index backup: %v", err)
+	}
+	if n := bytes.Count(newest, []byte("\n")); n != 4 {
+		t.Errorf("newest backup has %d items, want 4", n)
+	}
+}
@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"slices"
+)
+
+// RemoveBatch removes all media uploaded in an import batch (CreateOptions.Batch), like Remove,
+// and persists the index once. Returns the removed media, ordered by their creation time.
+func (r *Repository) RemoveBatch(ctx context.Context, batch string) ([]*media.Media, error) {
+	if batch == "" {
+		return nil, nil
+	}
+
+	var ms []*media.Media
+	r.mu.RLock()
+	for _, m := range r.items {
+		if m.Batch == batch {
+			ms = append(ms, m)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(ms, func(a, b *media.Media) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return r.removeAll(ctx, ms)
+}
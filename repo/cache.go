@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"container/list"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached piece of media content.
+type cacheEntry struct {
+	id      uuid.UUID
+	variant string // distinguishes derived content of the same media, e.g. resized images, empty for the content itself
+	data    []byte
+	modTime time.Time
+}
+
+// cache is a size-bounded LRU cache of media content.
+type cache struct {
+	size, used int64
+
+	entries map[uuid.UUID]map[string]*list.Element // media ID to variant to entry
+	order   *list.List                             // front is the most recently used entry
+	mu      sync.Mutex
+}
+
+// newCache creates a cache holding up to size bytes of media content.
+func newCache(size int64) *cache {
+	return &cache{
+		size:    size,
+		entries: make(map[uuid.UUID]map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get looks up cached content by the media ID and variant, returns nil if it is not cached.
+func (c *cache) get(id uuid.UUID, variant string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id][variant]
+	if !ok {
+		return nil
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry)
+}
+
+// put inserts content into the cache, evicting the least recently used entries to make room.
+// Content larger than the cache itself is not cached.
+func (c *cache) put(ce *cacheEntry) {
+	size := int64(len(ce.data))
+	if size > c.size {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove0(ce.id, ce.variant)
+	for c.used+size > c.size {
+		back := c.order.Back().Value.(*cacheEntry)
+		c.remove0(back.id, back.variant)
+	}
+
+	variants, ok := c.entries[ce.id]
+	if !ok {
+		variants = make(map[string]*list.Element, 1)
+		c.entries[ce.id] = variants
+	}
+
+	variants[ce.variant] = c.order.PushFront(ce)
+	c.used += size
+}
+
+// remove invalidates all cached content of the media ID.
+func (c *cache) remove(id uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for variant := range c.entries[id] {
+		c.remove0(id, variant)
+	}
+}
+
+func (c *cache) remove0(id uuid.UUID, variant string) {
+	e, ok := c.entries[id][variant]
+	if !ok {
+		return
+	}
+
+	c.order.Remove(e)
+	if delete(c.entries[id], variant); len(c.entries[id]) == 0 {
+		delete(c.entries, id)
+	}
+	c.used -= int64(len(e.Value.(*cacheEntry).data))
+}
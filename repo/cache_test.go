@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestCacheEviction(t *testing.T) {
+	c := newCache(10)
+
+	a, b, d := uuid.New(), uuid.New(), uuid.New()
+	c.put(&cacheEntry{id: a, data: make([]byte, 4)})
+	c.put(&cacheEntry{id: b, data: make([]byte, 4)})
+	c.get(a, "") // b is the least recently used entry now
+	c.put(&cacheEntry{id: d, data: make([]byte, 4)})
+
+	if c.get(a, "") == nil || c.get(d, "") == nil {
+		t.Error("recently used entries were evicted")
+	}
+	if c.get(b, "") != nil {
+		t.Error("least recently used entry wasn't evicted")
+	}
+	if c.used != 8 {
+		t.Errorf("used = %d, want 8", c.used)
+	}
+
+	c.put(&cacheEntry{id: uuid.New(), data: make([]byte, 11)})
+	if c.used != 8 {
+		t.Error("content larger than the cache was cached")
+	}
+}
+
+func TestCacheRemoveVariants(t *testing.T) {
+	c := newCache(100)
+
+	id := uuid.New()
+	c.put(&cacheEntry{id: id, data: []byte("a")})
+	c.put(&cacheEntry{id: id, variant: "10x10-contain", data: []byte("b")})
+	c.remove(id)
+
+	if c.get(id, "") != nil || c.get(id, "10x10-contain") != nil || c.used != 0 {
+		t.Error("removing media left cached content behind")
+	}
+}
+
+func TestOpenCached(t *testing.T) {
+	var (
+		r = newTestRepo(t, &Options{CacheSize: 1 << 20})
+		b = testPNG(t, 4, 4)
+		m = mustCreate(t, r, b, nil)
+	)
+
+	read := func() ([]byte, error) {
+		f, err := r.Open(context.Background(), m)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return io.ReadAll(f)
+	}
+	if _, err := read(); err != nil {
+		t.Fatalf("failed to read media: %v", err)
+	}
+
+	// the second read must not touch the disk
+	if err := os.Remove(m.Path); err != nil {
+		t.Fatalf("failed to remove media file: %v", err)
+	}
+	b0, err := read()
+	if err != nil {
+		t.Fatalf("second read wasn't served from the cache: %v", err)
+	}
+	if !bytes.Equal(b0, b) {
+		t.Error("cached content differs from the media content")
+	}
+
+	if err := r.Remove(context.Background(), m.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if _, err := read(); err == nil {
+		t.Error("removed media is still served from the cache")
+	}
+}
@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CloneIndexName is the name of the index file of cloned repositories, the default index file name.
+const CloneIndexName = "nero.lock"
+
+// cloneSidecars are the suffixes of the index sidecar files copied along with a cloned repository.
+var cloneSidecars = []string{".collections", ".counts", ".access"}
+
+// Clone copies the repository into a new directory, producing an independent repository with the same media.
+// Media files keep their paths relative to the repository directory, files stored outside of it are copied to its root,
+// files in a shared store (Options.Shared) are named by the media ID.
+// A fresh index file is written to CloneIndexName in dest, along with copies of the collections,
+// download counters and last access times.
+// The source repository isn't locked while copying, media removed in the meantime is left out.
+// Returns the amount of cloned media.
+func (r *Repository) Clone(ctx context.Context, dest string) (_ int, err error) {
+	if r.path == "" {
+		return 0, errors.ErrUnsupported
+	}
+	if dest, err = filepath.Abs(dest); err != nil {
+		return 0, errors.Wrap(err, "failed to make destination path absolute")
+	}
+	if des, err := os.ReadDir(dest); err == nil && len(des) > 0 {
+		return 0, errors.New("destination directory is not empty")
+	}
+
+	fi, err := os.Stat(r.path)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to stat repository directory")
+	}
+	if err = os.MkdirAll(dest, fi.Mode().Perm()); err != nil {
+		return 0, errors.Wrap(err, "failed to make destination directory")
+	}
+
+	clone := &Repository{
+		id:       r.id,
+		path:     dest,
+		lockPath: filepath.Join(dest, CloneIndexName),
+		opts:     r.opts,
+		logger:   r.logger,
+		items:    make(map[uuid.UUID]*media.Media),
+	}
+	for _, m := range r.Items() {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		m0 := *m
+		if r.opts.Shared.contains(m.Path) {
+			// the clone doesn't use the shared store, content shared by multiple media is copied for each of them
+			m0.Path = filepath.Join(dest, m.ID.String()+filepath.Ext(m.Path))
+			err = r.copyFile(m.Path, m0.Path)
+		} else {
+			m0.Path, err = r.cloneFile(m.Path, dest)
+		}
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && r.Get(m.ID) == nil {
+				continue // removed in the meantime
+			}
+			return 0, err
+		}
+		if m.Original != "" {
+			if m0.Original, err = r.cloneFile(m.Original, dest); err != nil {
+				return 0, err
+			}
+		}
+
+		clone.items[m.ID] = &m0
+	}
+
+	if r.lockPath != "" {
+		for _, suffix := range cloneSidecars {
+			err := clone.copyFile(r.lockPath+suffix, clone.lockPath+suffix)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return 0, err
+			}
+		}
+	}
+	if err = clone.writeIndex(ctx, clone.lockPath); err != nil {
+		return 0, storageError(err)
+	}
+	if err = clone.syncDir(dest); err != nil {
+		return 0, err
+	}
+	return len(clone.items), nil
+}
+
+// cloneFile copies a media file into the directory of a cloned repository, returning the path of the copy.
+func (r *Repository) cloneFile(path, dest string) (string, error) {
+	rel, err := filepath.Rel(r.path, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = filepath.Base(path) // stored outside the repository directory
+	}
+
+	clonePath := filepath.Join(dest, rel)
+	if err := os.MkdirAll(filepath.Dir(clonePath), 0755); err != nil {
+		return "", errors.Wrap(err, "failed to make media directory")
+	}
+
+	return clonePath, r.copyFile(path, clonePath)
+}
+
+// copyFile copies a file to a new path, keeping its permissions. Symbolic links are followed.
+// On Linux, the content is copied by the kernel (copy_file_range),
+// sharing the data between both files on filesystems supporting reflinks, e.g. Btrfs and XFS.
+func (r *Repository) copyFile(src, dst string) (err error) {
+	sf, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to open file")
+	}
+	defer func() {
+		if err0 := sf.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close file"))
+		}
+	}()
+
+	fi, err := sf.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to stat file")
+	}
+
+	df, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to create file copy"))
+	}
+	defer func() {
+		if err0 := df.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close file copy"))
+		}
+	}()
+
+	if _, err = io.Copy(df, sf); err != nil {
+		return storageError(errors.Wrap(err, "failed to copy file"))
+	}
+	return r.syncFile(df)
+}
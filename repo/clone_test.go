@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var (
+		r    = newTestRepo(t, nil)
+		dest = filepath.Join(t.TempDir(), "clone")
+	)
+	for i := 0; i < 3; i++ {
+		if _, err := r.Create(context.Background(), testPNG(t, i+1, 1), &meta.GenericMetadata{Artist: "artist"}, nil); err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+	}
+
+	n, err := r.Clone(context.Background(), dest)
+	if err != nil {
+		t.Fatalf("failed to clone repository: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("cloned %d items, want 3", n)
+	}
+
+	clone := openTestRepo(t, dest, nil)
+	if clone.Usage() != r.Usage() {
+		t.Errorf("clone usage = %+v, want %+v", clone.Usage(), r.Usage())
+	}
+	for _, m := range r.Items() {
+		m0 := clone.Get(m.ID)
+		if m0 == nil {
+			t.Fatalf("media %s wasn't cloned", m.ID)
+		}
+		if !strings.HasPrefix(m0.Path, dest) || m0.Hash != m.Hash {
+			t.Errorf("cloned media %s is stored at %s with hash %s", m.ID, m0.Path, m0.Hash)
+		}
+		if gm, ok := m0.Meta.(*meta.GenericMetadata); !ok || gm.Artist != "artist" {
+			t.Error("metadata wasn't cloned")
+		}
+
+		b, err := os.ReadFile(m.Path)
+		if err != nil {
+			t.Fatalf("failed to read media file: %v", err)
+		}
+		b0, err := os.ReadFile(m0.Path)
+		if err != nil {
+			t.Fatalf("failed to read cloned media file: %v", err)
+		}
+		if !bytes.Equal(b, b0) {
+			t.Error("cloned content differs from the source content")
+		}
+	}
+
+	// the clone is independent of the source
+	m := r.Items()[0]
+	if err := clone.Remove(context.Background(), m.ID); err != nil {
+		t.Fatalf("failed to remove cloned media: %v", err)
+	}
+	if r.Get(m.ID) == nil {
+		t.Error("removing cloned media removed it from the source")
+	}
+	if _, err := os.Stat(m.Path); err != nil {
+		t.Errorf("removing cloned media removed the source file: %v", err)
+	}
+
+	if _, err := r.Clone(context.Background(), dest); err == nil {
+		t.Error("cloning into a non-empty directory succeeded")
+	}
+}
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"strings"
+	"sync"
+)
+
+// codecHeader is the prefix of the index file header line naming a non-default codec.
+const codecHeader = "#codec "
+
+// Codec is a serialization format of index items.
+type Codec interface {
+	// Name returns the name of the codec, written to the index file header.
+	Name() string
+	// Marshal encodes media, the result must not contain newlines.
+	Marshal(m *media.Media) ([]byte, error)
+	// Unmarshal decodes media.
+	Unmarshal(b []byte, m *media.Media) error
+}
+
+// UUIDFormat is a textual format of media IDs in index files.
+// IDs are read in any format regardless.
+type UUIDFormat string
+
+const (
+	// UUIDDashed is the canonical lowercase format with dashes, the default.
+	UUIDDashed UUIDFormat = "dashed"
+	// UUIDCompact is the lowercase format without dashes.
+	UUIDCompact UUIDFormat = "compact"
+	// UUIDUpper is the uppercase format with dashes.
+	UUIDUpper UUIDFormat = "upper"
+)
+
+// format formats a media ID.
+func (uf UUIDFormat) format(id uuid.UUID) (string, error) {
+	switch uf {
+	case "", UUIDDashed:
+		return id.String(), nil
+	case UUIDCompact:
+		return strings.ReplaceAll(id.String(), "-", ""), nil
+	case UUIDUpper:
+		return strings.ToUpper(id.String()), nil
+	}
+
+	return "", fmt.Errorf("unknown UUID format %q", uf)
+}
+
+// JSONCodec is the default JSON Codec.
+type JSONCodec struct {
+	// UUIDFormat is the format of written media IDs, UUIDDashed if empty.
+	UUIDFormat UUIDFormat
+}
+
+// Name returns the name of the codec.
+func (JSONCodec) Name() string {
+	return "json"
+}
+
+// Marshal encodes media to JSON.
+func (jc JSONCodec) Marshal(m *media.Media) ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil || jc.UUIDFormat == "" || jc.UUIDFormat == UUIDDashed {
+		return b, err
+	}
+
+	id, err := jc.UUIDFormat.format(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ID is the first field, so the first occurrence is always the ID itself
+	return bytes.Replace(b, []byte(`"`+m.ID.String()+`"`), []byte(`"`+id+`"`), 1), nil
+}
+
+// Unmarshal decodes media from JSON.
+func (JSONCodec) Unmarshal(b []byte, m *media.Media) error {
+	return json.Unmarshal(b, m)
+}
+
+var (
+	codecs   = map[string]Codec{"json": JSONCodec{}}
+	codecsMu sync.RWMutex
+)
+
+// RegisterCodec makes a codec available for reading index files by its name.
+func RegisterCodec(c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+
+	codecs[c.Name()] = c
+}
+
+// codecByName looks up a registered codec by its name.
+func codecByName(name string) (Codec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+
+	c, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown index codec %q", name)
+	}
+	return c, nil
+}
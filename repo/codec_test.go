@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// base64Codec is a Codec encoding JSON items in base64.
+type base64Codec struct{}
+
+func (base64Codec) Name() string {
+	return "base64"
+}
+
+func (base64Codec) Marshal(m *media.Media) ([]byte, error) {
+	b, err := JSONCodec{}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+func (base64Codec) Unmarshal(b []byte, m *media.Media) error {
+	b, err := base64.StdEncoding.DecodeString(string(b))
+	if err != nil {
+		return err
+	}
+	return JSONCodec{}.Unmarshal(b, m)
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	RegisterCodec(base64Codec{})
+
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, &Options{Codec: base64Codec{}})
+		m   = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+
+	b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if !strings.HasPrefix(string(b), codecHeader+"base64\n") {
+		t.Fatalf("index file doesn't start with the codec header: %q", b)
+	}
+	if strings.Contains(string(b), m.ID.String()) {
+		t.Error("index file wasn't written with the alternate codec")
+	}
+
+	// the codec is detected from the header, regardless of the configured one
+	m0 := openTestRepo(t, dir, nil).Get(m.ID)
+	if m0 == nil {
+		t.Fatal("media wasn't loaded from the index file")
+	}
+	if m0.Hash != m.Hash || m0.Path != m.Path || m0.Width != m.Width || !m0.CreatedAt.Equal(m.CreatedAt) {
+		t.Errorf("loaded media = %+v, want %+v", m0, m)
+	}
+}
+
+func TestUUIDFormat(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		dashed  = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "dashed.png"}
+		compact = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "compact.png"}
+		upper   = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "upper.png"}
+	)
+	writeTestIndex(
+		t, dir,
+		testIndexLine(t, dir, dashed),
+		strings.Replace(testIndexLine(t, dir, compact), compact.ID.String(), strings.ReplaceAll(compact.ID.String(), "-", ""), 1),
+		strings.Replace(testIndexLine(t, dir, upper), upper.ID.String(), strings.ToUpper(upper.ID.String()), 1),
+	)
+
+	// IDs are read in any format, regardless of the configured one
+	r := openTestRepo(t, dir, &Options{Codec: JSONCodec{UUIDFormat: UUIDCompact}})
+	for _, m := range []*media.Media{dashed, compact, upper} {
+		if r.Get(m.ID) == nil {
+			t.Errorf("media %s wasn't loaded", m.Path)
+		}
+	}
+
+	m := mustCreate(t, r, testPNG(t, 2, 2), nil)
+	b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if !strings.Contains(string(b), `{"id":"`+strings.ReplaceAll(m.ID.String(), "-", "")+`"`) {
+		t.Error("index file wasn't written with compact IDs")
+	}
+
+	if _, err := NewFile("test", t.TempDir(), filepath.Join(t.TempDir(), "nero.lock"), nil, &Options{Codec: JSONCodec{UUIDFormat: "braces"}}, zap.NewNop()); err == nil {
+		t.Error("unknown UUID format was accepted")
+	}
+}
+
+// lineCodec is a Codec encoding JSON items with a line terminator, split over two lines if multiline is set.
+type lineCodec struct {
+	multiline bool
+}
+
+func (lineCodec) Name() string {
+	return "line"
+}
+
+func (lc lineCodec) Marshal(m *media.Media) ([]byte, error) {
+	b, err := JSONCodec{}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	if lc.multiline {
+		b = []byte(strings.Replace(string(b), ",", ",\n", 1))
+	}
+	return append(b, "\r\n"...), nil
+}
+
+func (lineCodec) Unmarshal(b []byte, m *media.Media) error {
+	return JSONCodec{}.Unmarshal(b, m)
+}
+
+func TestIndexLines(t *testing.T) {
+	dir := t.TempDir()
+
+	// an index file cut off without its final newline
+	m := &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "a.png"}
+	if err := os.WriteFile(filepath.Join(dir, "nero.lock"), []byte(testIndexLine(t, dir, m)), 0644); err != nil {
+		t.Fatalf("failed to write index file: %v", err)
+	}
+
+	r := openTestRepo(t, dir, nil)
+	mustCreate(t, r, testPNG(t, 2, 2), nil)
+
+	b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if lines := strings.Split(string(b), "\n"); len(lines) != 3 || lines[2] != "" {
+		t.Errorf("index file isn't 2 newline-terminated lines:\n%s", b)
+	}
+	if n := openTestRepo(t, dir, nil).Usage().Items; n != 2 {
+		t.Errorf("reloaded index has %d items, want 2", n)
+	}
+
+	// codec line terminators are trimmed, not doubled
+	dir = t.TempDir()
+	RegisterCodec(lineCodec{})
+	mustCreate(t, openTestRepo(t, dir, &Options{Codec: lineCodec{}}), testPNG(t, 2, 2), nil)
+
+	b, err = os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if strings.Count(string(b), "\n") != 2 || strings.Contains(string(b), "\r") {
+		t.Errorf("index file has stray line terminators:\n%q", b)
+	}
+
+	if _, err := newTestRepo(t, &Options{Codec: lineCodec{multiline: true}}).Create(context.Background(), testPNG(t, 2, 2), nil, nil); err == nil {
+		t.Error("item serialized to multiple lines was written")
+	}
+}
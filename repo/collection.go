@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"golang.org/x/exp/maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Collection is a named group of media, providing default metadata to its members.
+type Collection struct {
+	// ID is the collection ID.
+	ID string `json:"id"`
+	// Name is the display name of the collection, may be empty.
+	Name string `json:"name,omitempty"`
+	// Meta is the metadata inherited by the members of the collection, may be nil.
+	Meta meta.Metadata `json:"meta"`
+}
+
+// UnmarshalJSON reads data from a JSON representation.
+func (c *Collection) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ID   string          `json:"id"`
+		Name string          `json:"name"`
+		Meta json.RawMessage `json:"meta"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	m, err := meta.Unmarshal(raw.Meta)
+	if err != nil {
+		return err
+	}
+
+	c.ID, c.Name, c.Meta = raw.ID, raw.Name, m
+	return nil
+}
+
+// collectionStore is a store of collections, persisted to a file in its entirety on every change.
+type collectionStore struct {
+	path  string // empty if the collections aren't persisted
+	items map[string]*Collection
+	mu    sync.RWMutex
+}
+
+// loadCollections loads the collections persisted at a path, if it exists.
+func loadCollections(path string) (*collectionStore, error) {
+	cs := &collectionStore{path: path, items: make(map[string]*Collection)}
+	if path == "" {
+		return cs, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cs, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read collections")
+	}
+
+	var items []*Collection
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, errors.Wrap(err, "failed to parse collections")
+	}
+	for _, c := range items {
+		cs.items[c.ID] = c
+	}
+
+	return cs, nil
+}
+
+// save persists the collections, the caller must hold the write lock.
+func (cs *collectionStore) save() error {
+	if cs.path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(sortedCollections(cs.items))
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize collections")
+	}
+
+	tmpPath := cs.path + ".tmp"
+	if err := os.WriteFile(tmpPath, b, 0644); err != nil {
+		return storageError(errors.Wrap(err, "failed to write collections"))
+	}
+	if err := os.Rename(tmpPath, cs.path); err != nil {
+		return storageError(errors.Wrap(err, "failed to move collections"))
+	}
+
+	return nil
+}
+
+// Collections returns all collections of the repository, ordered by their IDs.
+func (r *Repository) Collections() []*Collection {
+	r.collections.mu.RLock()
+	defer r.collections.mu.RUnlock()
+
+	return sortedCollections(r.collections.items)
+}
+
+// sortedCollections returns collections ordered by their IDs.
+func sortedCollections(items map[string]*Collection) []*Collection {
+	cs := maps.Values(items)
+	slices.SortFunc(cs, func(a, b *Collection) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return cs
+}
+
+// Collection returns a collection by its ID, nil if there is none.
+func (r *Repository) Collection(id string) *Collection {
+	r.collections.mu.RLock()
+	defer r.collections.mu.RUnlock()
+
+	return r.collections.items[id]
+}
+
+// PutCollection creates or replaces a collection and persists it.
+func (r *Repository) PutCollection(_ context.Context, c *Collection) error {
+	if c.ID == "" {
+		return errors.New("empty collection ID")
+	}
+
+	r.collections.mu.Lock()
+	r.collections.items[c.ID] = c
+	err := r.collections.save()
+	r.collections.mu.Unlock()
+
+	r.reindexMeta() // outside the collection lock, indexing takes it under the repository lock
+	r.notify()
+
+	return err
+}
+
+// RemoveCollection removes a collection by its ID and persists the change, returns nil if there was none.
+// Members of the collection keep referencing it, but don't inherit any metadata anymore.
+func (r *Repository) RemoveCollection(_ context.Context, id string) (*Collection, error) {
+	r.collections.mu.Lock()
+	c, ok := r.collections.items[id]
+	if !ok {
+		r.collections.mu.Unlock()
+		return nil, nil
+	}
+
+	delete(r.collections.items, id)
+	err := r.collections.save()
+	r.collections.mu.Unlock()
+
+	r.reindexMeta()
+	r.notify()
+
+	return c, err
+}
+
+// ResolveMeta returns the metadata of media with the fields it doesn't set inherited from its collection, if any.
+func (r *Repository) ResolveMeta(m *media.Media) meta.Metadata {
+	if m.Collection == "" {
+		return m.Meta
+	}
+
+	c := r.Collection(m.Collection)
+	if c == nil {
+		return m.Meta
+	}
+	return meta.Inherit(m.Meta, c.Meta)
+}
+
+// checkCollection checks whether a collection referenced by media exists, an empty ID is always valid.
+func (r *Repository) checkCollection(id string) error {
+	if id != "" && r.Collection(id) == nil {
+		return &ErrUnknownCollection{ID: id, Repo: r.id}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"testing"
+)
+
+func TestCollectionInherit(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, nil)
+		c   = &Collection{ID: "album", Meta: &meta.GenericMetadata{Artist: "album artist", Source: "album source"}}
+	)
+	if err := r.PutCollection(context.Background(), c); err != nil {
+		t.Fatalf("failed to put collection: %v", err)
+	}
+
+	inherited := mustCreate(t, r, testPNG(t, 1, 1), &CreateOptions{Collection: "album"})
+	overridden, err := r.Create(context.Background(), testPNG(t, 2, 2), &meta.GenericMetadata{Artist: "own artist"}, &CreateOptions{Collection: "album"})
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	outside := mustCreate(t, r, testPNG(t, 3, 3), nil)
+
+	check := func(r *Repository) {
+		t.Helper()
+
+		if gm, ok := r.ResolveMeta(r.Get(inherited.ID)).(*meta.GenericMetadata); !ok || gm.Artist != "album artist" {
+			t.Errorf("member without metadata resolved to %+v, want the collection artist", r.ResolveMeta(r.Get(inherited.ID)))
+		}
+		if gm, ok := r.ResolveMeta(r.Get(overridden.ID)).(*meta.GenericMetadata); !ok || gm.Artist != "own artist" || gm.Source != "album source" {
+			t.Errorf("member with an artist resolved to %+v, want its own artist and the collection source", r.ResolveMeta(r.Get(overridden.ID)))
+		}
+		if m := r.ResolveMeta(r.Get(outside.ID)); m != nil {
+			t.Errorf("media outside the collection resolved to %+v, want no metadata", m)
+		}
+	}
+	check(r)
+	check(openTestRepo(t, dir, nil))
+
+	_, err = r.Create(context.Background(), testPNG(t, 4, 4), nil, &CreateOptions{Collection: "unknown"})
+
+	var euc *ErrUnknownCollection
+	if !errors.As(err, &euc) {
+		t.Errorf("Create error = %v, want ErrUnknownCollection", err)
+	}
+
+	if _, err := r.RemoveCollection(context.Background(), "album"); err != nil {
+		t.Fatalf("failed to remove collection: %v", err)
+	}
+	if m := r.ResolveMeta(r.Get(inherited.ID)); m != nil {
+		t.Errorf("member of a removed collection resolved to %+v, want no metadata", m)
+	}
+}
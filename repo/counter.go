@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// DefaultCounterSaveInterval is the default interval of persisting download counters.
+const DefaultCounterSaveInterval = time.Minute
+
+// counters is a set of per-media download counters, persisted to a file next to the index file.
+type counters = ledger[int64]
+
+// loadCounters loads the counters persisted at a path, if it exists.
+func loadCounters(path string) (*counters, error) {
+	return loadLedger[int64](path, "download counters")
+}
+
+// CountDownload increments the download counter of media, does nothing if counting is disabled.
+func (r *Repository) CountDownload(id uuid.UUID) {
+	if r.counters != nil {
+		r.counters.update(id, func(n int64) (int64, bool) {
+			return n + 1, true
+		})
+	}
+}
+
+// Downloads returns the download counter of media, zero if counting is disabled.
+func (r *Repository) Downloads(id uuid.UUID) int64 {
+	if r.counters == nil {
+		return 0
+	}
+	return r.counters.get(id)
+}
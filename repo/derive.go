@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	mime "github.com/gabriel-vasile/mimetype"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"time"
+)
+
+// backfillSaveInterval is the amount of backfilled items after which the index is persisted.
+const backfillSaveInterval = 100
+
+// dimensions decodes the dimensions of image content, returns zeros if it isn't a decodable image.
+func dimensions(b []byte) (int, int) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return 0, 0
+	}
+
+	return cfg.Width, cfg.Height
+}
+
+// DerivedField is a piece of data derived from media content, computed for existing media by Backfill.
+type DerivedField string
+
+const (
+	// DerivedHash is the content hash of media.
+	DerivedHash DerivedField = "hash"
+	// DerivedDimensions are the dimensions of images, other media has none.
+	DerivedDimensions DerivedField = "dimensions"
+	// DerivedMIME is the stored MIME type of media, see Options.StoreMIME.
+	DerivedMIME DerivedField = "mime"
+)
+
+// missing checks whether media lacks the derived field.
+func (f DerivedField) missing(m *media.Media) bool {
+	switch f {
+	case DerivedHash:
+		return m.Hash == ""
+	case DerivedDimensions:
+		return (m.Format == media.FormatImage || m.Format == media.FormatAnimatedImage) && m.Width == 0
+	case DerivedMIME:
+		return m.MIME == ""
+	}
+
+	return false
+}
+
+// missingDerived checks whether media lacks any data derived from its content.
+func (r *Repository) missingDerived(m *media.Media) bool {
+	if DerivedHash.missing(m) || (r.opts.StoreMIME && DerivedMIME.missing(m)) {
+		return true
+	}
+
+	return DerivedDimensions.missing(m)
+}
+
+// Backfill computes missing derived data (content hashes, dimensions, stored MIME types) of existing media.
+// At most one item is processed per interval, zero disables rate limiting.
+// Progress is persisted as it goes, so a cancelled backfill can be resumed by calling Backfill again.
+// Returns the amount of updated media.
+func (r *Repository) Backfill(ctx context.Context, interval time.Duration) (n int, err error) {
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if n%backfillSaveInterval != 0 {
+			// persist progress even if the backfill was cancelled
+			if err0 := r.save(context.Background()); err0 != nil {
+				err = multierr.Append(err, errors.Wrap(err0, "failed to save backfilled items"))
+			}
+		}
+	}()
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		tick = t.C
+	}
+
+	for _, m := range r.Items() {
+		if !r.missingDerived(m) {
+			continue
+		}
+
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return n, ctx.Err()
+			case <-tick:
+			}
+		} else if err := ctx.Err(); err != nil {
+			return n, err
+		}
+
+		b, err := os.ReadFile(m.Path)
+		if err != nil {
+			r.logger.Warn(
+				"failed to read item for backfill",
+				zap.String("repo", r.id),
+				zap.String("id", m.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		hash := sha256.Sum256(b)
+		m0 := *m
+		m0.Hash = hex.EncodeToString(hash[:])
+		m0.Size = int64(len(b))
+		m0.Width, m0.Height = dimensions(b)
+		if r.opts.StoreMIME {
+			m0.MIME = mime.Detect(b).String()
+		}
+
+		if !r.replace(m, &m0) {
+			continue // removed or changed in the meantime
+		}
+
+		n++
+		if n%backfillSaveInterval == 0 {
+			r.mu.Lock()
+			err := r.save(ctx)
+			r.mu.Unlock()
+
+			if err != nil {
+				return n, errors.Wrap(err, "failed to save backfilled items")
+			}
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackfillDimensions(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		legacy = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "legacy.png"}
+	)
+	writeTestIndex(t, dir, testIndexLine(t, dir, legacy))
+
+	r, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	if n := len(r.List(&Query{Missing: DerivedDimensions})); n != 1 {
+		_ = r.Close()
+		t.Fatalf("%d items lack dimensions before the backfill, want 1", n)
+	}
+
+	n, err := r.Backfill(context.Background(), 0)
+	if err != nil {
+		_ = r.Close()
+		t.Fatalf("failed to backfill: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("backfill updated %d items, want 1", n)
+	}
+	if m := r.Get(legacy.ID); m == nil || m.Width != 1 || m.Height != 1 || m.Hash == "" {
+		t.Errorf("backfilled item = %+v, want 1x1 with a hash", m)
+	}
+
+	// progress is persisted
+	if err := r.Close(); err != nil {
+		t.Fatalf("failed to close repository: %v", err)
+	}
+	r = openTestRepo(t, dir, nil)
+	if m := r.Get(legacy.ID); m == nil || m.Width != 1 {
+		t.Error("backfilled dimensions weren't persisted")
+	}
+	if n, err := r.Backfill(context.Background(), 0); err != nil || n != 0 {
+		t.Errorf("second backfill updated %d items (%v), want none", n, err)
+	}
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// freeSpaceCacheTTL is the time a measurement of the free disk space is reused for.
+const freeSpaceCacheTTL = 5 * time.Second
+
+// freeSpace measures the free disk space of a path, replaced in tests.
+var freeSpace = statFreeSpace
+
+// freeSpaceCache is a briefly cached measurement of the free disk space of a repository.
+type freeSpaceCache struct {
+	bytes uint64
+	at    time.Time
+	mu    sync.Mutex
+}
+
+// checkFreeSpace checks whether the free disk space of the repository directory is above Options.MinFreeSpace,
+// so uploads are rejected before running out of space mid-write. The measurement is cached for freeSpaceCacheTTL.
+// Failed measurements, e.g. on unsupported platforms, are not treated as errors.
+func (r *Repository) checkFreeSpace() error {
+	if r.opts.MinFreeSpace <= 0 {
+		return nil
+	}
+
+	c := &r.freeSpace
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Since(c.at) > freeSpaceCacheTTL {
+		free, err := freeSpace(r.path)
+		if err != nil {
+			r.logger.Debug("failed to measure free disk space", zap.String("repo", r.id), zap.Error(err))
+			return nil
+		}
+
+		c.bytes, c.at = free, time.Now()
+	}
+
+	if c.bytes < uint64(r.opts.MinFreeSpace) {
+		return &ErrDiskFull{Err: fmt.Errorf("%d bytes free, below the minimum of %d bytes", c.bytes, r.opts.MinFreeSpace)}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd
+
+package repo
+
+import "github.com/cephxdev/nero/internal/errors"
+
+// statFreeSpace measures the disk space available on the filesystem of a path, unsupported on this platform.
+func statFreeSpace(_ string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
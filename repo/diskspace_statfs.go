@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd
+
+package repo
+
+import "syscall"
+
+// statFreeSpace measures the disk space available to unprivileged users on the filesystem of a path in bytes.
+func statFreeSpace(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"testing"
+	"time"
+)
+
+func TestCheckFreeSpace(t *testing.T) {
+	var (
+		free  uint64 = 500
+		calls int
+	)
+	freeSpace = func(string) (uint64, error) {
+		calls++
+		return free, nil
+	}
+	t.Cleanup(func() {
+		freeSpace = statFreeSpace
+	})
+
+	r := newTestRepo(t, &Options{MinFreeSpace: 1000})
+
+	var edf *ErrDiskFull
+	if _, err := r.Create(context.Background(), testPNG(t, 1, 1), nil, nil); !errors.As(err, &edf) {
+		t.Errorf("upload with low disk space error = %v, want ErrDiskFull", err)
+	}
+	if _, err := r.CreateFrom(context.Background(), bytes.NewReader(testPNG(t, 2, 2)), nil, nil); !errors.As(err, &edf) {
+		t.Errorf("streamed upload with low disk space error = %v, want ErrDiskFull", err)
+	}
+	if calls != 1 {
+		t.Errorf("free disk space was measured %d times, want once while cached", calls)
+	}
+	if n := r.Usage().Items; n != 0 {
+		t.Errorf("stored %d items with low disk space", n)
+	}
+
+	free = 2000
+	r.freeSpace.at = time.Time{} // expire the cached measurement
+	mustCreate(t, r, testPNG(t, 3, 3), nil)
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"cmp"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+)
+
+// DuplicateGroup is a group of media with identical metadata, candidates for merging.
+type DuplicateGroup struct {
+	// Meta is the metadata of the first media in the group.
+	Meta meta.Metadata
+	// IDs are the IDs of the media in the group, ordered by their creation time.
+	IDs []uuid.UUID
+}
+
+// DuplicateMetadata groups media with identical metadata, i.e. the same artist and source or the same anime name.
+// Values are compared case-insensitively ignoring surrounding whitespace, media with incomplete metadata are skipped.
+// Only groups of at least two media are returned, ordered by their size descending.
+func (r *Repository) DuplicateMetadata() []DuplicateGroup {
+	items := r.Items()
+	slices.SortStableFunc(items, func(a, b *media.Media) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	var (
+		groups []*DuplicateGroup
+		byKey  = make(map[string]*DuplicateGroup)
+	)
+	for _, m := range items {
+		key, ok := duplicateKey(m.Meta)
+		if !ok {
+			continue
+		}
+
+		g, ok := byKey[key]
+		if !ok {
+			g = &DuplicateGroup{Meta: m.Meta}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.IDs = append(g.IDs, m.ID)
+	}
+
+	var res []DuplicateGroup
+	for _, g := range groups {
+		if len(g.IDs) > 1 {
+			res = append(res, *g)
+		}
+	}
+
+	slices.SortStableFunc(res, func(a, b DuplicateGroup) int {
+		return cmp.Compare(len(b.IDs), len(a.IDs))
+	})
+	return res
+}
+
+// duplicateKey returns the key metadata is grouped by, false if it's missing the identifying values.
+func duplicateKey(m meta.Metadata) (string, bool) {
+	norm := func(s string) string {
+		return strings.ToLower(strings.TrimSpace(s))
+	}
+
+	switch m := m.(type) {
+	case *meta.GenericMetadata:
+		artist, source := norm(m.Artist), norm(m.Source)
+		if artist == "" || source == "" {
+			return "", false
+		}
+		return "generic\x00" + artist + "\x00" + source, true
+	case *meta.AnimeMetadata:
+		if name := norm(m.Name); name != "" {
+			return "anime\x00" + name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDuplicateMetadata(t *testing.T) {
+	var (
+		r     = newTestRepo(t, nil)
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		n     int
+	)
+	add := func(m meta.Metadata) uuid.UUID {
+		t.Helper()
+
+		id := uuid.New()
+		n++
+		m0 := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), Meta: m, CreatedAt: start.Add(time.Duration(n) * time.Hour)}
+		if err := r.Add(context.Background(), m0); err != nil {
+			t.Fatalf("failed to add media: %v", err)
+		}
+		return id
+	}
+
+	var (
+		a1 = add(&meta.GenericMetadata{Artist: "Artist", Source: "https://example.com/1"})
+		a2 = add(&meta.GenericMetadata{Artist: " artist ", Source: "HTTPS://EXAMPLE.COM/1"})
+		a3 = add(&meta.GenericMetadata{Artist: "artist", Source: "https://example.com/1", ArtistLink: "other"})
+		n1 = add(&meta.AnimeMetadata{Name: "Name"})
+		n2 = add(&meta.AnimeMetadata{Name: "name"})
+	)
+	add(&meta.GenericMetadata{Artist: "artist", Source: "https://example.com/2"}) // other source
+	add(&meta.GenericMetadata{Artist: "artist"})                                  // incomplete
+	add(&meta.GenericMetadata{Artist: "artist"})
+	add(&meta.AnimeMetadata{Name: "artist"}) // other metadata type
+	add(nil)
+	add(nil)
+
+	groups := r.DuplicateMetadata()
+	if len(groups) != 2 {
+		t.Fatalf("found %d groups, want 2", len(groups))
+	}
+	if !slices.Equal(groups[0].IDs, []uuid.UUID{a1, a2, a3}) {
+		t.Errorf("first group = %v, want the three generic items in creation order", groups[0].IDs)
+	}
+	if !slices.Equal(groups[1].IDs, []uuid.UUID{n1, n2}) {
+		t.Errorf("second group = %v, want the two anime items in creation order", groups[1].IDs)
+	}
+	if m, ok := groups[1].Meta.(*meta.AnimeMetadata); !ok || m.Name != "Name" {
+		t.Errorf("group metadata = %v, want the metadata of its first item", groups[1].Meta)
+	}
+}
@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"go.uber.org/multierr"
+	"os"
+)
+
+// Durability is a level of durability of index and media file writes, trading write throughput for crash safety.
+type Durability string
+
+const (
+	// DurabilityNone leaves flushing written data to the operating system, the default.
+	// Files are still replaced atomically, so a crash never leaves a partially written index file in place,
+	// but changes made shortly before a power loss may be lost or point to media files with missing content.
+	DurabilityNone Durability = "none"
+	// DurabilityFsync flushes every written index and media file to stable storage before it's used.
+	// Written file contents survive a power loss, but a freshly created or renamed file may still vanish
+	// if its directory entry wasn't flushed yet.
+	DurabilityFsync Durability = "fsync"
+	// DurabilityFsyncDir additionally flushes the containing directory after creating or renaming a file,
+	// so a completed write survives a power loss along with its directory entry.
+	DurabilityFsyncDir Durability = "fsync+dir"
+)
+
+// fsync flushes a file or directory to stable storage, replaced in tests.
+var fsync = (*os.File).Sync
+
+// syncFile flushes a written file to stable storage if required by the durability level.
+func (r *Repository) syncFile(f *os.File) error {
+	if r.opts.Durability != DurabilityFsync && r.opts.Durability != DurabilityFsyncDir {
+		return nil
+	}
+
+	if err := fsync(f); err != nil {
+		return errors.Wrap(err, "failed to sync file")
+	}
+	return nil
+}
+
+// syncDir flushes the entries of a directory to stable storage if required by the durability level.
+func (r *Repository) syncDir(path string) (err error) {
+	if r.opts.Durability != DurabilityFsyncDir {
+		return nil
+	}
+
+	d, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open directory")
+	}
+	defer func() {
+		if err0 := d.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close directory"))
+		}
+	}()
+
+	if err = fsync(d); err != nil {
+		return errors.Wrap(err, "failed to sync directory")
+	}
+	return nil
+}
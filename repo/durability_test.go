@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDurability(t *testing.T) {
+	tests := []struct {
+		level                Durability
+		syncsFile, syncsDirs bool
+	}{
+		{DurabilityNone, false, false},
+		{DurabilityFsync, true, false},
+		{DurabilityFsyncDir, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			var synced []string
+			fsync = func(f *os.File) error {
+				synced = append(synced, f.Name())
+				return nil
+			}
+			t.Cleanup(func() {
+				fsync = (*os.File).Sync
+			})
+
+			var (
+				dir = t.TempDir()
+				r   = openTestRepo(t, dir, &Options{Durability: tt.level})
+				m   = mustCreate(t, r, testPNG(t, 2, 2), nil)
+			)
+
+			var syncedMedia, syncedIndex, syncedDir bool
+			for _, name := range synced {
+				switch {
+				case name == m.Path:
+					syncedMedia = true
+				case strings.HasPrefix(name, filepath.Join(dir, "nero.lock")):
+					syncedIndex = true
+				case name == dir:
+					syncedDir = true
+				}
+			}
+			if syncedMedia != tt.syncsFile || syncedIndex != tt.syncsFile {
+				t.Errorf("media file synced = %t, index file synced = %t, want %t", syncedMedia, syncedIndex, tt.syncsFile)
+			}
+			if syncedDir != tt.syncsDirs {
+				t.Errorf("repository directory synced = %t, want %t", syncedDir, tt.syncsDirs)
+			}
+		})
+	}
+
+	dir := t.TempDir()
+	if _, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, &Options{Durability: "fsync+all"}, zap.NewNop()); err == nil {
+		t.Error("unknown durability level was accepted")
+	}
+}
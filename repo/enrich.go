@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+)
+
+// MetaEnricher inspects new media before it's stored and returns its possibly modified metadata.
+type MetaEnricher interface {
+	// Enrich returns the metadata to store with new media, m may be nil.
+	// b is the media content, or only its leading bytes if the content is streamed.
+	Enrich(ctx context.Context, format media.Format, b []byte, m meta.Metadata) (meta.Metadata, error)
+}
+
+// EnricherFunc is a function implementing MetaEnricher.
+type EnricherFunc func(ctx context.Context, format media.Format, b []byte, m meta.Metadata) (meta.Metadata, error)
+
+// Enrich calls the function.
+func (ef EnricherFunc) Enrich(ctx context.Context, format media.Format, b []byte, m meta.Metadata) (meta.Metadata, error) {
+	return ef(ctx, format, b, m)
+}
+
+// enrich runs the metadata enrichers in order, each receiving the result of the previous one.
+func (r *Repository) enrich(ctx context.Context, format media.Format, b []byte, m meta.Metadata) (meta.Metadata, error) {
+	for _, e := range r.opts.Enrichers {
+		var err error
+		if m, err = e.Enrich(ctx, format, b, m); err != nil {
+			return nil, errors.Wrap(err, "failed to enrich metadata")
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"go.uber.org/zap"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnrichers(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		tagger = EnricherFunc(func(_ context.Context, format media.Format, _ []byte, m meta.Metadata) (meta.Metadata, error) {
+			if m == nil && format == media.FormatImage {
+				return &meta.GenericMetadata{Artist: "tagger"}, nil
+			}
+			return m, nil
+		})
+		sourcer = EnricherFunc(func(_ context.Context, _ media.Format, _ []byte, m meta.Metadata) (meta.Metadata, error) {
+			if gm, ok := m.(*meta.GenericMetadata); ok {
+				return &meta.GenericMetadata{Artist: gm.Artist, Source: "https://example.com/" + gm.Artist}, nil
+			}
+			return m, nil
+		})
+		r = openTestRepo(t, dir, &Options{Enrichers: []MetaEnricher{tagger, sourcer}})
+		m = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	// each enricher receives the result of the previous one
+	gm, ok := r0.Get(m.ID).Meta.(*meta.GenericMetadata)
+	if !ok || gm.Artist != "tagger" || gm.Source != "https://example.com/tagger" {
+		t.Errorf("persisted metadata = %#v, want the enriched one", r0.Get(m.ID).Meta)
+	}
+
+	failing := newTestRepo(t, &Options{Enrichers: []MetaEnricher{EnricherFunc(func(context.Context, media.Format, []byte, meta.Metadata) (meta.Metadata, error) {
+		return nil, errors.New("enricher unavailable")
+	})}})
+	if _, err := failing.Create(context.Background(), testPNG(t, 2, 2), nil, nil); err == nil {
+		t.Error("creating media succeeded despite a failing enricher")
+	}
+	if len(failing.Items()) != 0 {
+		t.Error("media was stored despite a failing enricher")
+	}
+}
@@ -1,6 +1,9 @@
 package repo
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/cephxdev/nero/repo/media/meta"
+)
 
 // ErrDuplicateID is an error about a duplicate media ID in a repository.
 type ErrDuplicateID struct {
@@ -14,3 +17,129 @@ type ErrDuplicateID struct {
 func (edi *ErrDuplicateID) Error() string {
 	return fmt.Sprintf("duplicate media ID %s in repository %s", edi.ID, edi.Repo)
 }
+
+// ErrInvalidMedia is an error about media content rejected by a repository.
+type ErrInvalidMedia struct {
+	// Reason is the reason for the rejection.
+	Reason string
+	// Err is the underlying error, may be nil.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (eim *ErrInvalidMedia) Error() string {
+	if eim.Err != nil {
+		return fmt.Sprintf("invalid media: %s: %s", eim.Reason, eim.Err)
+	}
+	return fmt.Sprintf("invalid media: %s", eim.Reason)
+}
+
+// Unwrap returns the underlying error.
+func (eim *ErrInvalidMedia) Unwrap() error {
+	return eim.Err
+}
+
+// ErrMetaTypeNotAllowed is an error about metadata of a type not allowed in a repository.
+type ErrMetaTypeNotAllowed struct {
+	// Type is the offending metadata type.
+	Type meta.Type
+	// Repo is the repository ID.
+	Repo string
+}
+
+// Error returns the string representation of the error.
+func (emt *ErrMetaTypeNotAllowed) Error() string {
+	return fmt.Sprintf("metadata type %s is not allowed in repository %s", emt.Type, emt.Repo)
+}
+
+// ErrUnknownCollection is an error about media referencing a collection missing in a repository.
+type ErrUnknownCollection struct {
+	// ID is the collection ID.
+	ID string
+	// Repo is the repository ID.
+	Repo string
+}
+
+// Error returns the string representation of the error.
+func (euc *ErrUnknownCollection) Error() string {
+	return fmt.Sprintf("unknown collection %s in repository %s", euc.ID, euc.Repo)
+}
+
+// ErrQuotaExceeded is an error about media content not fitting into a repository quota.
+type ErrQuotaExceeded struct {
+	// Repo is the repository ID.
+	Repo string
+	// Quota is the repository quota in bytes.
+	Quota int64
+}
+
+// Error returns the string representation of the error.
+func (eqe *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("quota of %d bytes exceeded in repository %s", eqe.Quota, eqe.Repo)
+}
+
+// ErrDiskFull is an error about the storage device running out of space.
+type ErrDiskFull struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (edf *ErrDiskFull) Error() string {
+	return fmt.Sprintf("storage full: %s", edf.Err)
+}
+
+// Unwrap returns the underlying error.
+func (edf *ErrDiskFull) Unwrap() error {
+	return edf.Err
+}
+
+// ErrStoragePermission is an error about missing permissions to the storage.
+type ErrStoragePermission struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (esp *ErrStoragePermission) Error() string {
+	return fmt.Sprintf("storage permission denied: %s", esp.Err)
+}
+
+// Unwrap returns the underlying error.
+func (esp *ErrStoragePermission) Unwrap() error {
+	return esp.Err
+}
+
+// ErrStorageIO is an error about any other storage failure.
+type ErrStorageIO struct {
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (esi *ErrStorageIO) Error() string {
+	return fmt.Sprintf("storage failure: %s", esi.Err)
+}
+
+// Unwrap returns the underlying error.
+func (esi *ErrStorageIO) Unwrap() error {
+	return esi.Err
+}
+
+// ErrRepoDirMissing is an error about a repository storage directory that disappeared and couldn't be recreated.
+type ErrRepoDirMissing struct {
+	// Path is the path of the directory.
+	Path string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (erdm *ErrRepoDirMissing) Error() string {
+	return fmt.Sprintf("repository directory %s is missing: %s", erdm.Path, erdm.Err)
+}
+
+// Unwrap returns the underlying error.
+func (erdm *ErrRepoDirMissing) Unwrap() error {
+	return erdm.Err
+}
@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"time"
+)
+
+// DefaultSweepInterval is the default interval of removing expired media, see Options.SweepInterval.
+const DefaultSweepInterval = time.Minute
+
+// ExpiresAt returns the time media expires at, its per-item expiry if it's overridden, Options.TTL after its creation otherwise.
+// Returns a zero time if the media never expires.
+func (r *Repository) ExpiresAt(m *media.Media) time.Time {
+	if m.ExpiresAt != nil {
+		return *m.ExpiresAt
+	}
+	if r.opts.TTL <= 0 || m.CreatedAt.IsZero() {
+		return time.Time{}
+	}
+
+	return m.CreatedAt.Add(r.opts.TTL)
+}
+
+// expired checks whether media has expired at a point in time.
+func (r *Repository) expired(m *media.Media, now time.Time) bool {
+	at := r.ExpiresAt(m)
+	return !at.IsZero() && !now.Before(at)
+}
+
+// Sweep removes expired media from the repository, like Remove, and persists the index once.
+// Returns the amount of removed media.
+func (r *Repository) Sweep(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var expired []*media.Media
+	r.mu.RLock()
+	for _, m := range r.items {
+		if r.expired(m, now) {
+			expired = append(expired, m)
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	removed, err := r.removeAll(ctx, expired)
+	return len(removed), err
+}
+
+// startSweeper starts removing expired media periodically in the background until stopSweeper is called.
+// Does nothing if it's disabled, running already or was stopped.
+func (r *Repository) startSweeper() {
+	r.sweepMu.Lock()
+	defer r.sweepMu.Unlock()
+
+	if r.sweepInterval <= 0 || r.sweepStop != nil || r.sweepStopped {
+		return
+	}
+
+	r.sweepStop, r.sweepDone = make(chan struct{}), make(chan struct{})
+	go func(stop, done chan struct{}) {
+		defer close(done)
+
+		t := time.NewTicker(r.sweepInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				n, err := r.Sweep(context.Background())
+				if err != nil {
+					r.logger.Warn("failed to remove expired media", zap.String("repo", r.id), zap.Error(err))
+				}
+				if n > 0 {
+					r.logger.Info("removed expired media", zap.String("repo", r.id), zap.Int("removed", n))
+				}
+			}
+		}
+	}(r.sweepStop, r.sweepDone)
+}
+
+// stopSweeper stops removing expired media in the background, keeping it from being started again.
+func (r *Repository) stopSweeper() {
+	r.sweepMu.Lock()
+	stop, done := r.sweepStop, r.sweepDone
+	r.sweepStop, r.sweepDone, r.sweepStopped = nil, nil, true
+	r.sweepMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// expiring checks whether media has a per-item expiry, which needs the sweeper even without Options.TTL.
+func expiring(m *media.Media) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.IsZero()
+}
+
+// expiry returns a copy of the per-item expiry of new media, nil if it isn't overridden.
+func (co *CreateOptions) expiry() *time.Time {
+	if co.Expiry == nil {
+		return nil
+	}
+	if co.Expiry.IsZero() {
+		return &time.Time{}
+	}
+
+	at := co.Expiry.UTC()
+	return &at
+}
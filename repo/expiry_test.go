@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSweepExpiryOverride(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		opts = &Options{TTL: time.Hour, SweepInterval: -1}
+		r    = openTestRepo(t, dir, opts)
+		now  = time.Now()
+	)
+	add := func(createdAt time.Time, expiresAt *time.Time) *media.Media {
+		t.Helper()
+
+		id := uuid.New()
+		m := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(dir, id.String()+".png"), CreatedAt: createdAt, ExpiresAt: expiresAt}
+		if err := os.WriteFile(m.Path, testPNG(t, 1, 1), 0644); err != nil {
+			t.Fatalf("failed to write media file: %v", err)
+		}
+		if err := r.Add(context.Background(), m); err != nil {
+			t.Fatalf("failed to add media: %v", err)
+		}
+		return m
+	}
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	var (
+		expired  = add(now.Add(-2*time.Hour), nil)
+		kept     = add(now, nil)
+		never    = add(now.Add(-2*time.Hour), &time.Time{})
+		outlives = add(now.Add(-2*time.Hour), at(time.Hour))
+		undercut = add(now, at(-time.Second))
+	)
+
+	n, err := r.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("failed to sweep: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("swept %d items, want 2", n)
+	}
+
+	for _, tt := range []struct {
+		name string
+		m    *media.Media
+		kept bool
+	}{
+		{"default expiry", expired, false},
+		{"default lifetime", kept, true},
+		{"never expiring override", never, true},
+		{"later override", outlives, true},
+		{"earlier override", undercut, false},
+	} {
+		if (r.Get(tt.m.ID) != nil) != tt.kept {
+			t.Errorf("%s: kept = %t, want %t", tt.name, !tt.kept, tt.kept)
+		}
+	}
+
+	// overrides are persisted in the index
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if m := r0.Get(outlives.ID); m == nil || !r0.ExpiresAt(m).Equal(*outlives.ExpiresAt) {
+		t.Error("expiry override didn't survive a reload")
+	}
+	if m := r0.Get(never.ID); m == nil || !r0.ExpiresAt(m).IsZero() {
+		t.Error("never expiring override didn't survive a reload")
+	}
+}
+
+func TestSweeperStart(t *testing.T) {
+	running := func(r *Repository) bool {
+		r.sweepMu.Lock()
+		defer r.sweepMu.Unlock()
+
+		return r.sweepStop != nil
+	}
+
+	if r := newTestRepo(t, &Options{TTL: time.Hour}); !running(r) {
+		t.Error("sweeper isn't running with a TTL")
+	}
+
+	r := newTestRepo(t, nil)
+	if running(r) {
+		t.Fatal("sweeper is running without anything to expire")
+	}
+
+	never := time.Time{}
+	if _, err := r.Create(context.Background(), testPNG(t, 1, 1), nil, &CreateOptions{Expiry: &never}); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if running(r) {
+		t.Error("sweeper started for never expiring media")
+	}
+
+	at := time.Now().Add(time.Hour)
+	if _, err := r.Create(context.Background(), testPNG(t, 2, 1), nil, &CreateOptions{Expiry: &at}); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if !running(r) {
+		t.Error("sweeper didn't start for media with a per-item expiry")
+	}
+}
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// extension returns the file extension for media content.
+// The extension of the client-provided file name is used if enabled and consistent with the detected type,
+// falling back to the canonical extension of the type.
+func (r *Repository) extension(type_ *mimetype.MIME, filename string) string {
+	if r.opts.KeepExtension {
+		if ext := strings.ToLower(filepath.Ext(filename)); ext != "" {
+			if t, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil && type_.Is(t) {
+				return ext
+			}
+		}
+	}
+
+	return type_.Extension()
+}
+
+// ExtensionChange is a media file renamed to the canonical extension of its content type.
+type ExtensionChange struct {
+	ID       uuid.UUID
+	From, To string
+}
+
+// NormalizeExtensions renames media files to the canonical extension of their content type, e.g. ".jpeg" to ".jpg",
+// and persists the updated index. Files are renamed atomically and never replace existing files,
+// files in the shared store (Options.Shared) are skipped.
+// If dryRun is true, the changes are only reported. Returns the renamed media files.
+func (r *Repository) NormalizeExtensions(ctx context.Context, dryRun bool) (changes []ExtensionChange, err error) {
+	if r.path == "" {
+		return nil, errors.ErrUnsupported
+	}
+	if r.Ephemeral() && !dryRun {
+		return nil, errors.New("repository index is ephemeral, renamed files wouldn't be persisted")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	defer func() {
+		if err == nil || dryRun {
+			return
+		}
+
+		// restore the previous file names, the index still refers to them
+		for _, c := range changes {
+			if err0 := os.Rename(c.To, c.From); err0 != nil {
+				err = multierr.Append(err, errors.Wrap(err0, "failed to restore media file name"))
+			}
+			if m, ok := r.items[c.ID]; ok {
+				m0 := *m
+				m0.Path = c.From
+				r.items[c.ID] = &m0
+			}
+		}
+	}()
+
+	for id, m := range r.items {
+		if err = ctx.Err(); err != nil {
+			return changes, err
+		}
+		if r.opts.Shared.contains(m.Path) {
+			continue // named by the content hash and referenced by other repositories
+		}
+
+		type_, err := mimetype.DetectFile(m.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // missing content is reported on load
+		}
+		if err != nil {
+			return changes, errors.Wrap(err, "failed to detect content type")
+		}
+
+		ext, canonical := filepath.Ext(m.Path), type_.Extension()
+		if canonical == "" || ext == canonical {
+			continue
+		}
+
+		path := strings.TrimSuffix(m.Path, ext) + canonical
+		if _, err := os.Lstat(path); err == nil {
+			r.logger.Warn(
+				"not normalizing extension of media, the file name is taken",
+				zap.String("repo", r.id),
+				zap.String("id", id.String()),
+				zap.String("path", path),
+			)
+			continue
+		}
+
+		if !dryRun {
+			if err := os.Rename(m.Path, path); err != nil {
+				return changes, storageError(errors.Wrap(err, "failed to rename media file"))
+			}
+
+			m0 := *m
+			m0.Path = path
+			r.items[id] = &m0
+		}
+		changes = append(changes, ExtensionChange{ID: id, From: m.Path, To: path})
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+	return changes, r.save(ctx)
+}
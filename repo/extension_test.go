@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeepExtension(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4)), nil); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	b := buf.Bytes()
+
+	tests := []struct {
+		name     string
+		keep     bool
+		filename string
+		want     string
+	}{
+		{"kept", true, "photo.JPEG", ".jpeg"},
+		{"disabled", false, "photo.jpeg", ".jpg"},
+		{"inconsistent", true, "photo.png", ".jpg"},
+		{"no extension", true, "photo", ".jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t, &Options{KeepExtension: tt.keep})
+
+			m := mustCreate(t, r, b, &CreateOptions{Filename: tt.filename})
+			if ext := filepath.Ext(m.Path); ext != tt.want {
+				t.Errorf("extension = %q, want %q", ext, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeExtensions(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		r       = openTestRepo(t, dir, &Options{KeepExtension: true})
+		jpg     = mustCreate(t, r, testJPEG(t, 1), &CreateOptions{Filename: "photo.jpeg"})
+		png     = mustCreate(t, r, testPNG(t, 2, 2), nil)
+		blocked = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: filepath.Join(dir, "blocked.gif")}
+	)
+	if filepath.Ext(jpg.Path) != ".jpeg" {
+		t.Fatalf("media was stored as %s, want a .jpeg extension", jpg.Path)
+	}
+
+	// a PNG image with a wrong extension whose canonical file name is taken
+	if err := os.WriteFile(blocked.Path, testPNG(t, 3, 3), 0644); err != nil {
+		t.Fatalf("failed to write media file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "blocked.png"), nil, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := r.Add(context.Background(), blocked); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+
+	changes, err := r.NormalizeExtensions(context.Background(), true)
+	if err != nil {
+		t.Fatalf("failed to normalize extensions: %v", err)
+	}
+	want := strings.TrimSuffix(jpg.Path, ".jpeg") + ".jpg"
+	if len(changes) != 1 || changes[0].ID != jpg.ID || changes[0].To != want {
+		t.Fatalf("dry run reported %v, want only the .jpeg file", changes)
+	}
+	if _, err := os.Stat(jpg.Path); err != nil || r.Get(jpg.ID).Path != jpg.Path {
+		t.Error("dry run renamed the media file")
+	}
+
+	if _, err := r.NormalizeExtensions(context.Background(), false); err != nil {
+		t.Fatalf("failed to normalize extensions: %v", err)
+	}
+	if m := r.Get(jpg.ID); m.Path != want {
+		t.Errorf("normalized path = %s, want %s", m.Path, want)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("media file wasn't renamed: %v", err)
+	}
+	if r.Get(png.ID).Path != png.Path || r.Get(blocked.ID).Path != blocked.Path {
+		t.Error("canonical or blocked media files were renamed")
+	}
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if m := r0.Get(jpg.ID); m == nil || m.Path != want {
+		t.Error("normalized path didn't survive a reload")
+	}
+}
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"cmp"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"slices"
+)
+
+// FacetField is a metadata field media can be grouped by.
+type FacetField string
+
+const (
+	// FacetArtist groups by the artist of generic metadata.
+	FacetArtist FacetField = "artist"
+	// FacetSource groups by the source of generic metadata.
+	FacetSource FacetField = "source"
+	// FacetName groups by the name of anime metadata.
+	FacetName FacetField = "name"
+)
+
+// Facet is a distinct metadata value and the amount of media having it.
+type Facet struct {
+	// Value is the metadata value.
+	Value string
+	// Count is the amount of media with the value.
+	Count int
+}
+
+// Facets lists distinct non-empty values of a metadata field in the effective metadata of media (see ResolveMeta),
+// ordered by their count descending. At most limit facets are returned, zero means no limit.
+func (r *Repository) Facets(field FacetField, limit int) []Facet {
+	counts := make(map[string]int)
+	for _, m := range r.Items() {
+		if v := facetValue(r.ResolveMeta(m), field); v != "" {
+			counts[v]++
+		}
+	}
+
+	res := make([]Facet, 0, len(counts))
+	for v, c := range counts {
+		res = append(res, Facet{Value: v, Count: c})
+	}
+
+	slices.SortFunc(res, func(a, b Facet) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Value, b.Value)
+	})
+
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// facetValue extracts the value of a metadata field, returns an empty string if the metadata doesn't have it.
+func facetValue(m meta.Metadata, field FacetField) string {
+	switch m := m.(type) {
+	case *meta.GenericMetadata:
+		switch field {
+		case FacetArtist:
+			return m.Artist
+		case FacetSource:
+			return m.Source
+		}
+	case *meta.AnimeMetadata:
+		if field == FacetName {
+			return m.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// addTestMeta inserts media without content with metadata.
+func addTestMeta(t *testing.T, r *Repository, m meta.Metadata) *media.Media {
+	t.Helper()
+
+	id := uuid.New()
+	m0 := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), Meta: m, CreatedAt: time.Now()}
+	if err := r.Add(context.Background(), m0); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+	return m0
+}
+
+func TestFacets(t *testing.T) {
+	r := newTestRepo(t, nil)
+	for _, artist := range []string{"b", "a", "b", "c", "b", "a", ""} {
+		addTestMeta(t, r, &meta.GenericMetadata{Artist: artist, Source: "https://example.com"})
+	}
+	addTestMeta(t, r, &meta.AnimeMetadata{Name: "a"})
+	addTestMeta(t, r, nil)
+
+	want := []Facet{{"b", 3}, {"a", 2}, {"c", 1}}
+	if got := r.Facets(FacetArtist, 0); !slices.Equal(got, want) {
+		t.Errorf("artist facets = %v, want %v", got, want)
+	}
+	if got := r.Facets(FacetArtist, 2); !slices.Equal(got, want[:2]) {
+		t.Errorf("limited artist facets = %v, want %v", got, want[:2])
+	}
+	if got := r.Facets(FacetSource, 0); !slices.Equal(got, []Facet{{"https://example.com", 7}}) {
+		t.Errorf("source facets = %v, want all generic items", got)
+	}
+	if got := r.Facets(FacetName, 0); !slices.Equal(got, []Facet{{"a", 1}}) {
+		t.Errorf("name facets = %v, want only the anime item", got)
+	}
+}
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/multierr"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File is an opened piece of media content.
+type File struct {
+	io.ReadSeeker
+
+	// ModTime is the modification time of the content.
+	ModTime time.Time
+
+	closer io.Closer
+}
+
+// Close closes the underlying file, if any.
+func (f *File) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+	return f.closer.Close()
+}
+
+// Open opens the content of media for reading.
+// If the repository has a media cache, the content is served from memory if possible.
+// If the repository has an upstream, content missing locally is fetched from it first.
+func (r *Repository) Open(ctx context.Context, m *media.Media) (*File, error) {
+	if r.cache != nil {
+		if ce := r.cache.get(m.ID, ""); ce != nil {
+			return &File{ReadSeeker: bytes.NewReader(ce.data), ModTime: ce.modTime}, nil
+		}
+	}
+
+	if err := r.checkSymlink(m.Path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(m.Path)
+	if errors.Is(err, os.ErrNotExist) && r.opts.Upstream != nil {
+		if err = r.pull(ctx, m); err != nil {
+			return nil, err
+		}
+
+		f, err = os.Open(m.Path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open media")
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.Wrap(err, "failed to stat media")
+	}
+
+	if r.cache == nil || fi.Size() > r.cache.size {
+		return &File{ReadSeeker: f, ModTime: fi.ModTime(), closer: f}, nil
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read media")
+	}
+	if err0 := f.Close(); err0 != nil {
+		err = multierr.Append(err, errors.Wrap(err0, "failed to close media"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.put(&cacheEntry{id: m.ID, data: b, modTime: fi.ModTime()})
+	return &File{ReadSeeker: bytes.NewReader(b), ModTime: fi.ModTime()}, nil
+}
+
+// Attachment returns whether media content should be served as an attachment instead of inline.
+func (r *Repository) Attachment(m *media.Media) bool {
+	return r.opts.SVG == SVGAttachment && strings.EqualFold(filepath.Ext(m.Path), ".svg")
+}
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"encoding/binary"
+	"fmt"
+	mime "github.com/gabriel-vasile/mimetype"
+)
+
+// frameCount counts the frames of an animated image by walking its container structure, without decoding the frames.
+// Returns -1 if the content is malformed or of an unsupported type.
+func frameCount(b []byte, type_ *mime.MIME) int {
+	switch {
+	case type_.Is("image/gif"):
+		return gifFrames(b)
+	case type_.Is("image/vnd.mozilla.apng"):
+		return apngFrames(b)
+	case type_.Is("image/webp"):
+		return webpFrames(b)
+	}
+
+	return -1
+}
+
+// gifFrames counts the image descriptors of a GIF image.
+func gifFrames(b []byte) int {
+	const (
+		colorTableFlag = 0x80
+		headerSize     = 13 // signature, logical screen descriptor
+	)
+
+	if len(b) < headerSize {
+		return -1
+	}
+
+	// skipTable skips a color table if the flags have it, returns the new position
+	skipTable := func(i int, flags byte) int {
+		if flags&colorTableFlag != 0 {
+			i += 3 << (flags&0x07 + 1)
+		}
+		return i
+	}
+	// skipBlocks skips a chain of data sub-blocks, returns the new position or -1 if it's truncated
+	skipBlocks := func(i int) int {
+		for i < len(b) {
+			size := int(b[i])
+			i++
+			if size == 0 {
+				return i
+			}
+			i += size
+		}
+		return -1
+	}
+
+	var (
+		i      = skipTable(headerSize, b[10])
+		frames int
+	)
+	for i < len(b) {
+		switch b[i] {
+		case 0x21: // extension
+			if i+2 > len(b) {
+				return -1
+			}
+			i = skipBlocks(i + 2)
+		case 0x2c: // image descriptor
+			if i+10 > len(b) {
+				return -1
+			}
+			frames++
+			i = skipTable(i+10, b[i+9]) + 1 // LZW minimum code size
+			i = skipBlocks(i)
+		case 0x3b: // trailer
+			return frames
+		default:
+			return -1
+		}
+
+		if i < 0 {
+			return -1
+		}
+	}
+
+	return frames // tolerate a missing trailer, like decoders do
+}
+
+// apngFrames counts the frame control chunks of an APNG image, a PNG image without any has one frame.
+func apngFrames(b []byte) int {
+	const signatureSize = 8
+
+	if len(b) < signatureSize {
+		return -1
+	}
+
+	frames := 0
+	for i := signatureSize; i+8 <= len(b); {
+		var (
+			size = int(binary.BigEndian.Uint32(b[i:]))
+			name = string(b[i+4 : i+8])
+		)
+		if size > len(b) {
+			return -1
+		}
+		switch name {
+		case "fcTL":
+			frames++
+		case "IEND":
+			return max(frames, 1)
+		}
+
+		i += 12 + size // length, type, data, CRC
+	}
+
+	return max(frames, 1)
+}
+
+// webpFrames counts the animation frame chunks of a WebP image, a still image has one frame.
+func webpFrames(b []byte) int {
+	const headerSize = 12 // RIFF header
+
+	if len(b) < headerSize {
+		return -1
+	}
+
+	frames := 0
+	for i := headerSize; i+8 <= len(b); {
+		var (
+			size = int(binary.LittleEndian.Uint32(b[i+4:]))
+			name = string(b[i : i+4])
+		)
+		if size > len(b) {
+			return -1
+		}
+		if name == "ANMF" {
+			frames++
+		}
+
+		i += 8 + size + size&1 // chunks are padded to an even size
+	}
+
+	return max(frames, 1)
+}
+
+// validateFrames checks the frame count of an animated image against the repository limit.
+func (r *Repository) validateFrames(b []byte, type_ *mime.MIME) error {
+	if r.opts.MaxFrames <= 0 {
+		return nil
+	}
+
+	frames := frameCount(b, type_)
+	if frames < 0 {
+		return &ErrInvalidMedia{Reason: "image frame count could not be read"}
+	}
+	if frames > r.opts.MaxFrames {
+		return &ErrInvalidMedia{Reason: fmt.Sprintf("image frame count %d is above the maximum of %d", frames, r.opts.MaxFrames)}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	mime "github.com/gabriel-vasile/mimetype"
+	"testing"
+)
+
+func TestFrameCount(t *testing.T) {
+	for _, n := range []int{1, 3, 12} {
+		b := testGIF(t, 4, 4, n)
+		if got := frameCount(b, mime.Detect(b)); got != n {
+			t.Errorf("frameCount of a %d frame GIF = %d", n, got)
+		}
+	}
+
+	if got := frameCount(testGIF(t, 4, 4, 3)[:20], mime.Lookup("image/gif")); got != -1 {
+		t.Errorf("frameCount of a truncated GIF = %d, want -1", got)
+	}
+}
+
+func TestValidateFrames(t *testing.T) {
+	r := newTestRepo(t, &Options{MaxFrames: 4})
+
+	_, err := r.Create(context.Background(), testGIF(t, 4, 4, 5), nil, nil)
+
+	var eim *ErrInvalidMedia
+	if !errors.As(err, &eim) {
+		t.Fatalf("Create error = %v, want ErrInvalidMedia", err)
+	}
+	if r.Usage().Items != 0 {
+		t.Error("rejected animation was stored")
+	}
+
+	if _, err := r.Create(context.Background(), testGIF(t, 4, 4, 4), nil, nil); err != nil {
+		t.Errorf("animation within the frame cap was rejected: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/repo/media"
+	"slices"
+	"sync"
+)
+
+// UploadSizeBuckets are the upper bounds of the upload size histogram buckets in bytes, see Repository.UploadSizes.
+var UploadSizeBuckets = []int64{16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20, 64 << 20, 256 << 20}
+
+// SizeHistogram is a histogram of content sizes, bucketed by UploadSizeBuckets.
+type SizeHistogram struct {
+	// Buckets are the cumulative amounts of sizes less than or equal to the respective UploadSizeBuckets bound.
+	Buckets []uint64
+	// Count is the total amount of sizes.
+	Count uint64
+	// Sum is the total of all sizes in bytes.
+	Sum int64
+}
+
+// uploadSizes is a set of upload size histograms by media format, kept in memory since the repository was opened.
+type uploadSizes struct {
+	formats map[media.Format]*SizeHistogram // buckets aren't cumulative
+	mu      sync.Mutex
+}
+
+// observe records the content size of uploaded media.
+func (us *uploadSizes) observe(m *media.Media) {
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	if us.formats == nil {
+		us.formats = make(map[media.Format]*SizeHistogram)
+	}
+
+	h, ok := us.formats[m.Format]
+	if !ok {
+		h = &SizeHistogram{Buckets: make([]uint64, len(UploadSizeBuckets))}
+		us.formats[m.Format] = h
+	}
+
+	if i, _ := slices.BinarySearch(UploadSizeBuckets, m.Size); i < len(h.Buckets) {
+		h.Buckets[i]++
+	}
+	h.Count++
+	h.Sum += m.Size
+}
+
+// UploadSizes returns the histograms of content sizes of media uploaded since the repository was opened, by format.
+// Only formats with uploads are present.
+func (r *Repository) UploadSizes() map[media.Format]SizeHistogram {
+	r.uploadSizes.mu.Lock()
+	defer r.uploadSizes.mu.Unlock()
+
+	res := make(map[media.Format]SizeHistogram, len(r.uploadSizes.formats))
+	for format, h := range r.uploadSizes.formats {
+		h0 := SizeHistogram{Buckets: make([]uint64, len(h.Buckets)), Count: h.Count, Sum: h.Sum}
+
+		var n uint64
+		for i, count := range h.Buckets {
+			n += count
+			h0.Buckets[i] = n
+		}
+		res[format] = h0
+	}
+
+	return res
+}
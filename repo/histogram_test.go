@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"slices"
+	"testing"
+)
+
+func TestUploadSizes(t *testing.T) {
+	r := newTestRepo(t, nil)
+
+	small := mustCreate(t, r, testPNG(t, 2, 2), nil)
+	mustCreate(t, r, testGIF(t, 2, 2, 2), nil)
+	large, err := r.CreateFrom(context.Background(), bytes.NewReader(bytes.Repeat([]byte("nero"), 5<<10)), nil, nil) // 20 KiB
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	tx, err := r.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if _, err := tx.Create(context.Background(), testPNG(t, 3, 3), nil, nil); err != nil {
+		t.Fatalf("failed to stage media: %v", err)
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("failed to commit transaction: %v", err)
+	}
+
+	hs := r.UploadSizes()
+	if len(hs) != 3 {
+		t.Fatalf("expected histograms of 3 formats, got %d", len(hs))
+	}
+
+	// buckets returns the cumulative buckets of n sizes falling into the bucket at index i, see UploadSizeBuckets
+	buckets := func(i int, n uint64) []uint64 {
+		res := make([]uint64, len(UploadSizeBuckets))
+		for ; i < len(res); i++ {
+			res[i] = n
+		}
+		return res
+	}
+	tests := []struct {
+		format  media.Format
+		buckets []uint64
+		count   uint64
+	}{
+		{media.FormatImage, buckets(0, 2), 2},
+		{media.FormatAnimatedImage, buckets(0, 1), 1},
+		{large.Format, buckets(1, 1), 1},
+	}
+	for _, tt := range tests {
+		h := hs[tt.format]
+		if !slices.Equal(h.Buckets, tt.buckets) || h.Count != tt.count {
+			t.Errorf("%s: buckets = %v, count = %d, want %v, %d", tt.format, h.Buckets, h.Count, tt.buckets, tt.count)
+		}
+	}
+	if h := hs[media.FormatImage]; h.Sum <= small.Size {
+		t.Errorf("image size sum = %d, want the total of both images", h.Sum)
+	}
+	if h := hs[large.Format]; h.Sum != 20<<10 {
+		t.Errorf("%s size sum = %d, want %d", large.Format, h.Sum, 20<<10)
+	}
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Hook is an external command run asynchronously after media is created in a repository.
+type Hook struct {
+	command []string
+	timeout time.Duration
+
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// NewHook creates a Hook running a command, at most concurrency instances at once, each with a timeout.
+// The media ID, path and format are appended to the command arguments.
+// A concurrency of zero or less means 1, a timeout of zero means no timeout.
+func NewHook(command []string, concurrency int, timeout time.Duration) *Hook {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	return &Hook{
+		command: command,
+		timeout: timeout,
+		sem:     make(chan struct{}, concurrency),
+	}
+}
+
+// run runs the hook for a piece of media in the background.
+func (h *Hook) run(repo string, m *media.Media, logger *zap.Logger) {
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		h.sem <- struct{}{}
+		defer func() { <-h.sem }()
+
+		ctx := context.Background()
+		if h.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+		}
+
+		var (
+			args   = append(h.command[1:len(h.command):len(h.command)], m.ID.String(), m.Path, m.Format.String())
+			output bytes.Buffer
+		)
+		cmd := exec.CommandContext(ctx, h.command[0], args...)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		if err := cmd.Run(); err != nil {
+			logger.Warn(
+				"upload hook failed",
+				zap.String("repo", repo),
+				zap.String("id", m.ID.String()),
+				zap.ByteString("output", output.Bytes()),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// wait waits for all running hook commands to finish.
+func (h *Hook) wait() {
+	h.wg.Wait()
+}
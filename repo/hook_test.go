@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs need a POSIX shell")
+	}
+
+	var (
+		dir    = t.TempDir()
+		script = filepath.Join(dir, "hook.sh")
+		out    = filepath.Join(dir, "args")
+	)
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"$@\" > \""+out+"\"\n"), 0755); err != nil {
+		t.Fatalf("failed to write script stub: %v", err)
+	}
+
+	var (
+		h = NewHook([]string{script, "--flag"}, 1, 10*time.Second)
+		r = newTestRepo(t, &Options{Hook: h})
+		m = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+	h.wait()
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook didn't run: %v", err)
+	}
+
+	want := strings.Join([]string{"--flag", m.ID.String(), m.Path, m.Format.String()}, " ")
+	if got := strings.TrimSpace(string(b)); got != want {
+		t.Errorf("hook arguments = %q, want %q", got, want)
+	}
+}
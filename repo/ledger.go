@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"encoding/json"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"sync"
+	"time"
+)
+
+// ledger is a set of per-media values, persisted to a file next to the index file in the background.
+// Changes are batched in memory and written at most once per save interval.
+type ledger[V any] struct {
+	path string // empty if the values aren't persisted
+	name string // human-readable name of the values, used in errors
+
+	values map[uuid.UUID]V
+	dirty  bool
+	mu     sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// loadLedger loads the values persisted at a path, if it exists.
+func loadLedger[V any](path, name string) (*ledger[V], error) {
+	l := &ledger[V]{path: path, name: name, values: make(map[uuid.UUID]V)}
+	if path == "" {
+		return l, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read "+name)
+	}
+
+	if err := json.Unmarshal(b, &l.values); err != nil {
+		return nil, errors.Wrap(err, "failed to parse "+name)
+	}
+	return l, nil
+}
+
+// start persists the values periodically in the background until close is called.
+// Does nothing if the values aren't persisted.
+func (l *ledger[V]) start(interval time.Duration, repo string, logger *zap.Logger) {
+	if l.path == "" {
+		return
+	}
+
+	l.stop, l.done = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(l.done)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-t.C:
+				if err := l.save(); err != nil {
+					logger.Warn("failed to save "+l.name, zap.String("repo", repo), zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// close stops persisting the values in the background and persists them one last time.
+func (l *ledger[V]) close() error {
+	if l.stop != nil {
+		close(l.stop)
+		<-l.done
+	}
+
+	return l.save()
+}
+
+// update replaces the value of media with the result of f, which is called with the current value.
+// f returns false if the value should be left unchanged.
+func (l *ledger[V]) update(id uuid.UUID, f func(V) (V, bool)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if v, ok := f(l.values[id]); ok {
+		l.values[id] = v
+		l.dirty = true
+	}
+}
+
+// get returns the value of media.
+func (l *ledger[V]) get(id uuid.UUID) V {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.values[id]
+}
+
+// snapshot returns a copy of all values.
+func (l *ledger[V]) snapshot() map[uuid.UUID]V {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	values := make(map[uuid.UUID]V, len(l.values))
+	for id, v := range l.values {
+		values[id] = v
+	}
+	return values
+}
+
+// remove removes the value of media.
+func (l *ledger[V]) remove(id uuid.UUID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.values[id]; ok {
+		delete(l.values, id)
+		l.dirty = true
+	}
+}
+
+// save persists the values if they changed since the last save.
+func (l *ledger[V]) save() error {
+	if l.path == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	if !l.dirty {
+		l.mu.Unlock()
+		return nil
+	}
+
+	b, err := json.Marshal(l.values)
+	l.dirty = false
+	l.mu.Unlock()
+
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize "+l.name)
+	}
+
+	tmpPath := l.path + ".tmp"
+	if err := os.WriteFile(tmpPath, b, 0644); err != nil {
+		return storageError(errors.Wrap(err, "failed to write "+l.name))
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return storageError(errors.Wrap(err, "failed to move "+l.name))
+	}
+
+	return nil
+}
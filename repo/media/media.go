@@ -2,9 +2,11 @@ package media
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/cephxdev/nero/repo/media/meta"
 	"github.com/google/uuid"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 // Format is a media format.
@@ -17,8 +19,37 @@ const (
 	FormatImage
 	// FormatAnimatedImage is an animated image media format, i.e. GIF, APNG, WEBP.
 	FormatAnimatedImage
+	// FormatVideo is a video media format, i.e. MP4, WEBM.
+	FormatVideo
 )
 
+// String returns the string representation of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatImage:
+		return "image"
+	case FormatAnimatedImage:
+		return "animated_image"
+	case FormatVideo:
+		return "video"
+	}
+
+	return "unknown"
+}
+
+// ParseIDFromFilename recovers a media ID from the name of its content file, i.e. <uuid>.jpg or <uuid>.orig.gif.
+// Directories of sharded layouts are ignored, everything after the first dot of the base name is an extension.
+// Returns false if the name doesn't start with an ID.
+func ParseIDFromFilename(name string) (uuid.UUID, bool) {
+	stem, _, _ := strings.Cut(filepath.Base(name), ".")
+
+	id, err := uuid.Parse(stem)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
 // Media is a piece of media.
 type Media struct {
 	// ID is the media ID.
@@ -29,15 +60,63 @@ type Media struct {
 	Path string `json:"path"`
 	// Meta is the media metadata, may be nil.
 	Meta meta.Metadata `json:"meta"`
+	// CreatedAt is the time of the media's creation, zero if unknown.
+	CreatedAt time.Time `json:"created_at"`
+	// Hash is the hex-encoded SHA-256 hash of the media content, empty if unknown.
+	Hash string `json:"hash"`
+	// Size is the size of the media content in bytes.
+	Size int64 `json:"size"`
+	// Width is the width of the media in pixels, zero if unknown.
+	Width int `json:"width"`
+	// Height is the height of the media in pixels, zero if unknown.
+	Height int `json:"height"`
+	// Original is the path of the original content if the media was transcoded and the original was kept.
+	Original string `json:"original,omitempty"`
+	// ContentType is the content type the media is served with, detected from the content if empty.
+	ContentType string `json:"content_type,omitempty"`
+	// MIME is the detected MIME type of the content, empty if it wasn't stored.
+	MIME string `json:"mime,omitempty"`
+	// Pinned is whether the media is pinned, i.e. featured.
+	Pinned bool `json:"pinned,omitempty"`
+	// Uploader is the client info of the uploader, nil if it wasn't recorded.
+	Uploader *Uploader `json:"uploader,omitempty"`
+	// Collection is the ID of the collection the media inherits metadata from, empty if there is none.
+	Collection string `json:"collection,omitempty"`
+	// Batch is the ID of the import batch the media was uploaded in, empty if there is none.
+	Batch string `json:"batch,omitempty"`
+	// ExpiresAt is the time the media expires at, overriding the repository default, nil if it isn't overridden.
+	// A zero time means the media never expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Uploader is the client info of an uploader, recorded for auditing.
+type Uploader struct {
+	// IP is the IP address of the client, hex-encoded SHA-256 hash of it if hashing is enabled.
+	IP string `json:"ip,omitempty"`
+	// UserAgent is the user agent of the client.
+	UserAgent string `json:"user_agent,omitempty"`
 }
 
 // UnmarshalJSON reads data from a JSON representation.
 func (m *Media) UnmarshalJSON(bytes []byte) error {
 	var raw struct {
-		ID     uuid.UUID       `json:"id"`
-		Format Format          `json:"format"`
-		Path   string          `json:"path"`
-		Meta   json.RawMessage `json:"meta"`
+		ID          uuid.UUID       `json:"id"`
+		Format      Format          `json:"format"`
+		Path        string          `json:"path"`
+		Meta        json.RawMessage `json:"meta"`
+		CreatedAt   time.Time       `json:"created_at"`
+		Hash        string          `json:"hash"`
+		Size        int64           `json:"size"`
+		Width       int             `json:"width"`
+		Height      int             `json:"height"`
+		Original    string          `json:"original"`
+		ContentType string          `json:"content_type"`
+		MIME        string          `json:"mime"`
+		Pinned      bool            `json:"pinned"`
+		Uploader    *Uploader       `json:"uploader"`
+		Collection  string          `json:"collection"`
+		Batch       string          `json:"batch"`
+		ExpiresAt   *time.Time      `json:"expires_at"`
 	}
 	if err := json.Unmarshal(bytes, &raw); err != nil {
 		return err
@@ -46,32 +125,25 @@ func (m *Media) UnmarshalJSON(bytes []byte) error {
 	m.ID = raw.ID
 	m.Format = raw.Format
 	m.Path = raw.Path
+	m.CreatedAt = raw.CreatedAt
+	m.Hash = raw.Hash
+	m.Size = raw.Size
+	m.Width = raw.Width
+	m.Height = raw.Height
+	m.Original = raw.Original
+	m.ContentType = raw.ContentType
+	m.MIME = raw.MIME
+	m.Pinned = raw.Pinned
+	m.Uploader = raw.Uploader
+	m.Collection = raw.Collection
+	m.Batch = raw.Batch
+	m.ExpiresAt = raw.ExpiresAt
 
-	var partialMeta struct {
-		Type meta.Type `json:"type"`
-	}
-	if err := json.Unmarshal(raw.Meta, &partialMeta); err != nil {
+	meta0, err := meta.Unmarshal(raw.Meta) // keeps missing metadata nil
+	if err != nil {
 		return err
 	}
 
-	switch partialMeta.Type {
-	case meta.TypeGeneric:
-		var meta0 meta.GenericMetadata
-		if err := json.Unmarshal(raw.Meta, &meta0); err != nil {
-			return err
-		}
-
-		m.Meta = &meta0
-	case meta.TypeAnime:
-		var meta0 meta.AnimeMetadata
-		if err := json.Unmarshal(raw.Meta, &meta0); err != nil {
-			return err
-		}
-
-		m.Meta = &meta0
-	default:
-		return fmt.Errorf("unexpected metadata type %d", partialMeta.Type)
-	}
-
+	m.Meta = meta0
 	return nil
 }
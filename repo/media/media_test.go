@@ -0,0 +1,29 @@
+package media
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestParseIDFromFilename(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{id.String() + ".jpg", true},
+		{id.String() + ".orig.gif", true},
+		{id.String(), true},
+		{"ab/cd/" + id.String() + ".webm", true},
+		{"cat.jpg", false},
+		{"x" + id.String() + ".jpg", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseIDFromFilename(tt.name)
+		if ok != tt.ok || (ok && got != id) {
+			t.Errorf("ParseIDFromFilename(%q) = %s, %t, want %t", tt.name, got, ok, tt.ok)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package meta
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Type is a type of metadata.
 type Type uint
 
@@ -10,6 +15,33 @@ const (
 	TypeAnime
 )
 
+// Types are all metadata types.
+var Types = []Type{TypeGeneric, TypeAnime}
+
+// String returns the string representation of the type.
+func (t Type) String() string {
+	switch t {
+	case TypeGeneric:
+		return "generic"
+	case TypeAnime:
+		return "anime"
+	}
+
+	return "unknown"
+}
+
+// ParseType parses the string representation of a type, returns false if it is unknown.
+func ParseType(s string) (Type, bool) {
+	switch s {
+	case "generic":
+		return TypeGeneric, true
+	case "anime":
+		return TypeAnime, true
+	}
+
+	return 0, false
+}
+
 // Metadata is a piece of media metadata.
 type Metadata interface {
 	// Type returns the type of the metadata.
@@ -21,3 +53,65 @@ type Matchable interface {
 	// Matches tries to match against a string query.
 	Matches(query string) bool
 }
+
+// Unmarshal reads metadata from a JSON representation discriminated by its type, returns nil for null.
+func Unmarshal(b []byte) (Metadata, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+
+	var partial struct {
+		Type Type `json:"type"`
+	}
+	if err := json.Unmarshal(b, &partial); err != nil {
+		return nil, err
+	}
+
+	var m Metadata
+	switch partial.Type {
+	case TypeGeneric:
+		m = &GenericMetadata{}
+	case TypeAnime:
+		m = &AnimeMetadata{}
+	default:
+		return nil, fmt.Errorf("unexpected metadata type %d", partial.Type)
+	}
+
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Inherit fills the empty fields of metadata with those of parent metadata of the same type, i.e. of a collection.
+// Returns parent if m is nil, m is returned as-is if parent is nil or of a different type.
+func Inherit(m, parent Metadata) Metadata {
+	if m == nil {
+		return parent
+	}
+
+	switch v := m.(type) {
+	case *GenericMetadata:
+		if p, ok := parent.(*GenericMetadata); ok {
+			return &GenericMetadata{
+				Source:     orParent(v.Source, p.Source),
+				Artist:     orParent(v.Artist, p.Artist),
+				ArtistLink: orParent(v.ArtistLink, p.ArtistLink),
+			}
+		}
+	case *AnimeMetadata:
+		if p, ok := parent.(*AnimeMetadata); ok {
+			return &AnimeMetadata{Name: orParent(v.Name, p.Name)}
+		}
+	}
+
+	return m
+}
+
+// orParent returns the parent value if the value is empty.
+func orParent(v, parent string) string {
+	if v == "" {
+		return parent
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"fmt"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// metaIndex is a secondary index of media by the values of metadata fields.
+// Media is indexed by its effective metadata, including fields inherited from its collection (see ResolveMeta).
+type metaIndex map[FacetField]map[string][]uuid.UUID
+
+// newMetaIndex creates an index of the metadata fields, returns nil if there are none.
+func newMetaIndex(fields []FacetField) (metaIndex, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	mi := make(metaIndex, len(fields))
+	for _, field := range fields {
+		switch field {
+		case FacetArtist, FacetSource, FacetName:
+		default:
+			return nil, fmt.Errorf("unknown indexed metadata field %q", field)
+		}
+
+		mi[field] = make(map[string][]uuid.UUID)
+	}
+
+	return mi, nil
+}
+
+// add adds media with its effective metadata to the index.
+func (mi metaIndex) add(id uuid.UUID, m meta.Metadata) {
+	for field, values := range mi {
+		if v := facetValue(m, field); v != "" {
+			values[v] = append(values[v], id)
+		}
+	}
+}
+
+// remove removes media with its effective metadata from the index.
+func (mi metaIndex) remove(id uuid.UUID, m meta.Metadata) {
+	for field, values := range mi {
+		v := facetValue(m, field)
+		if v == "" {
+			continue
+		}
+
+		ids := slices.DeleteFunc(values[v], func(id0 uuid.UUID) bool {
+			return id0 == id
+		})
+		if len(ids) == 0 {
+			delete(values, v)
+		} else {
+			values[v] = ids
+		}
+	}
+}
+
+// reindexMeta rebuilds the metadata index, i.e. after the metadata inherited from a collection changed.
+func (r *Repository) reindexMeta() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.metaIndex == nil {
+		return
+	}
+
+	r.metaIndex, _ = newMetaIndex(r.opts.IndexedFields) // fields were validated on creation
+	for _, m := range r.items {
+		r.metaIndex.add(m.ID, r.ResolveMeta(m))
+	}
+}
+
+// FindByMeta looks up media by the exact value of an indexed metadata field in its effective metadata.
+// Returns false if the field isn't indexed, see Options.IndexedFields.
+func (r *Repository) FindByMeta(field FacetField, value string) ([]*media.Media, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values, ok := r.metaIndex[field]
+	if !ok {
+		return nil, false
+	}
+
+	ids := values[value]
+	res := make([]*media.Media, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := r.items[id]; ok { // the index is briefly stale while a collection change is reindexed
+			res = append(res, m)
+		}
+	}
+
+	return res, true
+}
@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// findIDs looks up media by an indexed metadata field, returning their sorted IDs.
+func findIDs(t *testing.T, r *Repository, field FacetField, value string) []uuid.UUID {
+	t.Helper()
+
+	ms, ok := r.FindByMeta(field, value)
+	if !ok {
+		t.Fatalf("field %s isn't indexed", field)
+	}
+	return sortedIDs(ms)
+}
+
+// sortedIDs returns the sorted IDs of media.
+func sortedIDs(ms []*media.Media) []uuid.UUID {
+	ids := make([]uuid.UUID, len(ms))
+	for i, m := range ms {
+		ids[i] = m.ID
+	}
+
+	slices.SortFunc(ids, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+	return ids
+}
+
+func TestFindByMeta(t *testing.T) {
+	var (
+		r = newTestRepo(t, &Options{IndexedFields: []FacetField{FacetArtist, FacetName}})
+		a = addTestMeta(t, r, &meta.GenericMetadata{Artist: "a"})
+		b = addTestMeta(t, r, &meta.GenericMetadata{Artist: "b"})
+		c = addTestMeta(t, r, &meta.GenericMetadata{Artist: "a", Source: "https://example.com"})
+		d = addTestMeta(t, r, &meta.AnimeMetadata{Name: "a"})
+	)
+
+	if got, want := findIDs(t, r, FacetArtist, "a"), sortedIDs([]*media.Media{a, c}); !slices.Equal(got, want) {
+		t.Errorf("artist a = %v, want %v", got, want)
+	}
+	if got := findIDs(t, r, FacetName, "a"); !slices.Equal(got, []uuid.UUID{d.ID}) {
+		t.Errorf("name a = %v, want %v", got, d.ID)
+	}
+	if _, ok := r.FindByMeta(FacetSource, "https://example.com"); ok {
+		t.Error("lookup by an unindexed field succeeded")
+	}
+
+	if err := r.Remove(context.Background(), a.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if got := findIDs(t, r, FacetArtist, "a"); !slices.Equal(got, []uuid.UUID{c.ID}) {
+		t.Errorf("artist a after removal = %v, want %v", got, c.ID)
+	}
+
+	if _, err := r.Update(context.Background(), b.ID, &Update{Meta: &meta.GenericMetadata{Artist: "c"}}); err != nil {
+		t.Fatalf("failed to update media: %v", err)
+	}
+	if got := findIDs(t, r, FacetArtist, "b"); len(got) != 0 {
+		t.Errorf("artist b after an update = %v, want none", got)
+	}
+	if got := findIDs(t, r, FacetArtist, "c"); !slices.Equal(got, []uuid.UUID{b.ID}) {
+		t.Errorf("artist c after an update = %v, want %v", got, b.ID)
+	}
+}
+
+func TestFindByMetaInherited(t *testing.T) {
+	r := newTestRepo(t, &Options{IndexedFields: []FacetField{FacetArtist}})
+	if err := r.PutCollection(context.Background(), &Collection{ID: "album", Meta: &meta.GenericMetadata{Artist: "a"}}); err != nil {
+		t.Fatalf("failed to create collection: %v", err)
+	}
+
+	id := uuid.New()
+	member := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), CreatedAt: time.Now(), Collection: "album"}
+	if err := r.Add(context.Background(), member); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+	own := addTestMeta(t, r, &meta.GenericMetadata{Artist: "b"})
+
+	if got := findIDs(t, r, FacetArtist, "a"); !slices.Equal(got, []uuid.UUID{member.ID}) {
+		t.Errorf("inherited artist a = %v, want %v", got, member.ID)
+	}
+	if got := r.List(&Query{Meta: map[FacetField]string{FacetArtist: "a"}}); len(got) != 1 || got[0].ID != member.ID {
+		t.Error("listing by an inherited artist didn't list the collection member")
+	}
+
+	// changes of the collection are reindexed
+	if err := r.PutCollection(context.Background(), &Collection{ID: "album", Meta: &meta.GenericMetadata{Artist: "b"}}); err != nil {
+		t.Fatalf("failed to update collection: %v", err)
+	}
+	if got := findIDs(t, r, FacetArtist, "a"); len(got) != 0 {
+		t.Errorf("artist a after a collection change = %v, want none", got)
+	}
+	if got, want := findIDs(t, r, FacetArtist, "b"), sortedIDs([]*media.Media{member, own}); !slices.Equal(got, want) {
+		t.Errorf("artist b after a collection change = %v, want %v", got, want)
+	}
+	if got, want := r.Facets(FacetArtist, 0), []Facet{{"b", 2}}; !slices.Equal(got, want) {
+		t.Errorf("artist facets = %v, want %v", got, want)
+	}
+
+	if _, err := r.RemoveCollection(context.Background(), "album"); err != nil {
+		t.Fatalf("failed to remove collection: %v", err)
+	}
+	if got := findIDs(t, r, FacetArtist, "b"); !slices.Equal(got, []uuid.UUID{own.ID}) {
+		t.Errorf("artist b after removing the collection = %v, want %v", got, own.ID)
+	}
+
+	// unindexed fields are filtered by scanning
+	if got := r.List(&Query{Meta: map[FacetField]string{FacetArtist: "b", FacetSource: ""}}); len(got) != 1 || got[0].ID != own.ID {
+		t.Error("listing by an indexed and an unindexed field didn't list only the matching media")
+	}
+}
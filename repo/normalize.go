@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/repo/media/meta"
+	"strings"
+)
+
+// Normalization is a set of rules applied to metadata strings before they are stored.
+type Normalization struct {
+	// Trim removes leading and trailing whitespace.
+	Trim bool
+	// CollapseWhitespace replaces runs of whitespace with a single space, implies Trim.
+	CollapseWhitespace bool
+	// Lowercase converts names (artists, anime names) to lower case, links are left as-is.
+	Lowercase bool
+}
+
+// text normalizes a metadata string, name is whether it is a name rather than a link.
+func (n *Normalization) text(s string, name bool) string {
+	if n.CollapseWhitespace {
+		s = strings.Join(strings.Fields(s), " ")
+	} else if n.Trim {
+		s = strings.TrimSpace(s)
+	}
+	if n.Lowercase && name {
+		s = strings.ToLower(s)
+	}
+
+	return s
+}
+
+// metadata returns a normalized copy of metadata.
+func (n *Normalization) metadata(m meta.Metadata) meta.Metadata {
+	switch m := m.(type) {
+	case *meta.GenericMetadata:
+		return &meta.GenericMetadata{
+			Source:     n.text(m.Source, false),
+			Artist:     n.text(m.Artist, true),
+			ArtistLink: n.text(m.ArtistLink, false),
+		}
+	case *meta.AnimeMetadata:
+		return &meta.AnimeMetadata{Name: n.text(m.Name, true)}
+	}
+
+	return m
+}
@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	r := newTestRepo(t, &Options{Normalization: &Normalization{CollapseWhitespace: true, Lowercase: true}})
+
+	var artists []string
+	for _, artist := range []string{"Artist Name", " artist  name ", "ARTIST\tNAME"} {
+		m, err := r.Create(context.Background(), testPNG(t, len(artists)+1, 1), &meta.GenericMetadata{Artist: artist, Source: " https://example.com/A "}, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+
+		gm := m.Meta.(*meta.GenericMetadata)
+		if gm.Source != "https://example.com/A" {
+			t.Errorf("source = %q, want it trimmed but not lowercased", gm.Source)
+		}
+		artists = append(artists, gm.Artist)
+	}
+	for _, artist := range artists {
+		if artist != "artist name" {
+			t.Errorf("artists normalized to %q, want all equal to \"artist name\"", artists)
+			break
+		}
+	}
+
+	m := mustCreate(t, r, testPNG(t, 8, 1), nil)
+	m, err := r.Update(context.Background(), m.ID, &Update{Meta: &meta.AnimeMetadata{Name: "  Some  Anime "}})
+	if err != nil {
+		t.Fatalf("failed to update media: %v", err)
+	}
+	if name := m.Meta.(*meta.AnimeMetadata).Name; name != "some anime" {
+		t.Errorf("updated name = %q, want \"some anime\"", name)
+	}
+}
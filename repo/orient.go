@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/cephxdev/nero/internal/errors"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+	"image"
+	"image/jpeg"
+)
+
+// orientQuality is the JPEG quality of re-encoded auto-oriented images.
+const orientQuality = 95
+
+// exifOrientationTag is the EXIF tag of the image orientation.
+const exifOrientationTag = 0x0112
+
+// jpegOrientation returns the EXIF orientation (1-8) of a JPEG image, 1 (upright) if it is missing or malformed.
+func jpegOrientation(b []byte) int {
+	if len(b) < 4 || b[0] != 0xff || b[1] != 0xd8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(b); {
+		if b[i] != 0xff {
+			return 1
+		}
+
+		marker := b[i+1]
+		if marker == 0xd8 || (marker >= 0xd0 && marker <= 0xd7) || marker == 0xff { // markers without a length
+			i += 2
+			continue
+		}
+		if marker == 0xda || marker == 0xd9 { // image data starts, EXIF precedes it
+			return 1
+		}
+
+		end := i + 2 + int(binary.BigEndian.Uint16(b[i+2:]))
+		if end > len(b) {
+			return 1
+		}
+		if seg := b[i+4 : end]; marker == 0xe1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return tiffOrientation(seg[6:])
+		}
+
+		i = end
+	}
+
+	return 1
+}
+
+// tiffOrientation returns the orientation tag value of the first IFD of a TIFF structure, 1 if it is missing or malformed.
+func tiffOrientation(b []byte) int {
+	if len(b) < 8 {
+		return 1
+	}
+
+	var bo binary.ByteOrder
+	switch string(b[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(bo.Uint32(b[4:]))
+	if ifd < 8 || ifd+2 > len(b) {
+		return 1
+	}
+
+	n := int(bo.Uint16(b[ifd:]))
+	for i := 0; i < n; i++ {
+		e := ifd + 2 + i*12
+		if e+12 > len(b) {
+			break
+		}
+		if bo.Uint16(b[e:]) == exifOrientationTag && bo.Uint16(b[e+2:]) == 3 { // SHORT
+			if o := int(bo.Uint16(b[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			break
+		}
+	}
+
+	return 1
+}
+
+// autoOrient rotates and flips the pixels of a JPEG image to match its EXIF orientation.
+// The image is re-encoded without any EXIF data, images that are upright already are returned as-is.
+func autoOrient(b []byte) ([]byte, error) {
+	o := jpegOrientation(b)
+	if o == 1 {
+		return b, nil
+	}
+
+	src, err := jpeg.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode image")
+	}
+
+	var (
+		w, h = float64(src.Bounds().Dx()), float64(src.Bounds().Dy())
+		dw   = src.Bounds().Dx()
+		dh   = src.Bounds().Dy()
+		m    f64.Aff3 // maps source to destination coordinates
+	)
+	switch o {
+	case 2: // mirrored horizontally
+		m = f64.Aff3{-1, 0, w, 0, 1, 0}
+	case 3: // rotated by 180°
+		m = f64.Aff3{-1, 0, w, 0, -1, h}
+	case 4: // mirrored vertically
+		m = f64.Aff3{1, 0, 0, 0, -1, h}
+	case 5: // transposed
+		m, dw, dh = f64.Aff3{0, 1, 0, 1, 0, 0}, dh, dw
+	case 6: // rotated by 90° clockwise
+		m, dw, dh = f64.Aff3{0, -1, h, 1, 0, 0}, dh, dw
+	case 7: // transversed
+		m, dw, dh = f64.Aff3{0, -1, h, -1, 0, w}, dh, dw
+	case 8: // rotated by 90° counter-clockwise
+		m, dw, dh = f64.Aff3{0, 1, 0, -1, 0, w}, dh, dw
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	draw.NearestNeighbor.Transform(dst, m, src, src.Bounds(), draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: orientQuality}); err != nil {
+		return nil, errors.Wrap(err, "failed to encode image")
+	}
+
+	return buf.Bytes(), nil
+}
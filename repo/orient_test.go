@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"testing"
+)
+
+// testJPEG encodes a 16×8 JPEG image, red on the left and blue on the right, with an EXIF orientation.
+func testJPEG(t *testing.T, orientation uint16) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 8; y++ {
+			c := color.RGBA{R: 0xff, A: 0xff}
+			if x >= 8 {
+				c = color.RGBA{B: 0xff, A: 0xff}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+
+	// big-endian TIFF header with a single IFD holding only the orientation
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // padding, no next IFD
+
+	app1 := append([]byte{0xff, 0xe1}, binary.BigEndian.AppendUint16(nil, uint16(2+6+len(tiff)))...)
+	app1 = append(append(app1, "Exif\x00\x00"...), tiff...)
+
+	b := buf.Bytes()
+	return append(append(b[:2:2], app1...), b[2:]...) // right after the SOI marker
+}
+
+func TestAutoOrient(t *testing.T) {
+	b := testJPEG(t, 6)
+	if o := jpegOrientation(b); o != 6 {
+		t.Fatalf("jpegOrientation = %d, want 6", o)
+	}
+
+	if m := mustCreate(t, newTestRepo(t, nil), b, nil); m.Width != 16 || m.Height != 8 {
+		t.Errorf("image was rotated without auto-orientation: %d×%d", m.Width, m.Height)
+	}
+
+	r := newTestRepo(t, &Options{AutoOrient: true})
+	m := mustCreate(t, r, b, nil)
+	if m.Width != 8 || m.Height != 16 {
+		t.Errorf("rotated image is %d×%d, want 8×16", m.Width, m.Height)
+	}
+
+	b0, err := os.ReadFile(m.Path)
+	if err != nil {
+		t.Fatalf("failed to read media: %v", err)
+	}
+	if o := jpegOrientation(b0); o != 1 || bytes.Contains(b0, []byte("Exif\x00\x00")) {
+		t.Errorf("orientation tag wasn't cleared, orientation = %d", o)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(b0))
+	if err != nil {
+		t.Fatalf("failed to decode rotated image: %v", err)
+	}
+	// rotated clockwise, the left half is on top
+	if r, _, b, _ := img.At(4, 2).RGBA(); r < b {
+		t.Error("top half of the rotated image isn't red")
+	}
+	if r, _, b, _ := img.At(4, 13).RGBA(); b < r {
+		t.Error("bottom half of the rotated image isn't blue")
+	}
+}
@@ -0,0 +1,55 @@
+package repo
+
+import "go.uber.org/zap"
+
+// Orphans returns the paths of files in the repository directory not referenced by any media.
+// Hidden files and index files are never considered orphaned.
+func (r *Repository) Orphans() ([]string, error) {
+	if r.path == "" {
+		return nil, nil
+	}
+
+	files, err := r.contentFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	referenced := make(map[string]struct{}, len(r.items))
+	for _, m := range r.items {
+		referenced[m.Path] = struct{}{}
+		if m.Original != "" {
+			referenced[m.Original] = struct{}{}
+		}
+	}
+	r.mu.RUnlock()
+
+	var paths []string
+	for _, path := range files {
+		if _, ok := referenced[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// reportOrphans counts the orphaned files of the repository in the load summary and logs them, see Orphans.
+func (r *Repository) reportOrphans() error {
+	paths, err := r.Orphans()
+	if err != nil {
+		return err
+	}
+
+	r.loadSummary.Orphans = len(paths)
+	for _, path := range paths {
+		r.logger.Debug("orphaned file", zap.String("repo", r.id), zap.String("path", path))
+	}
+	if len(paths) > 0 {
+		r.logger.Warn("found files not referenced by the index", zap.String("repo", r.id), zap.Int("orphans", len(paths)))
+	} else {
+		r.logger.Info("found no files not referenced by the index", zap.String("repo", r.id))
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"slices"
+)
+
+// MaxPrefetch is the maximum amount of media prefetched after serving an item, see Options.Prefetch.
+const MaxPrefetch = 16
+
+// Prefetch loads the media following served media in a sort order into the media cache in the background,
+// reducing the latency of paging through sequences, e.g. manga pages. The sequence is the collection of the media
+// if it's in one, the whole repository otherwise, in order (Options.DefaultOrder if empty).
+// At most one prefetch runs at a time, requests made in the meantime are dropped.
+// Does nothing if prefetching is disabled, see Options.Prefetch.
+func (r *Repository) Prefetch(m *media.Media, order Order) {
+	if r.opts.Prefetch <= 0 {
+		return
+	}
+	select {
+	case r.prefetchSem <- struct{}{}:
+	default:
+		return // bounds the work, the next request picks up the sequence
+	}
+
+	r.prefetchWg.Add(1)
+	go func() {
+		defer func() {
+			<-r.prefetchSem
+			r.prefetchWg.Done()
+		}()
+
+		for _, next := range r.following(m, order, r.opts.Prefetch) {
+			if r.cache.get(next.ID, "") != nil {
+				continue
+			}
+
+			// content fitting into the cache is read into it fully on opening
+			f, err := r.Open(context.Background(), next)
+			if err == nil {
+				err = f.Close()
+			}
+			if err != nil {
+				r.logger.Debug(
+					"failed to prefetch media",
+					zap.String("repo", r.id),
+					zap.String("id", next.ID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}()
+}
+
+// following returns up to n media following media in its sequence, see Prefetch.
+func (r *Repository) following(m *media.Media, order Order, n int) []*media.Media {
+	q := &Query{Collection: m.Collection, Order: order, ExcludeUnknown: r.opts.HideUnknown}
+	if q.Order == "" {
+		q.Order = r.opts.DefaultOrder
+	}
+
+	seq := r.List(q)
+	i := slices.IndexFunc(seq, func(m0 *media.Media) bool {
+		return m0.ID == m.ID
+	})
+	if i < 0 {
+		return nil
+	}
+
+	return seq[i+1 : min(len(seq), i+1+n)]
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// createSequence creates n media with ascending creation times.
+func createSequence(t *testing.T, r *Repository, n int) []*media.Media {
+	t.Helper()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ms := make([]*media.Media, n)
+	for i := range ms {
+		ms[i] = mustCreate(t, r, testPNG(t, i+1, i+1), nil)
+		ms[i].CreatedAt = start.Add(time.Duration(i) * time.Hour) // keeps the order independent of the clock
+	}
+	return ms
+}
+
+func TestPrefetch(t *testing.T) {
+	r := newTestRepo(t, &Options{CacheSize: 1 << 20, Prefetch: 2})
+	ms := createSequence(t, r, 5)
+
+	// cached reports which media is in the media cache
+	cached := func() []bool {
+		res := make([]bool, len(ms))
+		for i, m := range ms {
+			res[i] = r.cache.get(m.ID, "") != nil
+		}
+		return res
+	}
+
+	r.Prefetch(ms[1], OrderCreatedAsc)
+	r.prefetchWg.Wait()
+	if got, want := cached(), []bool{false, false, true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("cached after prefetching in ascending order = %v, want %v", got, want)
+	}
+
+	r.Prefetch(ms[1], OrderCreatedDesc)
+	r.prefetchWg.Wait()
+	if got, want := cached(), []bool{true, false, true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("cached after prefetching in descending order = %v, want %v", got, want)
+	}
+
+	// the end of the sequence has nothing following it
+	r.Prefetch(ms[4], OrderCreatedAsc)
+	r.prefetchWg.Wait()
+	if got, want := cached(), []bool{true, false, true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("cached after prefetching at the end = %v, want %v", got, want)
+	}
+}
+
+func TestPrefetchDisabled(t *testing.T) {
+	r := newTestRepo(t, &Options{CacheSize: 1 << 20})
+	ms := createSequence(t, r, 2)
+
+	r.Prefetch(ms[0], OrderCreatedAsc)
+	r.prefetchWg.Wait()
+	if r.cache.get(ms[1].ID, "") != nil {
+		t.Error("media was prefetched with prefetching disabled")
+	}
+
+	dir := t.TempDir()
+	for _, opts := range []*Options{{Prefetch: 1}, {CacheSize: 1 << 20, Prefetch: MaxPrefetch + 1}} {
+		if r, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop()); err == nil {
+			r.Close()
+			t.Errorf("invalid prefetch options %+v were accepted", *opts)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"cmp"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Order is a sort order of listed media.
+type Order string
+
+const (
+	// OrderCreatedAsc sorts media from the oldest to the most recent, the default.
+	OrderCreatedAsc Order = "created_asc"
+	// OrderCreatedDesc sorts media from the most recent to the oldest.
+	OrderCreatedDesc Order = "created_desc"
+	// OrderPopular sorts media from the most downloaded to the least, then from the most recent to the oldest.
+	OrderPopular Order = "popular"
+)
+
+// Query is a media listing query, zero values of the fields mean no filtering.
+type Query struct {
+	// CreatedAfter filters out media created before or at this time.
+	CreatedAfter time.Time
+	// CreatedBefore filters out media created after or at this time.
+	CreatedBefore time.Time
+	// Pinned filters out media with a different pinned state, if not nil.
+	Pinned *bool
+	// ExcludeUnknown filters out media of an unknown format.
+	ExcludeUnknown bool
+	// Collection filters out media not in the collection with this ID.
+	Collection string
+	// Batch filters out media not uploaded in the import batch with this ID.
+	Batch string
+	// HashPrefix filters out media whose hex-encoded content hash doesn't start with this lowercase prefix,
+	// media without a known hash never matches.
+	HashPrefix string
+	// Missing filters out media that doesn't lack this derived field, e.g. for tracking the progress of Backfill.
+	Missing DerivedField
+	// Meta filters out media whose effective metadata (see Repository.ResolveMeta) doesn't have these exact field values.
+	// Indexed fields are looked up in the metadata index, see Options.IndexedFields.
+	Meta map[FacetField]string
+
+	// Offset is the amount of matching media to skip.
+	Offset int
+	// Limit is the maximum amount of media to return.
+	Limit int
+	// Order is the sort order of the media, OrderCreatedAsc if empty.
+	Order Order
+}
+
+// matches checks whether media matches the query filters.
+func (q *Query) matches(m *media.Media) bool {
+	if !q.CreatedAfter.IsZero() && !m.CreatedAt.After(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !m.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	if q.Pinned != nil && m.Pinned != *q.Pinned {
+		return false
+	}
+	if q.ExcludeUnknown && m.Format == media.FormatUnknown {
+		return false
+	}
+	if q.Collection != "" && m.Collection != q.Collection {
+		return false
+	}
+	if q.Batch != "" && m.Batch != q.Batch {
+		return false
+	}
+	if q.HashPrefix != "" && (m.Hash == "" || !strings.HasPrefix(m.Hash, q.HashPrefix)) {
+		return false
+	}
+	if q.Missing != "" && !q.Missing.missing(m) {
+		return false
+	}
+
+	return true
+}
+
+// List lists media matching a query, ordered by their creation time.
+func (r *Repository) List(q *Query) []*media.Media {
+	var res []*media.Media
+	for _, m := range r.candidates(q) {
+		if q.matches(m) && r.matchesMeta(q, m) {
+			res = append(res, m)
+		}
+	}
+
+	var counts map[uuid.UUID]int64
+	if q.Order == OrderPopular && r.counters != nil {
+		counts = r.counters.snapshot()
+	}
+
+	slices.SortFunc(res, func(a, b *media.Media) int {
+		if r.opts.PinnedFirst && a.Pinned != b.Pinned {
+			if a.Pinned {
+				return -1
+			}
+			return 1
+		}
+
+		if q.Order == OrderPopular {
+			if c := cmp.Compare(counts[b.ID], counts[a.ID]); c != 0 {
+				return c
+			}
+		}
+
+		c := a.CreatedAt.Compare(b.CreatedAt)
+		if c == 0 {
+			c = strings.Compare(a.ID.String(), b.ID.String())
+		}
+		if q.Order == OrderCreatedDesc || q.Order == OrderPopular {
+			return -c
+		}
+		return c
+	})
+
+	if q.Offset > 0 {
+		if q.Offset >= len(res) {
+			return nil
+		}
+		res = res[q.Offset:]
+	}
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+
+	return res
+}
+
+// candidates returns the media possibly matching a query, narrowed down by the metadata index if possible.
+func (r *Repository) candidates(q *Query) []*media.Media {
+	for field, value := range q.Meta {
+		if ms, ok := r.FindByMeta(field, value); ok {
+			return ms
+		}
+	}
+
+	return r.Items()
+}
+
+// matchesMeta checks whether the effective metadata of media matches the metadata filter of a query.
+func (r *Repository) matchesMeta(q *Query, m *media.Media) bool {
+	if len(q.Meta) == 0 {
+		return true
+	}
+
+	md := r.ResolveMeta(m)
+	for field, value := range q.Meta {
+		if facetValue(md, field) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DefaultQuery returns the query of media listed when no parameters are given.
+func (r *Repository) DefaultQuery() *Query {
+	return &Query{Limit: r.opts.DefaultLimit, Order: r.opts.DefaultOrder, ExcludeUnknown: r.opts.HideUnknown}
+}
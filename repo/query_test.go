@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// addTestMedia inserts media without content created at a time.
+func addTestMedia(t *testing.T, r *Repository, createdAt time.Time) *media.Media {
+	t.Helper()
+
+	id := uuid.New()
+	m := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), CreatedAt: createdAt}
+	if err := r.Add(context.Background(), m); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+	return m
+}
+
+func TestListCreatedRange(t *testing.T) {
+	var (
+		r     = newTestRepo(t, nil)
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		ms    = make([]*media.Media, 5)
+	)
+	for i := range ms {
+		ms[i] = addTestMedia(t, r, start.Add(time.Duration(i)*24*time.Hour))
+	}
+
+	tests := []struct {
+		name          string
+		after, before time.Time
+		want          []*media.Media
+	}{
+		{"unbounded", time.Time{}, time.Time{}, ms},
+		{"window", ms[0].CreatedAt, ms[4].CreatedAt, ms[1:4]},
+		{"after", ms[2].CreatedAt, time.Time{}, ms[3:]},
+		{"before", time.Time{}, ms[2].CreatedAt, ms[:2]},
+		{"empty", ms[4].CreatedAt, ms[0].CreatedAt, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.List(&Query{CreatedAfter: tt.after, CreatedBefore: tt.before})
+			if len(got) != len(tt.want) {
+				t.Fatalf("listed %d items, want %d", len(got), len(tt.want))
+			}
+			for i, m := range got {
+				if m.ID != tt.want[i].ID {
+					t.Errorf("item %d is %s, want %s", i, m.ID, tt.want[i].ID)
+				}
+			}
+		})
+	}
+
+	got := r.List(&Query{CreatedAfter: ms[0].CreatedAt, Order: OrderCreatedDesc, Limit: 2})
+	if len(got) != 2 || got[0].ID != ms[4].ID || got[1].ID != ms[3].ID {
+		t.Error("range filter doesn't combine with sorting and limits")
+	}
+}
+
+func TestListPinnedFirst(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, &Options{PinnedFirst: true})
+		ms  = make([]*media.Media, 3)
+	)
+	for i := range ms {
+		ms[i] = mustCreate(t, r, testPNG(t, i+1, 1), nil)
+	}
+
+	pinned := true
+	if _, err := r.Update(context.Background(), ms[1].ID, &Update{Pinned: &pinned}); err != nil {
+		t.Fatalf("failed to pin media: %v", err)
+	}
+
+	for _, order := range []Order{OrderCreatedAsc, OrderCreatedDesc} {
+		if got := r.List(&Query{Order: order}); len(got) != 3 || got[0].ID != ms[1].ID {
+			t.Errorf("pinned media isn't listed first in %s order", order)
+		}
+	}
+	if got := r.List(&Query{Pinned: &pinned}); len(got) != 1 || got[0].ID != ms[1].ID {
+		t.Error("pinned filter doesn't list only the pinned media")
+	}
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	for i, m := range ms {
+		if m0 := r0.Get(m.ID); m0 == nil || m0.Pinned != (i == 1) {
+			t.Errorf("pinned state of media %d didn't survive a reload", i)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"container/heap"
+	"github.com/cephxdev/nero/repo/media"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// WeightFunc assigns a selection weight to media, media with non-positive weights are never selected.
+type WeightFunc func(m *media.Media) float64
+
+// UniformWeight weighs all media equally.
+func UniformWeight(*media.Media) float64 {
+	return 1
+}
+
+// KnownWeight wraps a weight function to never select media of an unknown format.
+func KnownWeight(weight WeightFunc) WeightFunc {
+	return func(m *media.Media) float64 {
+		if m.Format == media.FormatUnknown {
+			return 0
+		}
+		return weight(m)
+	}
+}
+
+// RecencyWeight weighs media by 1 / (1 + age/scale), so media of age scale weigh half as much as new media.
+func RecencyWeight(scale time.Duration) WeightFunc {
+	now := time.Now()
+	return func(m *media.Media) float64 {
+		age := now.Sub(m.CreatedAt)
+		if age < 0 {
+			age = 0
+		}
+
+		return 1 / (1 + float64(age)/float64(scale))
+	}
+}
+
+// weightedItem is media with its sampling key.
+type weightedItem struct {
+	m   *media.Media
+	key float64
+}
+
+// weightedHeap is a min-heap of weighted items by their key.
+type weightedHeap []weightedItem
+
+func (h weightedHeap) Len() int           { return len(h) }
+func (h weightedHeap) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h weightedHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *weightedHeap) Push(x any)        { *h = append(*h, x.(weightedItem)) }
+func (h *weightedHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// WeightedRandom picks N random media out of the repository without replacement,
+// with probabilities proportional to their weights (Efraimidis-Spirakis sampling).
+func (r *Repository) WeightedRandom(n int, weight WeightFunc) []*media.Media {
+	if n <= 0 {
+		return nil
+	}
+
+	h := make(weightedHeap, 0, n)
+	for _, m := range r.Items() {
+		w := weight(m)
+		if w <= 0 || math.IsNaN(w) {
+			continue
+		}
+
+		// key = u^(1/w), compared in log space to avoid underflow
+		key := math.Log(1-rand.Float64()) / w
+		if h.Len() < n {
+			heap.Push(&h, weightedItem{m: m, key: key})
+		} else if key > h[0].key {
+			h[0] = weightedItem{m: m, key: key}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	res := make([]*media.Media, h.Len())
+	for i := len(res) - 1; i >= 0; i-- { // highest keys first
+		res[i] = heap.Pop(&h).(weightedItem).m
+	}
+	return res
+}
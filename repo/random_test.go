@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWeightedRandom(t *testing.T) {
+	var (
+		r     = newTestRepo(t, nil)
+		heavy = addTestMedia(t, r, time.Now()).ID
+		light = addTestMedia(t, r, time.Now()).ID
+		never = addTestMedia(t, r, time.Now()).ID
+	)
+	weights := map[uuid.UUID]float64{heavy: 9, light: 1, never: 0}
+	weight := func(m *media.Media) float64 {
+		return weights[m.ID]
+	}
+
+	const draws = 2000
+	counts := make(map[uuid.UUID]int)
+	for i := 0; i < draws; i++ {
+		res := r.WeightedRandom(1, weight)
+		if len(res) != 1 {
+			t.Fatalf("drew %d items, want 1", len(res))
+		}
+		counts[res[0].ID]++
+	}
+
+	// 90% expected, far outside of the variance of 2000 draws
+	if n := counts[heavy]; n < draws*8/10 || n > draws*95/100 {
+		t.Errorf("item weighing 90%% was drawn %d/%d times", n, draws)
+	}
+	if counts[never] != 0 {
+		t.Error("item weighing zero was drawn")
+	}
+
+	if res := r.WeightedRandom(3, weight); len(res) != 2 {
+		t.Errorf("drew %d items without replacement, want the 2 with positive weights", len(res))
+	}
+}
+
+func TestExcludeRandom(t *testing.T) {
+	r := newTestRepo(t, nil)
+
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = addTestMedia(t, r, time.Now()).ID
+	}
+	exclude := ids[:3]
+
+	for i := 0; i < 50; i++ {
+		res := r.ExcludeRandom(2, exclude)
+		if len(res) != 2 {
+			t.Fatalf("picked %d items, want 2", len(res))
+		}
+		for _, m := range res {
+			if slices.Contains(exclude, m.ID) {
+				t.Fatalf("excluded item %s was picked while enough others exist", m.ID)
+			}
+		}
+	}
+
+	res := r.ExcludeRandom(4, exclude)
+	if len(res) != 4 {
+		t.Fatalf("picked %d items, want excluded ones to fill up to 4", len(res))
+	}
+
+	var excluded int
+	for _, m := range res {
+		if slices.Contains(exclude, m.ID) {
+			excluded++
+		}
+	}
+	if excluded != 2 {
+		t.Errorf("picked %d excluded items, want only the 2 missing ones", excluded)
+	}
+}
+
+func TestRandomUniform(t *testing.T) {
+	r := newTestRepo(t, nil)
+
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = addTestMedia(t, r, time.Now()).ID
+	}
+
+	const draws = 5000
+	var (
+		counts = make(map[uuid.UUID]int)
+		firsts = make(map[uuid.UUID]int)
+	)
+	for i := 0; i < draws; i++ {
+		res := r.Random(2)
+		if len(res) != 2 || res[0].ID == res[1].ID {
+			t.Fatalf("picked %v, want 2 distinct items", res)
+		}
+		for _, m := range res {
+			counts[m.ID]++
+		}
+		firsts[res[0].ID]++
+	}
+
+	// 2/5 and 1/5 expected, far outside of the variance of 5000 draws
+	for _, id := range ids {
+		if n := counts[id]; n < draws*35/100 || n > draws*45/100 {
+			t.Errorf("item was picked %d/%d times, want about 40%%", n, draws)
+		}
+		if n := firsts[id]; n < draws*15/100 || n > draws*25/100 {
+			t.Errorf("item was picked first %d/%d times, want about 20%%", n, draws)
+		}
+	}
+
+	if res := r.Random(10); len(res) != len(ids) {
+		t.Errorf("picked %d items out of %d, want all of them", len(res), len(ids))
+	}
+}
+
+func BenchmarkRandom(b *testing.B) {
+	r := &Repository{items: make(map[uuid.UUID]*media.Media)}
+	for i := 0; i < 100_000; i++ {
+		id := uuid.New()
+		r.items[id] = &media.Media{ID: id, Format: media.FormatImage}
+	}
+
+	b.Run("shuffle", func(b *testing.B) { // the previous approach, shuffling a copy of all items
+		for i := 0; i < b.N; i++ {
+			v := r.Items()
+			rand.Shuffle(len(v), func(i, j int) {
+				v[i], v[j] = v[j], v[i]
+			})
+			_ = v[:1]
+		}
+	})
+	b.Run("sample", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = r.Random(1)
+		}
+	})
+}
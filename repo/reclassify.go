@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	mime "github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"io"
+	"os"
+)
+
+// FormatChange is media whose stored format was corrected.
+type FormatChange struct {
+	ID       uuid.UUID
+	From, To media.Format
+}
+
+// ReclassifyWebP corrects the format of WebP media classified before static and animated WebP images were told apart,
+// which stored all of them as animated images, and persists the updated index.
+// Media files aren't moved, even if they are stored in per-format subdirectories (Options.FormatDirs).
+// If dryRun is true, the changes are only reported. Returns the corrected media.
+func (r *Repository) ReclassifyWebP(ctx context.Context, dryRun bool) (changes []FormatChange, err error) {
+	if r.Ephemeral() && !dryRun {
+		return nil, errors.New("repository index is ephemeral, corrected formats wouldn't be persisted")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, m := range r.items {
+		if err = ctx.Err(); err != nil {
+			return changes, err
+		}
+		if m.Format != media.FormatImage && m.Format != media.FormatAnimatedImage {
+			continue
+		}
+
+		head, err := readHead(m.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // missing content is reported on load
+		}
+		if err != nil {
+			return changes, err
+		}
+
+		type_ := mime.Detect(head)
+		if !type_.Is("image/webp") {
+			continue
+		}
+		if format := detectFormat(type_, head); format != m.Format {
+			if !dryRun {
+				m0 := *m
+				m0.Format = format
+				r.items[id] = &m0
+			}
+			changes = append(changes, FormatChange{ID: id, From: m.Format, To: format})
+		}
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+	return changes, r.save(ctx)
+}
+
+// readHead reads the leading bytes of a file used for detecting its content type.
+func readHead(path string) (_ []byte, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close file"))
+		}
+	}()
+
+	b := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, b)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	return b[:n], nil
+}
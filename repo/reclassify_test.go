@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testWebP builds the header of an extended format WebP image, with the animation flag set if animated.
+func testWebP(animated bool) []byte {
+	var flags byte
+	if animated {
+		flags = 1 << 1
+	}
+
+	vp8x := append([]byte("VP8X"), 10, 0, 0, 0, flags, 0, 0, 0, 0, 0, 0, 0, 0, 0) // 1×1 canvas
+	b := append([]byte("RIFF\x00\x00\x00\x00WEBP"), vp8x...)
+	binary.LittleEndian.PutUint32(b[4:8], uint32(len(b)-8))
+
+	return b
+}
+
+func TestReclassifyWebP(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, nil)
+	)
+	add := func(name string, b []byte) *media.Media {
+		t.Helper()
+
+		m := &media.Media{ID: uuid.New(), Format: media.FormatAnimatedImage, Path: filepath.Join(dir, name)}
+		if err := os.WriteFile(m.Path, b, 0644); err != nil {
+			t.Fatalf("failed to write media file: %v", err)
+		}
+		if err := r.Add(context.Background(), m); err != nil {
+			t.Fatalf("failed to add media: %v", err)
+		}
+		return m
+	}
+
+	var (
+		static   = add("static.webp", testWebP(false))
+		animated = add("animated.webp", testWebP(true))
+		gif      = add("animated.gif", testGIF(t, 2, 2, 2))
+	)
+
+	changes, err := r.ReclassifyWebP(context.Background(), true)
+	if err != nil {
+		t.Fatalf("failed to reclassify media: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != (FormatChange{ID: static.ID, From: media.FormatAnimatedImage, To: media.FormatImage}) {
+		t.Fatalf("dry run reported %v, want only the static WebP image", changes)
+	}
+	if r.Get(static.ID).Format != media.FormatAnimatedImage {
+		t.Error("dry run corrected the format")
+	}
+
+	if _, err := r.ReclassifyWebP(context.Background(), false); err != nil {
+		t.Fatalf("failed to reclassify media: %v", err)
+	}
+	if r.Get(static.ID).Format != media.FormatImage {
+		t.Error("static WebP image is still classified as animated")
+	}
+	if r.Get(animated.ID).Format != media.FormatAnimatedImage || r.Get(gif.ID).Format != media.FormatAnimatedImage {
+		t.Error("animated images were reclassified")
+	}
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if m := r0.Get(static.ID); m == nil || m.Format != media.FormatImage {
+		t.Error("corrected format didn't survive a reload")
+	}
+}
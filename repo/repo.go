@@ -2,7 +2,11 @@ package repo
 
 import (
 	"bufio"
-	"encoding/json"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo/media"
 	"github.com/cephxdev/nero/repo/media/meta"
@@ -11,15 +15,25 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
+	"golang.org/x/sync/singleflight"
+	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 const (
 	// AuthKey is an authentication key metadata key.
 	AuthKey = "auth_key"
+	// AdminKey is an administration key metadata key, administrative endpoints are disabled without it.
+	AdminKey = "admin_key"
+	// UploadSigningKey is a metadata key of the secret pre-signed upload tokens are signed with,
+	// pre-signed uploads are disabled without it.
+	UploadSigningKey = "upload_signing_key"
 )
 
 // Metadata is repository metadata.
@@ -35,30 +49,306 @@ func (m Metadata) Value(key string) (string, bool) {
 	return v, ok
 }
 
+// CollisionPolicy is a policy for handling index items sharing a file path when loading a repository.
+type CollisionPolicy string
+
+const (
+	// CollisionKeepFirst keeps the first item referencing a path and skips the rest with a warning, the default.
+	CollisionKeepFirst CollisionPolicy = "keep_first"
+	// CollisionError fails loading the repository.
+	CollisionError CollisionPolicy = "error"
+)
+
+// MetaParsing is a policy for parsing metadata sent by API clients.
+type MetaParsing string
+
+const (
+	// MetaParsingStrict rejects metadata of unknown types and with unknown fields, the default.
+	MetaParsingStrict MetaParsing = "strict"
+	// MetaParsingLenient ignores unknown fields and stores metadata of unknown types as generic metadata,
+	// keeping only the fields generic metadata has, so newer clients degrade gracefully.
+	MetaParsingLenient MetaParsing = "lenient"
+)
+
+// Options is a set of optional repository settings.
+type Options struct {
+	// PathCollision is the policy for index items sharing a file path.
+	PathCollision CollisionPolicy
+	// Symlinks is the policy for media paths that are symbolic links.
+	Symlinks SymlinkPolicy
+	// RemoveSymlinkTargets is whether removing media stored as a symbolic link should also remove the link target.
+	// Media content is never removed from storage otherwise.
+	RemoveSymlinkTargets bool
+	// ScanExisting is whether files in the repository directory should be indexed if there is no index file yet.
+	ScanExisting bool
+	// FormatDirs is whether new media files should be stored in per-format subdirectories of the repository directory,
+	// "images", "animated", "videos" and "other" for media of an unknown format.
+	FormatDirs bool
+	// IDFromFilename recovers media IDs from file names when indexing existing files, media.ParseIDFromFilename if nil.
+	// Files without a recoverable ID are assigned new IDs.
+	IDFromFilename func(name string) (uuid.UUID, bool)
+	// NewID generates the IDs of new media, uuid.New if nil. Taken IDs are retried, see MaxIDAttempts.
+	NewID func() uuid.UUID
+	// Durability is the durability level of index and media file writes, DurabilityNone if empty.
+	Durability Durability
+	// EphemeralIndex is whether index changes should only be kept in memory, the index file is loaded but never written.
+	// Media content is still stored in the repository directory, unlike with NewMemory.
+	EphemeralIndex bool
+	// ReportOrphans is whether files in the repository directory not referenced by the index should be reported on load.
+	ReportOrphans bool
+	// AllowDegraded is whether the repository should be loaded with the readable items of a partially corrupt index,
+	// instead of failing.
+	AllowDegraded bool
+	// LoadWarningThreshold is the amount of items skipped while loading the index, e.g. because of missing files,
+	// above which the repository is reported as degraded, disabled if zero. See Repository.LoadSummary.
+	LoadWarningThreshold int
+	// IndexBackups is the amount of timestamped index file backups kept across saves, disabled if zero.
+	IndexBackups int
+	// CanonicalIndex is whether index files should be written deterministically, ordering items by their creation time
+	// and ID, so saving unchanged media yields an identical file, e.g. for committing it to version control.
+	CanonicalIndex bool
+	// Codec is the serialization format of written index files, JSONCodec if nil.
+	// Index files are read with the codec named in their header, regardless of this setting.
+	Codec Codec
+	// ValidateImages is whether uploaded images should be fully decoded to reject corrupt ones.
+	ValidateImages bool
+	// MinWidth and MinHeight are the minimum dimensions of uploaded images in pixels, unlimited if zero.
+	MinWidth, MinHeight int
+	// MaxWidth and MaxHeight are the maximum dimensions of uploaded images in pixels, unlimited if zero.
+	MaxWidth, MaxHeight int
+	// MaxFrames is the maximum frame count of uploaded animated images, unlimited if zero.
+	MaxFrames int
+	// SVG is the policy for uploaded SVG images.
+	SVG SVGPolicy
+	// AutoOrient is whether uploaded JPEG images should be rotated upright according to their EXIF orientation.
+	// Rotated images are re-encoded, which strips their EXIF data.
+	AutoOrient bool
+	// DefaultLimit is the amount of media listed when no query parameters are given, unlimited if zero.
+	DefaultLimit int
+	// DefaultOrder is the sort order of media listed when no query parameters are given.
+	DefaultOrder Order
+	// IndexedFields are the metadata fields media is indexed by for FindByMeta lookups.
+	IndexedFields []FacetField
+	// HideUnknown is whether media of an unknown format should be left out of listings and random picks by default.
+	HideUnknown bool
+	// EmptyRandomNotFound is whether random picks without any eligible media should be served as not found,
+	// instead of an empty list.
+	EmptyRandomNotFound bool
+	// PinnedFirst is whether pinned media should be listed before other media, regardless of the sort order.
+	PinnedFirst bool
+	// MetaParsing is the policy for parsing metadata sent by API clients, MetaParsingStrict if empty.
+	MetaParsing MetaParsing
+	// StrictUploads is whether upload requests with unknown fields should be rejected by the API,
+	// unknown fields are ignored otherwise. Unknown metadata fields are handled according to MetaParsing.
+	StrictUploads bool
+	// MetaTypes are the metadata types allowed in new and updated media, all types are allowed if empty.
+	MetaTypes []meta.Type
+	// Enrichers are run in order on the metadata of new media before it's normalized and stored.
+	Enrichers []MetaEnricher
+	// Normalization is the normalization of metadata strings of new media, disabled if nil.
+	Normalization *Normalization
+	// StoreMIME is whether the detected MIME type of new media should be stored in the index and served.
+	StoreMIME bool
+	// KeepExtension is whether the extension of an uploaded file name should be kept if it matches the content type.
+	KeepExtension bool
+	// RecordUploader is whether the client info of uploaders should be stored with new media, see CreateOptions.Uploader.
+	RecordUploader bool
+	// HashUploaderIP is whether recorded uploader IP addresses should be hashed, salted with UploaderIPSalt.
+	HashUploaderIP bool
+	// UploaderIPSalt is the salt of hashed uploader IP addresses.
+	UploaderIPSalt string
+
+	// CountDownloads is whether served media should be counted, see Repository.CountDownload.
+	CountDownloads bool
+	// CounterSaveInterval is the interval of persisting download counters, DefaultCounterSaveInterval if zero.
+	CounterSaveInterval time.Duration
+	// TrackAccess is whether the last access time of served media should be recorded, see Repository.Touch.
+	TrackAccess bool
+	// AccessSaveInterval is the interval of persisting last access times, DefaultAccessSaveInterval if zero.
+	AccessSaveInterval time.Duration
+
+	// CacheSize is the maximum size of the in-memory media cache in bytes, caching is disabled if zero.
+	CacheSize int64
+	// Prefetch is the amount of media following served media loaded into the media cache in the background,
+	// see Repository.Prefetch. Requires CacheSize, disabled if zero, at most MaxPrefetch.
+	Prefetch int
+	// WebP is the encoder of images served as WebP to clients accepting it, conversion is disabled if nil.
+	WebP WebPEncoder
+	// WebPCacheSize is the maximum size of the in-memory cache of WebP conversions in bytes,
+	// DefaultWebPCacheSize if zero.
+	WebPCacheSize int64
+	// ResizeMaxSize is the maximum width and height of images served resized in pixels, resizing is disabled if zero.
+	ResizeMaxSize int
+	// ResizeSizes are the widths and heights images may be resized to, any size up to ResizeMaxSize if empty.
+	// Restricting the sizes bounds the work and cache churn caused by arbitrary resize requests.
+	ResizeSizes []int
+	// ResizeCacheSize is the maximum size of the in-memory cache of resized images in bytes,
+	// DefaultResizeCacheSize if zero.
+	ResizeCacheSize int64
+	// MaxDecodePixels is the maximum pixel count of stored images decoded for serving converted,
+	// guarding against decompression bombs, DefaultMaxDecodePixels if zero.
+	MaxDecodePixels int64
+	// FrameExtractor renders the first frames of animated WebP images for thumbnails, they have none if nil.
+	FrameExtractor FrameExtractor
+	// ThumbnailCacheSize is the maximum size of the in-memory cache of thumbnails in bytes,
+	// DefaultThumbnailCacheSize if zero.
+	ThumbnailCacheSize int64
+	// ListCacheTTL is the time listings served by the API are cached for, caching is disabled if zero.
+	// Cached listings are dropped on any change of the repository, download counts and access times may be stale.
+	ListCacheTTL time.Duration
+	// ListCacheSize is the maximum amount of cached listings, DefaultListCacheSize if zero.
+	ListCacheSize int
+	// Upstream is the remote source of media content missing locally, may be nil.
+	Upstream Upstream
+	// TTL is the time media is kept for after its creation before it expires, unless overridden per item
+	// (CreateOptions.Expiry). Media expires only if overridden if zero, expired media is removed by Repository.Sweep.
+	TTL time.Duration
+	// SweepInterval is the interval of removing expired media in the background, DefaultSweepInterval if zero,
+	// disabled if negative. The background removal only starts once media can expire, with a TTL or a per-item expiry.
+	SweepInterval time.Duration
+	// Quota is the maximum total size of the media content in bytes, unlimited if zero.
+	Quota int64
+	// MinFreeSpace is the free disk space in bytes below which new media is rejected with ErrDiskFull,
+	// disabled if zero. The free space is measured periodically, so it may be undercut by concurrent uploads.
+	MinFreeSpace int64
+	// SoftQuota is the total size of the media content in bytes at which a usage warning is reported,
+	// e.g. ahead of reaching Quota, disabled if zero. Uploads are never rejected because of it.
+	SoftQuota int64
+	// SoftItemLimit is the amount of media at which a usage warning is reported, disabled if zero.
+	SoftItemLimit int
+
+	// Transcoder is the transcoder of animated images to video, transcoding is disabled if nil.
+	Transcoder Transcoder
+	// TranscodeThreshold is the size in bytes an animated image needs to exceed to be transcoded.
+	TranscodeThreshold int64
+	// KeepOriginal is whether the original content of transcoded media should be kept alongside it.
+	KeepOriginal bool
+
+	// Shared is the store new media content is moved into, deduplicating identical content across the repositories
+	// sharing it, may be nil. Content in the store is removed along with the last media referencing it.
+	Shared *SharedStore
+
+	// Hook is the external command run after media is created, may be nil.
+	Hook *Hook
+
+	// WriteBufferSize is the size of the copy buffer of streamed uploads in bytes, DefaultWriteBufferSize if zero.
+	WriteBufferSize int
+	// UploadConcurrency is the maximum amount of concurrently handled uploads, unlimited if zero.
+	UploadConcurrency int
+	// UploadQueueTimeout is the maximum time an upload waits for a free slot, uploads are rejected immediately if zero.
+	UploadQueueTimeout time.Duration
+}
+
+// DefaultListCacheSize is the default maximum amount of cached listings, see Options.ListCacheSize.
+const DefaultListCacheSize = 64
+
 // Repository is a media repository.
 type Repository struct {
 	id, path, lockPath string
 	meta               Metadata
+	opts               Options
 	logger             *zap.Logger
 
-	items map[uuid.UUID]*media.Media
-	mu    sync.RWMutex
+	items       map[uuid.UUID]*media.Media
+	reserved    map[uuid.UUID]struct{} // IDs of media being staged, see Repository.reserveID
+	hashes      map[string][]uuid.UUID // content hash secondary index
+	metaIndex   metaIndex              // metadata field secondary index, nil if no fields are indexed
+	counters    *counters              // nil if download counting is disabled
+	accessTimes *accessTimes           // nil if access tracking is disabled
+	collections *collectionStore
+	used        int64 // total size of the media content in bytes
+	cache       *cache
+	webpCache   *cache // nil if WebP conversion is disabled
+	resizeCache *cache // nil if resizing is disabled
+	thumbCache  *cache // nil for memory repositories
+	mu          sync.RWMutex
+
+	degraded    bool        // whether the index was only partially loaded
+	loadSummary LoadSummary // immutable after loading
+	softWarned  bool        // whether crossing the soft usage limits was logged already
+	freeSpace   freeSpaceCache
+	uploadSizes uploadSizes
+
+	sweepInterval        time.Duration // disabled if not positive, set once loaded
+	sweepStop, sweepDone chan struct{} // nil if expired media isn't removed in the background
+	sweepStopped         bool
+	sweepMu              sync.Mutex
+
+	prefetchSem chan struct{} // holds a token while a prefetch runs
+	prefetchWg  sync.WaitGroup
+
+	pulls       singleflight.Group // upstream fetches in progress, keyed by media ID
+	conversions singleflight.Group // conversions for serving in progress, keyed by kind, media ID and variant
+
+	subscribers   []func() // see Repository.Subscribe
+	subscribersMu sync.RWMutex
 }
 
 // NewMemory creates a Repository without a backing lock file and storage directory.
 func NewMemory(id string, meta Metadata, logger *zap.Logger) *Repository {
 	return &Repository{
-		id:     id,
-		meta:   meta,
-		logger: logger,
+		id:          id,
+		meta:        meta,
+		logger:      logger,
+		collections: &collectionStore{items: make(map[string]*Collection)},
 	}
 }
 
 // NewFile creates a Repository persisted to a lock file.
 // If lockPath exists, its content is loaded into the repository.
-func NewFile(id, path, lockPath string, meta Metadata, logger *zap.Logger) (*Repository, error) {
+// opts may be nil, in which case default options are used.
+func NewFile(id, path, lockPath string, meta Metadata, opts *Options, logger *zap.Logger) (*Repository, error) {
 	var err error
 
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.Codec == nil {
+		opts.Codec = JSONCodec{}
+	}
+	if jc, ok := opts.Codec.(JSONCodec); ok {
+		if _, err := jc.UUIDFormat.format(uuid.Nil); err != nil {
+			return nil, err
+		}
+	}
+
+	switch opts.PathCollision {
+	case "", CollisionKeepFirst, CollisionError:
+	default:
+		return nil, fmt.Errorf("unknown path collision policy %q", opts.PathCollision)
+	}
+	switch opts.Durability {
+	case "", DurabilityNone, DurabilityFsync, DurabilityFsyncDir:
+	default:
+		return nil, fmt.Errorf("unknown durability level %q", opts.Durability)
+	}
+	switch opts.SVG {
+	case "", SVGInline, SVGSanitize, SVGAttachment:
+	default:
+		return nil, fmt.Errorf("unknown SVG policy %q", opts.SVG)
+	}
+	switch opts.DefaultOrder {
+	case "", OrderCreatedAsc, OrderCreatedDesc, OrderPopular:
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", opts.DefaultOrder)
+	}
+	switch opts.MetaParsing {
+	case "", MetaParsingStrict, MetaParsingLenient:
+	default:
+		return nil, fmt.Errorf("unknown metadata parsing policy %q", opts.MetaParsing)
+	}
+	switch opts.Symlinks {
+	case "", SymlinkFollow, SymlinkReject:
+	default:
+		return nil, fmt.Errorf("unknown symlink policy %q", opts.Symlinks)
+	}
+	if opts.Prefetch > MaxPrefetch {
+		return nil, fmt.Errorf("prefetch amount %d exceeds the maximum of %d", opts.Prefetch, MaxPrefetch)
+	}
+	if opts.Prefetch > 0 && opts.CacheSize <= 0 {
+		return nil, errors.New("prefetching requires a media cache")
+	}
+
 	if !filepath.IsAbs(path) {
 		path, err = filepath.Abs(path)
 		if err != nil {
@@ -69,8 +359,18 @@ func NewFile(id, path, lockPath string, meta Metadata, logger *zap.Logger) (*Rep
 	if err = os.MkdirAll(path, 0); err != nil {
 		return nil, errors.Wrap(err, "failed to make repository directories")
 	}
+	if lockPath != "" {
+		// the index may be kept in a dedicated subdirectory
+		if err = os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+			return nil, errors.Wrap(err, "failed to make index directory")
+		}
+	}
 
-	var items map[uuid.UUID]*media.Media
+	var (
+		items    map[uuid.UUID]*media.Media
+		degraded bool
+		summary  LoadSummary
+	)
 	if _, err := os.Stat(lockPath); err == nil {
 		f, err := os.Open(lockPath)
 		if err != nil {
@@ -83,16 +383,37 @@ func NewFile(id, path, lockPath string, meta Metadata, logger *zap.Logger) (*Rep
 		}()
 
 		items = make(map[uuid.UUID]*media.Media)
+		paths := make(map[string]uuid.UUID)
 
+		var (
+			codec Codec = JSONCodec{} // index files without a header are JSON
+			first       = true
+		)
 		s := bufio.NewScanner(f)
 		for s.Scan() {
 			if s.Text() == "" {
 				continue // skip empty lines
 			}
+			if first {
+				first = false
+				if name, ok := strings.CutPrefix(s.Text(), codecHeader); ok {
+					if codec, err = codecByName(name); err != nil {
+						return nil, err
+					}
+					continue
+				}
+			}
 
 			var m media.Media
-			if err := json.Unmarshal(s.Bytes(), &m); err != nil {
-				return nil, errors.Wrap(err, "failed to read index file item")
+			if err := codec.Unmarshal(s.Bytes(), &m); err != nil {
+				if !opts.AllowDegraded {
+					return nil, errors.Wrap(err, "failed to read index file item")
+				}
+
+				logger.Error("unreadable item in index", zap.String("repo", id), zap.Error(err))
+				degraded = true
+				summary.Unreadable++
+				continue
 			}
 
 			if _, ok := items[m.ID]; ok {
@@ -101,44 +422,248 @@ func NewFile(id, path, lockPath string, meta Metadata, logger *zap.Logger) (*Rep
 					zap.String("repo", id),
 					zap.String("id", m.ID.String()),
 				)
+				summary.Duplicates++
 				continue
 			}
 
-			absPath := m.Path
+			absPath := filepath.Clean(m.Path)
 			if !filepath.IsAbs(absPath) {
 				absPath = filepath.Join(path, m.Path)
 			}
 
-			if _, err := os.Stat(absPath); errors.Is(err, os.ErrNotExist) {
+			if otherId, ok := paths[absPath]; ok && !opts.Shared.contains(absPath) {
+				if opts.PathCollision == CollisionError {
+					return nil, fmt.Errorf("items %s and %s share the path %s in index", otherId, m.ID, absPath)
+				}
+
+				logger.Warn(
+					"path collision in index",
+					zap.String("repo", id),
+					zap.String("id", m.ID.String()),
+					zap.String("other_id", otherId.String()),
+					zap.String("path", absPath),
+				)
+				summary.Collisions++
+				continue
+			}
+			paths[absPath] = m.ID
+
+			if opts.Symlinks == SymlinkReject {
+				link, err := isSymlink(absPath)
+				if err != nil {
+					return nil, err
+				}
+				if link {
+					logger.Warn(
+						"skipping symbolically linked item in index",
+						zap.String("repo", id),
+						zap.String("id", m.ID.String()),
+						zap.String("path", absPath),
+					)
+					summary.Symlinks++
+					continue
+				}
+			}
+
+			fi, err := os.Stat(absPath)
+			if errors.Is(err, os.ErrNotExist) && opts.Upstream == nil { // content is fetched on demand otherwise
 				logger.Warn(
 					"missing item in index",
 					zap.String("repo", id),
 					zap.String("id", m.ID.String()),
 				)
+				summary.Missing++
 				continue
 			}
+			if m.Size == 0 && err == nil { // legacy item without a size
+				m.Size = fi.Size()
+			}
 
-			items[m.ID] = &media.Media{
-				ID:     m.ID,
-				Format: m.Format,
-				Path:   absPath,
-				Meta:   m.Meta,
+			m.Path = absPath
+			if m.Original != "" && !filepath.IsAbs(m.Original) {
+				m.Original = filepath.Join(path, m.Original)
 			}
+			items[m.ID] = &m
 		}
 
 		if err := s.Err(); err != nil {
-			return nil, errors.Wrap(err, "failed to read index file")
+			if !opts.AllowDegraded {
+				return nil, errors.Wrap(err, "failed to read index file")
+			}
+
+			logger.Error("failed to read index file", zap.String("repo", id), zap.Error(err))
+			degraded = true
 		}
 	}
 
-	return &Repository{
-		id:       id,
-		path:     path,
-		lockPath: lockPath,
-		meta:     meta,
-		logger:   logger,
-		items:    items,
-	}, err
+	if n := summary.Warnings(); n > 0 {
+		logger.Warn(
+			"skipped items while loading index",
+			zap.String("repo", id),
+			zap.Int("skipped", n),
+			zap.Int("unreadable", summary.Unreadable),
+			zap.Int("duplicates", summary.Duplicates),
+			zap.Int("collisions", summary.Collisions),
+			zap.Int("symlinks", summary.Symlinks),
+			zap.Int("missing", summary.Missing),
+		)
+	}
+	if degraded {
+		// the next save drops unreadable items, keep the index file around for recovery
+		backupPath := lockPath + ".degraded"
+		if err := os.Remove(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrap(err, "failed to remove degraded index file backup")
+		}
+		if err := os.Link(lockPath, backupPath); err != nil {
+			return nil, errors.Wrap(err, "failed to back up degraded index file")
+		}
+
+		logger.Warn(
+			"REPOSITORY LOADED IN DEGRADED MODE, some items could not be read",
+			zap.String("repo", id),
+			zap.Int("loaded", len(items)),
+			zap.String("backup", backupPath),
+		)
+	}
+
+	mi, err := newMetaIndex(opts.IndexedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionsPath := ""
+	if lockPath != "" && !opts.EphemeralIndex {
+		collectionsPath = lockPath + ".collections"
+	}
+	cs, err := loadCollections(collectionsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctrs *counters
+	if opts.CountDownloads {
+		countersPath := ""
+		if lockPath != "" && !opts.EphemeralIndex {
+			countersPath = lockPath + ".counts"
+		}
+		if ctrs, err = loadCounters(countersPath); err != nil {
+			return nil, err
+		}
+	}
+
+	var ats *accessTimes
+	if opts.TrackAccess {
+		accessPath := ""
+		if lockPath != "" && !opts.EphemeralIndex {
+			accessPath = lockPath + ".access"
+		}
+		if ats, err = loadAccessTimes(accessPath); err != nil {
+			return nil, err
+		}
+	}
+
+	var c *cache
+	if opts.CacheSize > 0 {
+		c = newCache(opts.CacheSize)
+	}
+
+	var wc *cache
+	if opts.WebP != nil {
+		if opts.WebPCacheSize <= 0 {
+			opts.WebPCacheSize = DefaultWebPCacheSize
+		}
+		wc = newCache(opts.WebPCacheSize)
+	}
+
+	var rc *cache
+	if opts.ResizeMaxSize > 0 {
+		if opts.ResizeCacheSize <= 0 {
+			opts.ResizeCacheSize = DefaultResizeCacheSize
+		}
+		rc = newCache(opts.ResizeCacheSize)
+	}
+
+	if opts.ThumbnailCacheSize <= 0 {
+		opts.ThumbnailCacheSize = DefaultThumbnailCacheSize
+	}
+	if opts.MaxDecodePixels <= 0 {
+		opts.MaxDecodePixels = DefaultMaxDecodePixels
+	}
+
+	r := &Repository{
+		id:          id,
+		path:        path,
+		lockPath:    lockPath,
+		meta:        meta,
+		opts:        *opts,
+		logger:      logger,
+		items:       items,
+		cache:       c,
+		webpCache:   wc,
+		resizeCache: rc,
+		thumbCache:  newCache(opts.ThumbnailCacheSize),
+		prefetchSem: make(chan struct{}, 1),
+		metaIndex:   mi,
+		counters:    ctrs,
+		accessTimes: ats,
+		collections: cs,
+		degraded:    degraded,
+		loadSummary: summary,
+	}
+	if opts.Shared != nil {
+		if err = opts.Shared.adopt(id, r.items); err != nil {
+			return nil, err
+		}
+	}
+	if items == nil && opts.ScanExisting {
+		if r.items, err = r.scan(); err != nil {
+			return nil, err
+		}
+
+		logger.Info("indexed existing files", zap.String("repo", id), zap.Int("items", len(r.items)))
+		if err = r.save(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range r.items {
+		r.index(m)
+	}
+
+	r.warnSoftLimits()
+
+	if opts.ReportOrphans {
+		if err = r.reportOrphans(); err != nil {
+			return nil, err
+		}
+	}
+	if opts.EphemeralIndex {
+		logger.Info("index changes of repository won't be persisted", zap.String("repo", id))
+	}
+	if ctrs != nil {
+		interval := opts.CounterSaveInterval
+		if interval <= 0 {
+			interval = DefaultCounterSaveInterval
+		}
+		ctrs.start(interval, id, logger)
+	}
+	if ats != nil {
+		interval := opts.AccessSaveInterval
+		if interval <= 0 {
+			interval = DefaultAccessSaveInterval
+		}
+		ats.start(interval, id, logger)
+	}
+	if opts.SweepInterval >= 0 {
+		r.sweepInterval = opts.SweepInterval
+		if r.sweepInterval == 0 {
+			r.sweepInterval = DefaultSweepInterval
+		}
+		if opts.TTL > 0 || slices.ContainsFunc(maps.Values(r.items), expiring) {
+			r.startSweeper()
+		}
+	}
+
+	return r, err
 }
 
 // ID returns the ID of the repository.
@@ -163,11 +688,56 @@ func (r *Repository) Memory() bool {
 	return r.lockPath == ""
 }
 
+// Ephemeral returns whether index changes are only kept in memory, see Options.EphemeralIndex.
+func (r *Repository) Ephemeral() bool {
+	return r.lockPath == "" || r.opts.EphemeralIndex
+}
+
+// Writable returns whether new media can be created in the repository, false for repositories without a backing
+// storage directory.
+func (r *Repository) Writable() bool {
+	return r.path != ""
+}
+
+// MetaTypes returns the metadata types allowed in new and updated media, see Options.MetaTypes.
+func (r *Repository) MetaTypes() []meta.Type {
+	if len(r.opts.MetaTypes) == 0 {
+		return meta.Types
+	}
+	return r.opts.MetaTypes
+}
+
+// Degraded returns whether the repository index could only be partially loaded,
+// or more items were skipped while loading it than allowed by Options.LoadWarningThreshold.
+func (r *Repository) Degraded() bool {
+	return r.degraded || (r.opts.LoadWarningThreshold > 0 && r.loadSummary.Warnings() > r.opts.LoadWarningThreshold)
+}
+
+// Options returns the repository options.
+func (r *Repository) Options() Options {
+	return r.opts
+}
+
 // Meta returns the repository metadata, may be nil.
 func (r *Repository) Meta() Metadata {
 	return r.meta
 }
 
+// Usage returns the current storage usage of the repository.
+func (r *Repository) Usage() Usage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return Usage{
+		Bytes:         r.used,
+		Items:         len(r.items),
+		Capacity:      r.opts.Quota,
+		SoftCapacity:  r.opts.SoftQuota,
+		SoftItemLimit: r.opts.SoftItemLimit,
+		Warning:       r.overSoftLimits(),
+	}
+}
+
 // Get tries to find media by its ID, returns nil if nothing was found.
 func (r *Repository) Get(id uuid.UUID) *media.Media {
 	r.mu.RLock()
@@ -179,6 +749,32 @@ func (r *Repository) Get(id uuid.UUID) *media.Media {
 	return r.items[id]
 }
 
+// Exists checks which media IDs are in the repository, the result is in the same order as ids.
+// The lookup is answered from memory, without accessing the storage directory.
+func (r *Repository) Exists(ids []uuid.UUID) []bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]bool, len(ids))
+	for i, id := range ids {
+		_, res[i] = r.items[id]
+	}
+	return res
+}
+
+// GetByHash tries to find media by its content hash, returns nil if nothing was found.
+// The lookup is answered from memory, without accessing the storage directory.
+func (r *Repository) GetByHash(hash string) *media.Media {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.hashes[strings.ToLower(hash)]
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.items[ids[0]]
+}
+
 // Find tries to find media by a metadata query (meta.Matchable) and a format, returns nil if nothing was found.
 // Supplying media.FormatUnknown means any format should be accepted.
 func (r *Repository) Find(query string, format media.Format, amount int) []*media.Media {
@@ -216,71 +812,304 @@ func (r *Repository) Find(query string, format media.Format, amount int) []*medi
 
 // Random picks N random media out of the repository.
 func (r *Repository) Random(n int) []*media.Media {
+	return r.sample(n, nil)
+}
+
+// ExcludeRandom picks N random media out of the repository, avoiding the excluded IDs.
+// If there isn't enough other media, excluded media is picked to fill up the result.
+func (r *Repository) ExcludeRandom(n int, exclude []uuid.UUID) []*media.Media {
+	if len(exclude) == 0 {
+		return r.sample(n, nil)
+	}
+
+	excluded := make(map[uuid.UUID]struct{}, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+
+	res := r.sample(n, func(m *media.Media) bool {
+		_, ok := excluded[m.ID]
+		return !ok
+	})
+	if missing := n - len(res); missing > 0 {
+		res = append(res, r.sample(missing, func(m *media.Media) bool {
+			_, ok := excluded[m.ID]
+			return ok
+		})...)
+	}
+
+	return res
+}
+
+// sample picks up to N random media matching keep out of the repository in random order, keep may be nil.
+// The media is selected in a single pass with reservoir sampling (Algorithm L), without copying or shuffling all items
+// and drawing random numbers only for the items replacing earlier picks.
+func (r *Repository) sample(n int, keep func(*media.Media) bool) []*media.Media {
 	if n <= 0 {
 		return nil
 	}
 
-	v := r.Items()
-	rand.Shuffle(len(v), func(i, j int) {
-		v[i], v[j] = v[j], v[i]
-	})
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		res  = make([]*media.Media, 0, min(n, len(r.items)))
+		seen int
+		next int     // position of the next item replacing a pick
+		w    float64 // running weight of Algorithm L
+	)
+	// skip moves next past a geometrically distributed amount of items
+	skip := func() {
+		w *= math.Exp(math.Log(1-rand.Float64()) / float64(n))
+
+		gap := math.Floor(math.Log(1-rand.Float64()) / math.Log(1-w))
+		if gap >= float64(len(r.items)) {
+			next = math.MaxInt // no more replacements
+			return
+		}
+		next += int(gap) + 1
+	}
+
+	for _, m := range r.items {
+		if keep != nil && !keep(m) {
+			continue
+		}
 
-	if len(v) > n {
-		v = v[:n]
+		seen++
+		switch {
+		case seen <= n:
+			res = append(res, m)
+			if seen == n {
+				w, next = 1, n
+				skip()
+			}
+		case seen == next:
+			res[rand.Intn(n)] = m
+			skip()
+		}
 	}
-	return v
+
+	// the reservoir is filled in map order, which isn't uniformly random
+	rand.Shuffle(len(res), func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	})
+	return res
+}
+
+// CreateOptions is a set of optional media creation settings.
+type CreateOptions struct {
+	// Filename is the client-provided name of the uploaded file, may be empty.
+	Filename string
+	// Uploader is the client info of the uploader, only recorded if enabled by Options.RecordUploader, may be nil.
+	Uploader *media.Uploader
+	// Collection is the ID of the collection the media is added to, it must exist, may be empty.
+	Collection string
+	// Batch is the ID of the import batch the media is uploaded in, e.g. for removing it with RemoveBatch, may be empty.
+	Batch string
+	// Expiry is the time the media expires at, overriding Options.TTL, nil to use the repository default.
+	// A zero time means the media never expires.
+	Expiry *time.Time
+	// Verify is called once the content has been fully read, before anything is stored, may be nil.
+	// Its error is returned as-is, rejecting the content.
+	Verify func() error
+
+	id uuid.UUID // ID of imported media, a new one is generated if nil
 }
 
-// Create creates and inserts new media into the repository.
+// Create creates and inserts new media into the repository, opts may be nil.
 // Returns errors.ErrUnsupported for repositories without a backing storage directory.
-func (r *Repository) Create(b []byte, m meta.Metadata) (*media.Media, error) {
+func (r *Repository) Create(ctx context.Context, b []byte, m meta.Metadata, opts *CreateOptions) (*media.Media, error) {
 	if r.path == "" {
 		return nil, errors.ErrUnsupported
 	}
 
+	m0, err := r.stage(ctx, b, m, opts, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer r.releaseID(m0.ID)
+
+	if err := r.insert(ctx, m0); err != nil {
+		return nil, multierr.Append(err, r.removeContent(m0))
+	}
+
+	r.uploadSizes.observe(m0)
+	if r.opts.Hook != nil {
+		r.opts.Hook.run(r.id, m0, r.logger)
+	}
+	return m0, nil
+}
+
+// stage validates new media and writes its content files, without inserting it into the repository, opts may be nil.
+// reserved is the size in bytes of content staged in the meantime, counted towards the quota.
+// The ID of the staged media stays reserved until the caller releases it with releaseID, no files are left behind on failure.
+func (r *Repository) stage(ctx context.Context, b []byte, m meta.Metadata, opts *CreateOptions, reserved int64) (_ *media.Media, err error) {
+	if opts == nil {
+		opts = &CreateOptions{}
+	}
+	if err := r.checkCollection(opts.Collection); err != nil {
+		return nil, err
+	}
+	if opts.Verify != nil {
+		if err := opts.Verify(); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.ensureDir(); err != nil {
+		return nil, err
+	}
+	if err := r.checkFreeSpace(); err != nil {
+		return nil, err
+	}
+
 	var (
-		err error
+		type_  = mime.Detect(b)
+		format = detectFormat(type_, b)
 
-		id    = uuid.New()
-		type_ = mime.Detect(b)
-		path  = filepath.Join(r.path, id.String()+type_.Extension())
+		original string
 	)
-	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0)
+	if err := r.validate(b, type_, format); err != nil {
+		return nil, err
+	}
+
+	m, err = r.enrich(ctx, format, b, m)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkMetaType(m); err != nil {
+		return nil, err
+	}
+	if r.opts.Normalization != nil {
+		m = r.opts.Normalization.metadata(m)
+	}
+	if r.opts.SVG == SVGSanitize && type_.Is("image/svg+xml") {
+		var err error
+		if b, err = sanitizeSVG(b); err != nil {
+			return nil, &ErrInvalidMedia{Reason: "malformed SVG image", Err: err}
+		}
+	}
+	if r.opts.AutoOrient && type_.Is("image/jpeg") {
+		var err error
+		if b, err = autoOrient(b); err != nil {
+			return nil, &ErrInvalidMedia{Reason: "malformed JPEG image", Err: err}
+		}
+	}
+	if err := r.checkQuota(reserved + int64(len(b))); err != nil {
+		return nil, err
+	}
+
+	id := opts.id
+	if id == uuid.Nil {
+		id, err = r.newID()
+	} else {
+		err = r.reserveID(id)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open file")
+		return nil, err
 	}
 	defer func() {
-		if err0 := f.Close(); err0 != nil {
-			err = multierr.Append(err, errors.Wrap(err0, "failed to close file"))
+		if err != nil {
+			r.releaseID(id)
 		}
 	}()
 
-	if _, err = f.Write(b); err != nil {
-		return nil, errors.Wrap(err, "failed to write file")
+	if r.opts.Transcoder != nil && format == media.FormatAnimatedImage && int64(len(b)) > r.opts.TranscodeThreshold {
+		v, err := r.opts.Transcoder.Transcode(ctx, b)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to transcode media")
+		}
+
+		if r.opts.KeepOriginal {
+			original, err = r.mediaPath(format, id.String()+".orig"+r.extension(type_, opts.Filename))
+			if err != nil {
+				return nil, err
+			}
+			if err := r.writeFile(original, b); err != nil {
+				return nil, multierr.Append(err, removeFiles(original))
+			}
+		}
+
+		b, type_, format = v, mime.Detect(v), media.FormatVideo
 	}
 
-	m0 := &media.Media{
-		ID:     id,
-		Format: media.FormatUnknown,
-		Path:   path,
-		Meta:   m,
+	var width, height int
+	if format == media.FormatImage || format == media.FormatAnimatedImage {
+		width, height = dimensions(b)
 	}
-	switch type_.String() {
-	case "image/jpeg", "image/png":
-		m0.Format = media.FormatImage
-	case "image/vnd.mozilla.apng", "image/gif", "image/webp":
-		m0.Format = media.FormatAnimatedImage
+
+	path, err := r.mediaPath(format, id.String()+r.extension(type_, opts.Filename))
+	if err != nil {
+		return nil, multierr.Append(err, removeFiles(original))
+	}
+	if err := r.writeFile(path, b); err != nil {
+		return nil, multierr.Append(err, removeFiles(path, original))
+	}
+
+	hash := sha256.Sum256(b)
+	if r.opts.Shared != nil {
+		sharedPath, err := r.opts.Shared.share(r.id, id, hex.EncodeToString(hash[:]), path)
+		if err != nil {
+			return nil, multierr.Append(err, removeFiles(path, original))
+		}
+		path = sharedPath
 	}
 
-	err = r.Add(m0)
-	return m0, err
+	m0 := &media.Media{
+		ID:         id,
+		Format:     format,
+		Path:       path,
+		Meta:       m,
+		CreatedAt:  time.Now().UTC(),
+		Hash:       hex.EncodeToString(hash[:]),
+		Size:       int64(len(b)),
+		Width:      width,
+		Height:     height,
+		Original:   original,
+		Uploader:   r.uploader(opts.Uploader),
+		Collection: opts.Collection,
+		Batch:      opts.Batch,
+		ExpiresAt:  opts.expiry(),
+	}
+	if r.opts.StoreMIME {
+		m0.MIME = type_.String()
+	}
+	return m0, nil
 }
 
 // Add inserts new media into the repository.
-func (r *Repository) Add(m *media.Media) error {
+// Returns ErrDuplicateID if its ID is taken or reserved for media being created.
+// If ctx is cancelled while the index is being saved, the previous index file is kept.
+func (r *Repository) Add(ctx context.Context, m *media.Media) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, ok := r.reserved[m.ID]; ok {
+		return &ErrDuplicateID{
+			ID:   m.ID.String(),
+			Repo: r.id,
+		}
+	}
+	return r.add(ctx, m)
+}
+
+// insert inserts new media staged under a reserved ID into the repository, see Repository.reserveID.
+// Unlike Add, the in-memory change is reverted if the index can't be saved, so the caller can remove the content files.
+func (r *Repository) insert(ctx context.Context, m *media.Media) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.add(ctx, m); err != nil {
+		if r.items[m.ID] == m {
+			r.drop(m)
+			r.notify()
+		}
+		return err
+	}
+	return nil
+}
+
+// add inserts new media into the repository, the caller must hold the lock.
+func (r *Repository) add(ctx context.Context, m *media.Media) error {
 	if r.items == nil {
 		r.items = make(map[uuid.UUID]*media.Media, 1)
 	} else if _, ok := r.items[m.ID]; ok {
@@ -291,16 +1120,133 @@ func (r *Repository) Add(m *media.Media) error {
 	}
 
 	r.items[m.ID] = m
-	return r.save()
+	r.index(m)
+
+	return r.save(ctx)
+}
+
+// MaxIDAttempts is the maximum amount of generated IDs tried for new media before failing with ErrDuplicateID.
+const MaxIDAttempts = 5
+
+// newID generates and reserves an ID for new media, retrying with a fresh one if it's taken already.
+// The reservation is released with releaseID once the media is inserted or discarded.
+func (r *Repository) newID() (uuid.UUID, error) {
+	gen := r.opts.NewID
+	if gen == nil {
+		gen = uuid.New
+	}
+
+	var id uuid.UUID
+	for i := 0; i < MaxIDAttempts; i++ {
+		id = gen()
+		if err := r.reserveID(id); err == nil {
+			return id, nil
+		}
+
+		r.logger.Warn("generated media ID is taken, retrying", zap.String("repo", r.id), zap.String("id", id.String()))
+	}
+
+	return uuid.Nil, &ErrDuplicateID{ID: id.String(), Repo: r.id}
+}
+
+// reserveID reserves an ID for media being staged, so its content files can't be written concurrently by another upload.
+// Returns ErrDuplicateID if the ID is taken or reserved already. Reserved IDs are rejected by Add.
+func (r *Repository) reserveID(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; ok {
+		return &ErrDuplicateID{ID: id.String(), Repo: r.id}
+	}
+	if _, ok := r.reserved[id]; ok {
+		return &ErrDuplicateID{ID: id.String(), Repo: r.id}
+	}
+
+	if r.reserved == nil {
+		r.reserved = make(map[uuid.UUID]struct{}, 1)
+	}
+	r.reserved[id] = struct{}{}
+	return nil
+}
+
+// releaseID releases an ID reserved with reserveID.
+func (r *Repository) releaseID(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.reserved, id)
 }
 
 // Remove removes media from the repository by its ID.
-func (r *Repository) Remove(id uuid.UUID) error {
+// If ctx is cancelled while the index is being saved, the previous index file is kept.
+func (r *Repository) Remove(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.items[id]
+	if ok {
+		r.drop(m)
+	}
+
+	if err := r.save(ctx); err != nil {
+		return err
+	}
+	if ok && r.opts.Shared.contains(m.Path) {
+		return r.opts.Shared.release(r.id, m.ID, m.Path)
+	}
+	if ok && r.opts.RemoveSymlinkTargets {
+		return removeSymlinkTarget(m)
+	}
+
+	return nil
+}
+
+// removeAll removes media from the repository, like Remove, and persists the index once.
+// Media removed or changed in the meantime is skipped. Returns the removed media.
+func (r *Repository) removeAll(ctx context.Context, ms []*media.Media) ([]*media.Media, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	delete(r.items, id)
-	return r.save()
+	var removed []*media.Media
+	for _, m := range ms {
+		if r.items[m.ID] != m {
+			continue // removed or changed in the meantime
+		}
+
+		r.drop(m)
+		removed = append(removed, m)
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	if err := r.save(ctx); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for _, m := range removed {
+		if r.opts.Shared.contains(m.Path) {
+			err = multierr.Append(err, r.opts.Shared.release(r.id, m.ID, m.Path))
+		} else if r.opts.RemoveSymlinkTargets {
+			err = multierr.Append(err, removeSymlinkTarget(m))
+		}
+	}
+	return removed, err
+}
+
+// drop removes media from the repository without persisting the change, the caller must hold the write lock.
+func (r *Repository) drop(m *media.Media) {
+	r.unindex(m)
+
+	delete(r.items, m.ID)
+	r.uncache(m.ID)
+	if r.counters != nil {
+		r.counters.remove(m.ID)
+	}
+	if r.accessTimes != nil {
+		r.accessTimes.remove(m.ID)
+	}
 }
 
 // Items returns all pieces of media in the repository.
@@ -314,21 +1260,149 @@ func (r *Repository) Items() []*media.Media {
 // Close cleans up after the repository.
 // The repository should not be used anymore after calling Close.
 func (r *Repository) Close() error {
-	return nil
+	if r.opts.Hook != nil {
+		r.opts.Hook.wait()
+	}
+
+	r.stopSweeper()
+	r.prefetchWg.Wait()
+
+	var err error
+	if r.counters != nil {
+		err = multierr.Append(err, r.counters.close())
+	}
+	if r.accessTimes != nil {
+		err = multierr.Append(err, r.accessTimes.close())
+	}
+
+	return err
+}
+
+// replace swaps media for its updated copy without persisting the change.
+// Returns false if the media isn't in the repository anymore or was replaced already.
+func (r *Repository) replace(old, new *media.Media) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.items[old.ID] != old {
+		return false
+	}
+
+	r.unindex(old)
+	r.items[new.ID] = new
+	r.index(new)
+	r.uncache(old.ID)
+	return true
+}
+
+// uncache removes the content of media and everything derived from it from the in-memory caches.
+func (r *Repository) uncache(id uuid.UUID) {
+	if r.cache != nil {
+		r.cache.remove(id)
+	}
+	if r.webpCache != nil {
+		r.webpCache.remove(id)
+	}
+	if r.resizeCache != nil {
+		r.resizeCache.remove(id)
+	}
+	if r.thumbCache != nil {
+		r.thumbCache.remove(id)
+	}
+}
+
+// index adds media to the secondary indexes, the caller must hold the write lock.
+// Starts the sweeper if the media has a per-item expiry.
+func (r *Repository) index(m *media.Media) {
+	if expiring(m) {
+		r.startSweeper()
+	}
+
+	r.used += m.Size
+	if r.metaIndex != nil {
+		r.metaIndex.add(m.ID, r.ResolveMeta(m))
+	}
+	if m.Hash == "" {
+		return
+	}
+
+	if r.hashes == nil {
+		r.hashes = make(map[string][]uuid.UUID, 1)
+	}
+	r.hashes[m.Hash] = append(r.hashes[m.Hash], m.ID)
+}
+
+// unindex removes media from the secondary indexes, the caller must hold the write lock.
+func (r *Repository) unindex(m *media.Media) {
+	r.used -= m.Size
+	if r.metaIndex != nil {
+		r.metaIndex.remove(m.ID, r.ResolveMeta(m))
+	}
+	if m.Hash == "" {
+		return
+	}
+
+	ids := slices.DeleteFunc(r.hashes[m.Hash], func(id uuid.UUID) bool {
+		return id == m.ID
+	})
+	if len(ids) == 0 {
+		delete(r.hashes, m.Hash)
+	} else {
+		r.hashes[m.Hash] = ids
+	}
 }
 
-func (r *Repository) save() (err error) {
-	if r.lockPath == "" {
+// save writes the index to a temporary file and atomically replaces the index file with it,
+// keeping the previous index file as a backup. The caller must hold the write lock.
+// Subscribers are notified of the change and soft usage limits are checked first.
+// Nothing is written for in-memory repositories and ephemeral indexes (Options.EphemeralIndex).
+// If ctx is cancelled, the temporary file is discarded and the index file is left untouched.
+// Storage failures are classified as typed errors.
+func (r *Repository) save(ctx context.Context) (err error) {
+	r.notify() // the change is made in memory already, even if persisting it fails
+	r.warnSoftLimits()
+
+	if r.lockPath == "" || r.opts.EphemeralIndex {
 		return nil
 	}
+	defer func() {
+		err = storageError(err)
+	}()
+
+	tmpPath := r.lockPath + ".tmp"
+	if err = r.writeIndex(ctx, tmpPath); err != nil {
+		if err0 := os.Remove(tmpPath); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to remove temporary index file"))
+		}
+		return err
+	}
 
 	if _, err := os.Stat(r.lockPath); err == nil {
-		if err = os.Rename(r.lockPath, r.lockPath+".old"); err != nil {
-			return errors.Wrap(err, "failed to move index file")
+		oldPath := r.lockPath + ".old"
+		if err := os.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "failed to remove index file backup")
+		}
+		if err := os.Link(r.lockPath, oldPath); err != nil {
+			return errors.Wrap(err, "failed to back up index file")
+		}
+
+		if r.opts.IndexBackups > 0 {
+			if err := r.backupIndex(); err != nil {
+				return err
+			}
 		}
 	}
 
-	f, err := os.OpenFile(r.lockPath, os.O_WRONLY|os.O_CREATE, 0)
+	if err = os.Rename(tmpPath, r.lockPath); err != nil {
+		return errors.Wrap(err, "failed to move index file")
+	}
+
+	return r.syncDir(filepath.Dir(r.lockPath))
+}
+
+// writeIndex writes all media in the repository to a new index file, ordered if required, see Options.CanonicalIndex.
+func (r *Repository) writeIndex(ctx context.Context, path string) (err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0)
 	if err != nil {
 		return errors.Wrap(err, "failed to open index file")
 	}
@@ -338,32 +1412,105 @@ func (r *Repository) save() (err error) {
 		}
 	}()
 
-	for _, m := range r.items {
+	if name := r.opts.Codec.Name(); name != (JSONCodec{}).Name() { // keep JSON index files header-less for compatibility
+		if _, err = f.WriteString(codecHeader + name + "\n"); err != nil {
+			return errors.Wrap(err, "failed to write index file header")
+		}
+	}
+
+	items := maps.Values(r.items)
+	if r.opts.CanonicalIndex {
+		slices.SortFunc(items, func(a, b *media.Media) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.ID[:], b.ID[:])
+		})
+	}
+
+	for _, m := range items {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
 		if err = r.write(f, m); err != nil {
 			return err
 		}
 	}
 
-	return err
+	return r.syncFile(f)
+}
+
+// detectFormat maps a MIME type to a media format.
+// WebP images are told apart by the animation flag in the header of their content, at least the first 21 bytes of b.
+func detectFormat(type_ *mime.MIME, b []byte) media.Format {
+	switch type_.String() {
+	case "image/jpeg", "image/png":
+		return media.FormatImage
+	case "image/webp":
+		if animatedWebP(b) {
+			return media.FormatAnimatedImage
+		}
+		return media.FormatImage
+	case "image/vnd.mozilla.apng", "image/gif":
+		return media.FormatAnimatedImage
+	case "video/mp4", "video/webm":
+		return media.FormatVideo
+	}
+
+	return media.FormatUnknown
+}
+
+// writeFile writes content to a new file, storage failures are classified as typed errors.
+// The file is flushed as required by the durability level, see Options.Durability.
+func (r *Repository) writeFile(path string, b []byte) (err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0)
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to open file"))
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close file"))
+		}
+		err = storageError(err)
+	}()
+
+	if _, err = f.Write(b); err != nil {
+		return errors.Wrap(err, "failed to write file")
+	}
+	if err = r.syncFile(f); err != nil {
+		return err
+	}
+
+	return r.syncDir(filepath.Dir(path))
 }
 
+// write writes media as a single newline-terminated line of an index file.
 func (r *Repository) write(f *os.File, m *media.Media) error {
 	path, err0 := filepath.Rel(r.path, m.Path)
 	if err0 != nil {
 		path = m.Path
 	}
 
-	b, err := json.Marshal(&media.Media{
-		ID:     m.ID,
-		Format: m.Format,
-		Path:   path,
-		Meta:   m.Meta,
-	})
+	m0 := *m
+	m0.Path = path
+	if m.Original != "" {
+		if m0.Original, err0 = filepath.Rel(r.path, m.Original); err0 != nil {
+			m0.Original = m.Original
+		}
+	}
+
+	b, err := r.opts.Codec.Marshal(&m0)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize index item")
 	}
 
-	if _, err = f.Write(append(b, []byte("\n")...)); err != nil {
+	// keep each item on exactly one line, regardless of the codec's own line terminators
+	b = bytes.TrimRight(b, "\r\n")
+	if bytes.ContainsAny(b, "\r\n") {
+		return fmt.Errorf("codec %s serialized index item %s to multiple lines", r.opts.Codec.Name(), m.ID)
+	}
+
+	if _, err = f.Write(append(b, '\n')); err != nil {
 		return errors.Wrap(err, "failed to write index item")
 	}
 
@@ -0,0 +1,410 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRepo creates a file-backed repository in a temporary directory, opts may be nil.
+// The repository is closed when the test finishes.
+func newTestRepo(t *testing.T, opts *Options) *Repository {
+	t.Helper()
+
+	return openTestRepo(t, t.TempDir(), opts)
+}
+
+// openTestRepo opens a file-backed repository in a directory, opts may be nil.
+// The repository is closed when the test finishes.
+func openTestRepo(t *testing.T, dir string, opts *Options) *Repository {
+	t.Helper()
+
+	r, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Close(); err != nil {
+			t.Errorf("failed to close repository: %v", err)
+		}
+	})
+
+	return r
+}
+
+// testPNG encodes a width×height PNG image.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: uint8(x), A: 0xff}) // keeps differently sized images distinct
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// mustCreate creates media without metadata, opts may be nil.
+func mustCreate(t *testing.T, r *Repository, b []byte, opts *CreateOptions) *media.Media {
+	t.Helper()
+
+	m, err := r.Create(context.Background(), b, nil, opts)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	return m
+}
+
+// testGIF encodes an animated width×height GIF image with a frame count.
+func testGIF(t *testing.T, width, height, frames int) []byte {
+	t.Helper()
+
+	g := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.Black, color.White})
+		img.SetColorIndex(i%width, 0, 1)
+
+		g.Image = append(g.Image, img)
+		g.Delay = append(g.Delay, 10)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGetByHash(t *testing.T) {
+	var (
+		r = newTestRepo(t, nil)
+		a = mustCreate(t, r, testPNG(t, 2, 2), nil)
+		b = mustCreate(t, r, testPNG(t, 3, 3), nil)
+	)
+
+	// lookups are answered from memory
+	for _, m := range []*media.Media{a, b} {
+		if err := os.Remove(m.Path); err != nil {
+			t.Fatalf("failed to remove media file: %v", err)
+		}
+	}
+
+	if m := r.GetByHash(b.Hash); m == nil || m.ID != b.ID {
+		t.Errorf("GetByHash(%s) = %v, want %s", b.Hash, m, b.ID)
+	}
+	if m := r.GetByHash(strings.ToUpper(a.Hash)); m == nil || m.ID != a.ID {
+		t.Error("hash lookup is case-sensitive")
+	}
+	if got := r.Exists([]uuid.UUID{a.ID, uuid.New(), b.ID}); !slices.Equal(got, []bool{true, false, true}) {
+		t.Errorf("Exists = %v, want [true false true]", got)
+	}
+
+	if err := r.Remove(context.Background(), a.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if m := r.GetByHash(a.Hash); m != nil {
+		t.Error("removed media is still found by its hash")
+	}
+}
+
+// writeTestIndex writes raw lines to the index file of a repository directory, see openTestRepo.
+func writeTestIndex(t *testing.T, dir string, lines ...string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, "nero.lock"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("failed to write index file: %v", err)
+	}
+}
+
+// testIndexLine serializes media to an index file line, creating its content file if it's missing.
+func testIndexLine(t *testing.T, dir string, m *media.Media) string {
+	t.Helper()
+
+	path := m.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(path, testPNG(t, 1, 1), 0644); err != nil {
+			t.Fatalf("failed to write content file: %v", err)
+		}
+	}
+
+	b, err := JSONCodec{}.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to serialize media: %v", err)
+	}
+	return string(b)
+}
+
+func TestLoadPathCollision(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		first  = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "shared.png"}
+		second = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "shared.png"}
+	)
+	writeTestIndex(t, dir, testIndexLine(t, dir, first), testIndexLine(t, dir, second))
+
+	_, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, &Options{PathCollision: CollisionError}, zap.NewNop())
+	if err == nil {
+		t.Error("loading an index with a path collision succeeded with the error policy")
+	}
+
+	r := openTestRepo(t, dir, &Options{PathCollision: CollisionKeepFirst})
+	if r.Get(first.ID) == nil || r.Get(second.ID) != nil {
+		t.Error("keep first policy didn't keep only the first item")
+	}
+	if n := r.LoadSummary().Collisions; n != 1 {
+		t.Errorf("load summary reports %d collisions, want 1", n)
+	}
+}
+
+func TestSaveCancelled(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, nil)
+		m   = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+	old, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r.mu.Lock()
+	err = r.save(ctx)
+	r.mu.Unlock()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled save error = %v, want context.Canceled", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if !bytes.Equal(b, old) {
+		t.Error("cancelled save modified the index file")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nero.lock.tmp")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("cancelled save left its temporary index file behind")
+	}
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("old index isn't usable: %v", err)
+	}
+	defer r0.Close()
+
+	if r0.Get(m.ID) == nil {
+		t.Error("old index lost its media")
+	}
+}
+
+func TestLoadDegraded(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		m   = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "a.png"}
+	)
+	writeTestIndex(t, dir, testIndexLine(t, dir, m), `{"id": "corrupt`)
+
+	if _, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop()); err == nil {
+		t.Error("partially corrupt index loaded in strict mode")
+	}
+
+	r := openTestRepo(t, dir, &Options{AllowDegraded: true})
+	if !r.Degraded() {
+		t.Error("partially loaded repository isn't degraded")
+	}
+	if r.Get(m.ID) == nil || r.Usage().Items != 1 {
+		t.Error("readable items weren't loaded")
+	}
+	if n := r.LoadSummary().Unreadable; n != 1 {
+		t.Errorf("load summary reports %d unreadable items, want 1", n)
+	}
+}
+
+func TestEphemeralIndex(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		m   = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "a.png"}
+	)
+	writeTestIndex(t, dir, testIndexLine(t, dir, m))
+	old, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+
+	r := openTestRepo(t, dir, &Options{EphemeralIndex: true})
+	if !r.Ephemeral() || r.Get(m.ID) == nil {
+		t.Fatal("ephemeral index wasn't loaded from the index file")
+	}
+
+	m0 := mustCreate(t, r, testPNG(t, 2, 2), nil)
+	if err := r.Remove(context.Background(), m.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if r.Get(m0.ID) == nil || r.Get(m.ID) != nil {
+		t.Error("changes weren't applied in memory")
+	}
+	if _, err := os.Stat(m0.Path); err != nil {
+		t.Errorf("media content wasn't stored: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+	if !bytes.Equal(b, old) {
+		t.Error("changes were written to the index file")
+	}
+}
+
+func TestCanonicalIndex(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		opts = &Options{CanonicalIndex: true}
+		r    = openTestRepo(t, dir, opts)
+	)
+	for i := 0; i < 20; i++ {
+		var (
+			b = testPNG(t, i+1, 1)
+			m = &media.Media{
+				ID:        uuid.New(),
+				Format:    media.FormatImage,
+				Path:      filepath.Join(dir, strconv.Itoa(i)+".png"),
+				CreatedAt: time.Date(2024, 1, 1+i%3, 0, 0, 0, 0, time.UTC), // shared creation times are ordered by ID
+				Size:      int64(len(b)),
+			}
+		)
+		if err := os.WriteFile(m.Path, b, 0644); err != nil {
+			t.Fatalf("failed to write media file: %v", err)
+		}
+		if err := r.Add(context.Background(), m); err != nil {
+			t.Fatalf("failed to add media: %v", err)
+		}
+	}
+
+	save := func(r *Repository) []byte {
+		t.Helper()
+
+		r.mu.Lock()
+		err := r.save(context.Background())
+		r.mu.Unlock()
+		if err != nil {
+			t.Fatalf("failed to save index: %v", err)
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, "nero.lock"))
+		if err != nil {
+			t.Fatalf("failed to read index file: %v", err)
+		}
+		return b
+	}
+
+	first := save(r)
+	if second := save(r); !bytes.Equal(first, second) {
+		t.Error("saving unchanged media twice yielded different index files")
+	}
+
+	r0, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if b := save(r0); !bytes.Equal(first, b) {
+		t.Error("saving reloaded media yielded a different index file")
+	}
+}
+
+func TestNewIDTaken(t *testing.T) {
+	var (
+		taken = uuid.New()
+		fresh = []uuid.UUID{uuid.New(), uuid.New()}
+		gen   = []uuid.UUID{taken, taken, fresh[0], taken, fresh[1]}
+	)
+	r := newTestRepo(t, &Options{
+		NewID: func() uuid.UUID {
+			id := gen[0]
+			gen = gen[1:]
+			return id
+		},
+	})
+
+	existing := mustCreate(t, r, testPNG(t, 2, 2), nil)
+	if existing.ID != taken {
+		t.Fatalf("expected ID %s, got %s", taken, existing.ID)
+	}
+	content, err := os.ReadFile(existing.Path)
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+
+	// the first generated ID collides with the existing media, the second is used instead
+	m := mustCreate(t, r, testPNG(t, 3, 3), nil)
+	if m.ID != fresh[0] {
+		t.Errorf("expected ID %s, got %s", fresh[0], m.ID)
+	}
+	m, err = r.CreateFrom(context.Background(), strings.NewReader("nero"), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if m.ID != fresh[1] {
+		t.Errorf("expected ID %s, got %s", fresh[1], m.ID)
+	}
+
+	if got, err := os.ReadFile(existing.Path); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("expected existing content to be kept, got err %v", err)
+	}
+	if n := len(r.Items()); n != 3 {
+		t.Errorf("expected 3 items, got %d", n)
+	}
+	if len(r.reserved) != 0 {
+		t.Errorf("expected no reserved IDs, got %d", len(r.reserved))
+	}
+}
+
+func TestReserveID(t *testing.T) {
+	r := newTestRepo(t, nil)
+
+	id := uuid.New()
+	if err := r.reserveID(id); err != nil {
+		t.Fatalf("failed to reserve ID: %v", err)
+	}
+
+	var edi *ErrDuplicateID
+	if err := r.reserveID(id); !errors.As(err, &edi) {
+		t.Errorf("expected ErrDuplicateID reserving twice, got %v", err)
+	}
+	if err := r.Add(context.Background(), &media.Media{ID: id}); !errors.As(err, &edi) {
+		t.Errorf("expected ErrDuplicateID adding a reserved ID, got %v", err)
+	}
+	if _, err := r.Create(context.Background(), testPNG(t, 2, 2), nil, &CreateOptions{id: id}); !errors.As(err, &edi) {
+		t.Errorf("expected ErrDuplicateID creating under a reserved ID, got %v", err)
+	}
+
+	r.releaseID(id)
+	if _, err := r.Create(context.Background(), testPNG(t, 2, 2), nil, &CreateOptions{id: id}); err != nil {
+		t.Errorf("failed to create media under a released ID: %v", err)
+	}
+}
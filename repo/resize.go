@@ -0,0 +1,214 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/image/draw"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultResizeCacheSize is the default size of the in-memory cache of resized images in bytes.
+const DefaultResizeCacheSize = 64 << 20
+
+// resizeQuality is the JPEG quality of resized JPEG images.
+const resizeQuality = 90
+
+// Fit is the way a resized image fits into the requested box.
+type Fit string
+
+const (
+	// FitContain scales the image to fit inside the box, keeping its aspect ratio. Images are never upscaled.
+	FitContain Fit = "contain"
+	// FitCover scales the image to cover the box, keeping its aspect ratio, and crops it to the box around its center.
+	FitCover Fit = "cover"
+	// FitFill stretches the image to the box, ignoring its aspect ratio.
+	FitFill Fit = "fill"
+)
+
+// ResizeOptions are the parameters of serving a resized image.
+type ResizeOptions struct {
+	// Width and Height are the dimensions of the box in pixels, one of them may be zero to keep the aspect ratio.
+	Width, Height int
+	// Fit is the way the image fits into the box, FitContain if empty.
+	Fit Fit
+}
+
+// variant returns the cache variant of the resize parameters.
+func (ro ResizeOptions) variant() string {
+	return fmt.Sprintf("%dx%d-%s", ro.Width, ro.Height, ro.Fit)
+}
+
+// CheckResize validates resize parameters against the allowed sizes of the repository (Options.ResizeMaxSize and
+// Options.ResizeSizes), filling in defaults. Returns errors.ErrUnsupported if resizing is disabled.
+func (r *Repository) CheckResize(ro *ResizeOptions) error {
+	if r.resizeCache == nil {
+		return errors.ErrUnsupported
+	}
+
+	switch ro.Fit {
+	case "":
+		ro.Fit = FitContain
+	case FitContain, FitCover, FitFill:
+	default:
+		return fmt.Errorf("unknown fit %q", ro.Fit)
+	}
+	if ro.Width < 0 || ro.Height < 0 || (ro.Width == 0 && ro.Height == 0) {
+		return errors.New("width or height required")
+	}
+
+	for _, size := range []int{ro.Width, ro.Height} {
+		if size > r.opts.ResizeMaxSize {
+			return fmt.Errorf("size exceeds the maximum of %d pixels", r.opts.ResizeMaxSize)
+		}
+		if size > 0 && len(r.opts.ResizeSizes) > 0 && !slices.Contains(r.opts.ResizeSizes, size) {
+			return fmt.Errorf("size %d is not allowed", size)
+		}
+	}
+	return nil
+}
+
+// Resizable returns whether media can be served resized.
+// Only still images without a content type override are resized.
+func (r *Repository) Resizable(m *media.Media) bool {
+	return r.resizeCache != nil && m.Format == media.FormatImage && m.ContentType == ""
+}
+
+// ResizedName returns the file name resized media content is served with,
+// JPEG images stay JPEG, other images are converted to PNG.
+func ResizedName(m *media.Media) string {
+	name := filepath.Base(m.Path)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg":
+		return name
+	}
+
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".png"
+}
+
+// OpenResized opens the content of media resized according to validated parameters (see CheckResize) for reading,
+// resized images are cached in memory per parameter combination and concurrent requests for the same one share a resize.
+// Returns an error wrapping errors.ErrUnsupported if the media can't be served resized, see Resizable,
+// or if it has more pixels than Options.MaxDecodePixels.
+func (r *Repository) OpenResized(ctx context.Context, m *media.Media, ro ResizeOptions) (*File, error) {
+	if !r.Resizable(m) {
+		return nil, errors.ErrUnsupported
+	}
+
+	variant := ro.variant()
+	if ce := r.resizeCache.get(m.ID, variant); ce != nil {
+		return &File{ReadSeeker: bytes.NewReader(ce.data), ModTime: ce.modTime}, nil
+	}
+
+	v, err, _ := r.conversions.Do("resize/"+m.ID.String()+"/"+variant, func() (any, error) {
+		return r.convertResized(ctx, m, ro)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ce := v.(*cacheEntry)
+	return &File{ReadSeeker: bytes.NewReader(ce.data), ModTime: ce.modTime}, nil
+}
+
+// convertResized resizes media content and caches it.
+func (r *Repository) convertResized(ctx context.Context, m *media.Media, ro ResizeOptions) (_ *cacheEntry, err error) {
+	f, err := r.Open(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close media"))
+		}
+	}()
+
+	if err := r.checkDecodePixels(f); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to rewind media")
+	}
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+	}
+
+	start := time.Now()
+	dst := resize(src, ro)
+
+	var buf bytes.Buffer
+	if strings.HasSuffix(ResizedName(m), ".png") {
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: resizeQuality})
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode resized image")
+	}
+
+	variant := ro.variant()
+	r.logger.Debug(
+		"resized media",
+		zap.String("repo", r.id),
+		zap.String("id", m.ID.String()),
+		zap.String("variant", variant),
+		zap.Duration("took", time.Since(start)),
+	)
+
+	ce := &cacheEntry{id: m.ID, variant: variant, data: buf.Bytes(), modTime: f.ModTime}
+	r.resizeCache.put(ce)
+	return ce, nil
+}
+
+// resize scales an image into the box of the resize parameters.
+func resize(src image.Image, ro ResizeOptions) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	boxWidth, boxHeight := ro.Width, ro.Height
+	if boxWidth == 0 { // keep the aspect ratio
+		boxWidth = max(1, width*boxHeight/height)
+	} else if boxHeight == 0 {
+		boxHeight = max(1, height*boxWidth/width)
+	}
+
+	srcRect := bounds
+	switch ro.Fit {
+	case FitContain:
+		if width > boxWidth || height > boxHeight {
+			if width*boxHeight > height*boxWidth {
+				width, height = boxWidth, max(1, height*boxWidth/width)
+			} else {
+				width, height = max(1, width*boxHeight/height), boxHeight
+			}
+		}
+	case FitCover:
+		// crop the source to the aspect ratio of the box
+		if cropWidth := height * boxWidth / boxHeight; cropWidth < width {
+			srcRect.Min.X += (width - cropWidth) / 2
+			srcRect.Max.X = srcRect.Min.X + max(1, cropWidth)
+		} else if cropHeight := width * boxHeight / boxWidth; cropHeight < height {
+			srcRect.Min.Y += (height - cropHeight) / 2
+			srcRect.Max.Y = srcRect.Min.Y + max(1, cropHeight)
+		}
+		width, height = boxWidth, boxHeight
+	case FitFill:
+		width, height = boxWidth, boxHeight
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"image"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestOpenResized(t *testing.T) {
+	var (
+		r = newTestRepo(t, &Options{ResizeMaxSize: 16})
+		m = mustCreate(t, r, testPNG(t, 8, 4), nil)
+	)
+
+	tests := []struct {
+		ro            ResizeOptions
+		width, height int
+	}{
+		{ResizeOptions{Width: 4, Height: 4}, 4, 2},
+		{ResizeOptions{Width: 16, Height: 16}, 8, 4}, // never upscaled
+		{ResizeOptions{Width: 4}, 4, 2},
+		{ResizeOptions{Width: 4, Height: 4, Fit: FitCover}, 4, 4},
+		{ResizeOptions{Width: 3, Height: 5, Fit: FitFill}, 3, 5},
+	}
+	for _, tt := range tests {
+		ro := tt.ro
+		if err := r.CheckResize(&ro); err != nil {
+			t.Fatalf("%s: invalid resize parameters: %v", tt.ro.variant(), err)
+		}
+
+		f, err := r.OpenResized(context.Background(), m, ro)
+		if err != nil {
+			t.Fatalf("%s: failed to open resized media: %v", ro.variant(), err)
+		}
+		cfg, _, err := image.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to decode resized media: %v", ro.variant(), err)
+		}
+		if cfg.Width != tt.width || cfg.Height != tt.height {
+			t.Errorf("%s: resized to %dx%d, want %dx%d", ro.variant(), cfg.Width, cfg.Height, tt.width, tt.height)
+		}
+		if r.resizeCache.get(m.ID, ro.variant()) == nil {
+			t.Errorf("%s: resized media isn't cached", ro.variant())
+		}
+	}
+}
+
+func TestOpenResizedConcurrent(t *testing.T) {
+	var (
+		r  = newTestRepo(t, &Options{ResizeMaxSize: 16})
+		m  = mustCreate(t, r, testPNG(t, 8, 4), nil)
+		ro = ResizeOptions{Width: 4, Height: 4, Fit: FitCover}
+	)
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]byte, 8)
+		errs    = make([]error, len(results))
+	)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			f, err := r.OpenResized(context.Background(), m, ro)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer f.Close()
+
+			results[i], errs[i] = io.ReadAll(f)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to open resized media: %v", err)
+		}
+		if string(results[i]) != string(results[0]) {
+			t.Error("concurrently resized media differs")
+		}
+	}
+}
+
+func TestOpenResizedDecodeLimit(t *testing.T) {
+	var (
+		r = newTestRepo(t, &Options{ResizeMaxSize: 16, MaxDecodePixels: 15})
+		m = mustCreate(t, r, testPNG(t, 4, 4), nil)
+	)
+
+	if _, err := r.OpenResized(context.Background(), m, ResizeOptions{Width: 2, Height: 2, Fit: FitContain}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("resizing an image above the pixel limit failed with %v, want errors.ErrUnsupported", err)
+	}
+}
+
+func TestCheckResize(t *testing.T) {
+	r := newTestRepo(t, &Options{ResizeMaxSize: 64, ResizeSizes: []int{16, 32}})
+
+	ro := ResizeOptions{Width: 16}
+	if err := r.CheckResize(&ro); err != nil {
+		t.Errorf("allowed size rejected: %v", err)
+	}
+	if ro.Fit != FitContain {
+		t.Errorf("default fit = %q, want %q", ro.Fit, FitContain)
+	}
+
+	for _, ro := range []ResizeOptions{
+		{},
+		{Width: 20},                   // not allowed
+		{Width: 128, Height: 16},      // above the maximum
+		{Width: -16},                  // negative
+		{Width: 16, Fit: "stretched"}, // unknown fit
+	} {
+		if err := r.CheckResize(&ro); err == nil {
+			t.Errorf("%s: invalid resize parameters accepted", ro.variant())
+		}
+	}
+
+	if err := newTestRepo(t, nil).CheckResize(&ResizeOptions{Width: 16}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("resizing with it disabled failed with %v, want errors.ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,209 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	mime "github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// scan indexes all media files in the repository directory.
+// Files named by a media ID keep it, other files are assigned new IDs.
+// Kept originals of transcoded media are linked to it, files of an unknown format and index files are skipped.
+func (r *Repository) scan() (map[uuid.UUID]*media.Media, error) {
+	paths, err := r.contentFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		items     = make(map[uuid.UUID]*media.Media)
+		originals = make(map[uuid.UUID]string)
+	)
+	for _, path := range paths {
+		name := filepath.Base(path)
+
+		id, ok := r.parseID(name)
+		if ok && originalFilename(name) {
+			originals[id] = path
+			continue
+		}
+		if _, dup := items[id]; !ok || dup {
+			id = uuid.New()
+		}
+
+		m, err := r.scanFile(id, path)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			items[m.ID] = m
+		}
+	}
+
+	for id, path := range originals {
+		if m, ok := items[id]; ok {
+			m.Original = path
+			continue
+		}
+
+		r.logger.Warn("skipping original of missing transcoded media", zap.String("repo", r.id), zap.String("path", path))
+	}
+
+	return items, nil
+}
+
+// parseID recovers a media ID from a file name, see Options.IDFromFilename.
+func (r *Repository) parseID(name string) (uuid.UUID, bool) {
+	if r.opts.IDFromFilename != nil {
+		return r.opts.IDFromFilename(name)
+	}
+	return media.ParseIDFromFilename(name)
+}
+
+// originalFilename checks whether a file name is that of a kept original, i.e. <uuid>.orig.gif.
+func originalFilename(name string) bool {
+	_, ext, _ := strings.Cut(name, ".")
+	return ext == "orig" || strings.HasPrefix(ext, "orig.")
+}
+
+// contentFiles lists the paths of regular files in the repository directory, except for hidden and index files.
+// Files in the per-format subdirectories are included if they're enabled, see Options.FormatDirs,
+// other subdirectories, e.g. one holding the index files, are never descended into.
+func (r *Repository) contentFiles() ([]string, error) {
+	lockPath, err := filepath.Abs(r.lockPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make index file path absolute")
+	}
+
+	dirs := []string{r.path}
+	if r.opts.FormatDirs {
+		for _, f := range []media.Format{media.FormatUnknown, media.FormatImage, media.FormatAnimatedImage, media.FormatVideo} {
+			dirs = append(dirs, filepath.Join(r.path, formatDir(f)))
+		}
+	}
+
+	var paths []string
+	for i, dir := range dirs {
+		des, err := os.ReadDir(dir)
+		if err != nil {
+			if i > 0 && errors.Is(err, os.ErrNotExist) {
+				continue // format subdirectories are made on demand
+			}
+			return nil, errors.Wrap(err, "failed to read repository directory")
+		}
+
+		for _, de := range des {
+			path := filepath.Join(dir, de.Name())
+			if de.Type().IsRegular() && !strings.HasPrefix(de.Name(), ".") && !strings.HasPrefix(path, lockPath) { // index files and their backups
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// scanFile indexes a media file, returns nil if it's of an unknown format.
+func (r *Repository) scanFile(id uuid.UUID, path string) (*media.Media, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	type_ := mime.Detect(b)
+	format := detectFormat(type_, b)
+	if format == media.FormatUnknown {
+		r.logger.Warn("skipping file of unknown format", zap.String("repo", r.id), zap.String("path", path))
+		return nil, nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+
+	var width, height int
+	if format == media.FormatImage || format == media.FormatAnimatedImage {
+		width, height = dimensions(b)
+	}
+
+	hash := sha256.Sum256(b)
+	m := &media.Media{
+		ID:        id,
+		Format:    format,
+		Path:      path,
+		Meta:      &meta.GenericMetadata{},
+		CreatedAt: fi.ModTime().UTC(),
+		Hash:      hex.EncodeToString(hash[:]),
+		Size:      int64(len(b)),
+		Width:     width,
+		Height:    height,
+	}
+	if r.opts.StoreMIME {
+		m.MIME = type_.String()
+	}
+
+	return m, nil
+}
+
+// Reindex rebuilds the index from the files in the repository directory and persists it, see scan.
+// Metadata of media still in the index is kept, other media gets empty metadata.
+// Media in the shared store (Options.Shared) is kept if its file exists, its reference is released otherwise.
+// Returns the amount of indexed media.
+func (r *Repository) Reindex(ctx context.Context) (int, error) {
+	if r.path == "" {
+		return 0, errors.ErrUnsupported
+	}
+
+	items, err := r.scan()
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, m := range items {
+		if old, ok := r.items[id]; ok {
+			m.Meta, m.Pinned, m.ContentType, m.Uploader, m.Collection = old.Meta, old.Pinned, old.ContentType, old.Uploader, old.Collection
+			m.ExpiresAt, m.Batch = old.ExpiresAt, old.Batch
+			if !old.CreatedAt.IsZero() {
+				m.CreatedAt = old.CreatedAt
+			}
+		}
+		r.uncache(id)
+	}
+
+	// content in the shared store isn't scanned, its media is kept as long as the shared file exists
+	var errs error
+	for id, old := range r.items {
+		if !r.opts.Shared.contains(old.Path) {
+			continue
+		}
+		if _, err := os.Stat(old.Path); err == nil {
+			items[id] = old
+		} else {
+			errs = multierr.Append(errs, r.opts.Shared.release(r.id, id, old.Path))
+		}
+	}
+
+	r.items, r.hashes, r.used = items, nil, 0
+	if r.metaIndex != nil {
+		r.metaIndex, _ = newMetaIndex(r.opts.IndexedFields) // fields were validated on creation
+	}
+	for _, m := range r.items {
+		r.index(m)
+	}
+
+	return len(items), multierr.Append(errs, r.save(ctx))
+}
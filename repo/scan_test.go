@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScanExisting(t *testing.T) {
+	dir := t.TempDir()
+	for name, b := range map[string][]byte{
+		"a.png":     testPNG(t, 2, 2),
+		"b.gif":     testGIF(t, 3, 3, 2),
+		"notes.txt": []byte("not media"),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	r := openTestRepo(t, dir, &Options{ScanExisting: true})
+	if n := r.Usage().Items; n != 2 {
+		t.Fatalf("indexed %d files, want the 2 images", n)
+	}
+	for _, m := range r.Items() {
+		if m.Hash == "" || m.Width == 0 {
+			t.Errorf("indexed media %s lacks derived data", m.Path)
+		}
+	}
+
+	// the index is persisted, a rescan would assign new IDs
+	if _, err := os.Stat(filepath.Join(dir, "nero.lock")); err != nil {
+		t.Fatalf("index file wasn't written: %v", err)
+	}
+	r0 := openTestRepo(t, dir, &Options{ScanExisting: true})
+	for _, m := range r.Items() {
+		if r0.Get(m.ID) == nil {
+			t.Errorf("media %s wasn't loaded from the written index", m.Path)
+		}
+	}
+}
+
+func TestReportOrphans(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, nil)
+		m   = mustCreate(t, r, testPNG(t, 2, 2), nil)
+	)
+	orphan := filepath.Join(dir, "leaked.png")
+	if err := os.WriteFile(orphan, testPNG(t, 3, 3), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if n := openTestRepo(t, dir, nil).LoadSummary().Orphans; n != 0 {
+		t.Errorf("orphans were counted without being enabled: %d", n)
+	}
+
+	r0 := openTestRepo(t, dir, &Options{ReportOrphans: true})
+	if n := r0.LoadSummary().Orphans; n != 1 {
+		t.Errorf("load summary reports %d orphans, want 1", n)
+	}
+	if paths, err := r0.Orphans(); err != nil || !slices.Equal(paths, []string{orphan}) {
+		t.Errorf("Orphans = %v, %v, want [%s]", paths, err, orphan)
+	}
+	if r0.Get(m.ID) == nil {
+		t.Error("referenced media is missing")
+	}
+}
+
+func TestOrphansIndexDir(t *testing.T) {
+	for _, name := range []string{".nero", "index"} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				dir  = t.TempDir()
+				lock = filepath.Join(dir, name, "nero.lock")
+				opts = &Options{ReportOrphans: true, IndexBackups: 2, CountDownloads: true}
+			)
+			r, err := NewFile("test", dir, lock, nil, opts, zap.NewNop())
+			if err != nil {
+				t.Fatalf("failed to open repository: %v", err)
+			}
+			for i := 0; i < 2; i++ { // the second save keeps a backup
+				mustCreate(t, r, testPNG(t, i+1, 1), nil)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatalf("failed to close repository: %v", err)
+			}
+
+			r0, err := NewFile("test", dir, lock, nil, opts, zap.NewNop())
+			if err != nil {
+				t.Fatalf("failed to reopen repository: %v", err)
+			}
+			defer r0.Close()
+
+			if n := r0.LoadSummary().Orphans; n != 0 {
+				t.Errorf("load summary reports %d orphans, want the index directory to be ignored", n)
+			}
+			if paths, err := r0.Orphans(); err != nil || len(paths) != 0 {
+				t.Errorf("Orphans = %v, %v, want none", paths, err)
+			}
+			if r0.Usage().Items != 2 {
+				t.Errorf("reloaded %d items, want 2", r0.Usage().Items)
+			}
+		})
+	}
+}
+
+func TestReindex(t *testing.T) {
+	var (
+		r         = newTestRepo(t, &Options{SweepInterval: -1})
+		expiresAt = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		m         = mustCreate(t, r, testPNG(t, 2, 2), &CreateOptions{Expiry: &expiresAt, Batch: "batch"})
+	)
+
+	if n, err := r.Reindex(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Reindex = %d (%v), want 1 item", n, err)
+	}
+
+	m0 := r.Get(m.ID)
+	if m0 == nil {
+		t.Fatal("reindexed media is missing")
+	}
+	if m0.ExpiresAt == nil || !m0.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("reindexed expiry = %v, want %s", m0.ExpiresAt, expiresAt)
+	}
+	if m0.Batch != "batch" {
+		t.Errorf("reindexed batch = %q, want %q", m0.Batch, "batch")
+	}
+}
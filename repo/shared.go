@@ -0,0 +1,327 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// sharedRefsName is the name of the reference log of a shared store.
+const sharedRefsName = ".refs"
+
+// sharedFile is a content file of a shared store.
+type sharedFile struct {
+	// Name is the file name, the content hash followed by the extension of the first stored copy.
+	Name string
+	// Refs are the media referencing the file, formatted as "<repository ID>/<media ID>".
+	Refs []string
+}
+
+// sharedRefOp is a line of the reference log of a shared store, adding or dropping a media reference to a stored file.
+type sharedRefOp struct {
+	Hash string `json:"hash"`
+	Name string `json:"name,omitempty"` // file name, only set when adding
+	Ref  string `json:"ref"`
+	Drop bool   `json:"drop,omitempty"`
+}
+
+// SharedStore is a content-addressed store of media files shared by repositories on the same filesystem,
+// storing identical content uploaded to any of them once (Options.Shared).
+// Files are reference counted by the media pointing to them and removed along with the last one.
+// The references are appended to a log file in the store directory, which is compacted when the store is opened.
+// The store must not be used by multiple processes.
+type SharedStore struct {
+	path  string
+	files map[string]*sharedFile // content hash to file
+	mu    sync.Mutex
+}
+
+// OpenSharedStore opens the shared store in a directory, creating it if it doesn't exist.
+func OpenSharedStore(path string) (*SharedStore, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make shared store path absolute")
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to make shared store directory")
+	}
+
+	ss := &SharedStore{path: path, files: make(map[string]*sharedFile)}
+
+	b, err := os.ReadFile(filepath.Join(path, sharedRefsName))
+	if errors.Is(err, os.ErrNotExist) {
+		return ss, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read shared store references")
+	}
+	for len(b) > 0 {
+		line, rest, ok := bytes.Cut(b, []byte("\n"))
+		if !ok {
+			break // partially appended before a crash
+		}
+		b = rest
+
+		var op sharedRefOp
+		if err := json.Unmarshal(line, &op); err != nil {
+			return nil, errors.Wrap(err, "failed to parse shared store references")
+		}
+		ss.apply(op)
+	}
+
+	return ss, ss.compact()
+}
+
+// apply applies a reference log line.
+func (ss *SharedStore) apply(op sharedRefOp) {
+	sf, ok := ss.files[op.Hash]
+	if op.Drop {
+		if ok {
+			sf.Refs = slices.DeleteFunc(sf.Refs, func(ref string) bool {
+				return ref == op.Ref
+			})
+			if len(sf.Refs) == 0 {
+				delete(ss.files, op.Hash)
+			}
+		}
+		return
+	}
+
+	if !ok {
+		sf = &sharedFile{Name: op.Name}
+		ss.files[op.Hash] = sf
+	}
+	if !slices.Contains(sf.Refs, op.Ref) {
+		sf.Refs = append(sf.Refs, op.Ref)
+	}
+}
+
+// compact replaces the reference log with one line per reference.
+func (ss *SharedStore) compact() error {
+	var buf bytes.Buffer
+	for hash, sf := range ss.files {
+		for _, ref := range sf.Refs {
+			if err := appendRefOp(&buf, sharedRefOp{Hash: hash, Name: sf.Name, Ref: ref}); err != nil {
+				return err
+			}
+		}
+	}
+
+	path := filepath.Join(ss.path, sharedRefsName)
+	if err := os.WriteFile(path+".tmp", buf.Bytes(), 0644); err != nil {
+		return storageError(errors.Wrap(err, "failed to write shared store references"))
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return storageError(errors.Wrap(err, "failed to replace shared store references"))
+	}
+	return nil
+}
+
+// Path returns the absolute path of the store directory.
+func (ss *SharedStore) Path() string {
+	return ss.path
+}
+
+// References returns the amount of media referencing a stored file by its content hash, zero if it isn't stored.
+func (ss *SharedStore) References(hash string) int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if sf, ok := ss.files[hash]; ok {
+		return len(sf.Refs)
+	}
+	return 0
+}
+
+// contains checks whether a media path points into the store, false for nil stores.
+func (ss *SharedStore) contains(path string) bool {
+	return ss != nil && filepath.Dir(path) == ss.path
+}
+
+// share moves a freshly written content file of media into the store, referencing the stored copy instead
+// if the content is stored already. Returns the path of the stored file.
+func (ss *SharedStore) share(repoId string, id uuid.UUID, hash, path string) (string, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	sf, ok := ss.files[hash]
+	if !ok {
+		sf = &sharedFile{Name: hash + strings.ToLower(filepath.Ext(path))}
+	}
+
+	storePath := filepath.Join(ss.path, sf.Name)
+	if _, err := os.Stat(storePath); err == nil {
+		if err := os.Remove(path); err != nil {
+			return "", storageError(errors.Wrap(err, "failed to remove duplicate file"))
+		}
+	} else if err := os.Rename(path, storePath); err != nil {
+		return "", storageError(errors.Wrap(err, "failed to move file into shared store"))
+	}
+
+	ss.files[hash] = sf
+	if !ss.ref(sf, repoId, id) {
+		return storePath, nil
+	}
+	if err := ss.log(sharedRefOp{Hash: hash, Name: sf.Name, Ref: repoId + "/" + id.String()}); err != nil {
+		if sf.Refs = sf.Refs[:len(sf.Refs)-1]; len(sf.Refs) == 0 {
+			delete(ss.files, hash)
+			_ = os.Remove(storePath) // unreferenced
+		}
+		return "", err
+	}
+	return storePath, nil
+}
+
+// release drops the reference of removed media to a stored file, removing the file if it was the last one.
+// Paths outside the store are ignored.
+func (ss *SharedStore) release(repoId string, id uuid.UUID, path string) error {
+	if !ss.contains(path) {
+		return nil
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	hash, _, _ := strings.Cut(filepath.Base(path), ".")
+	sf, ok := ss.files[hash]
+	if !ok {
+		return nil // unknown files are kept, other media may still point to them
+	}
+
+	op := sharedRefOp{Hash: hash, Ref: repoId + "/" + id.String(), Drop: true}
+	if !slices.Contains(sf.Refs, op.Ref) {
+		return nil
+	}
+	if err := ss.log(op); err != nil {
+		return err
+	}
+
+	ss.apply(op)
+	if _, ok := ss.files[hash]; ok {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return storageError(errors.Wrap(err, "failed to remove shared file"))
+	}
+	return nil
+}
+
+// rename moves the reference of media to a stored file to another media ID of the same repository.
+func (ss *SharedStore) rename(repoId string, from, to uuid.UUID, path string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	hash, _, _ := strings.Cut(filepath.Base(path), ".")
+	sf, ok := ss.files[hash]
+	if !ok {
+		return nil
+	}
+
+	ops := []sharedRefOp{
+		{Hash: hash, Name: sf.Name, Ref: repoId + "/" + to.String()},
+		{Hash: hash, Ref: repoId + "/" + from.String(), Drop: true},
+	}
+	if err := ss.log(ops...); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		ss.apply(op)
+	}
+	return nil
+}
+
+// adopt records the references of loaded media to stored files, in case they weren't persisted.
+// References of media missing from the repository are kept, the index might have been loaded only partially.
+func (ss *SharedStore) adopt(repoId string, items map[uuid.UUID]*media.Media) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	var ops []sharedRefOp
+	for _, m := range items {
+		if !ss.contains(m.Path) {
+			continue
+		}
+
+		name := filepath.Base(m.Path)
+		hash, _, _ := strings.Cut(name, ".")
+		op := sharedRefOp{Hash: hash, Name: name, Ref: repoId + "/" + m.ID.String()}
+		if sf, ok := ss.files[hash]; !ok || !slices.Contains(sf.Refs, op.Ref) {
+			ops = append(ops, op)
+		}
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := ss.log(ops...); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		ss.apply(op)
+	}
+	return nil
+}
+
+// ref adds a media reference to a stored file, returns false if it was present already.
+func (ss *SharedStore) ref(sf *sharedFile, repoId string, id uuid.UUID) bool {
+	ref := repoId + "/" + id.String()
+	if slices.Contains(sf.Refs, ref) {
+		return false
+	}
+
+	sf.Refs = append(sf.Refs, ref)
+	return true
+}
+
+// log appends lines to the reference log in a single write, the caller must hold the lock.
+func (ss *SharedStore) log(ops ...sharedRefOp) error {
+	var buf bytes.Buffer
+	for _, op := range ops {
+		if err := appendRefOp(&buf, op); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filepath.Join(ss.path, sharedRefsName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to open shared store references"))
+	}
+	_, err = f.Write(buf.Bytes())
+	if err0 := f.Close(); err == nil {
+		err = err0
+	}
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to write shared store references"))
+	}
+	return nil
+}
+
+// appendRefOp appends a reference log line to a buffer.
+func appendRefOp(buf *bytes.Buffer, op sharedRefOp) error {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal shared store reference")
+	}
+
+	buf.Write(append(b, '\n'))
+	return nil
+}
+
+// removeContent removes the content files of media that was never inserted or was removed,
+// releasing shared content instead of removing it.
+func (r *Repository) removeContent(m *media.Media) error {
+	if r.opts.Shared.contains(m.Path) {
+		return multierr.Append(r.opts.Shared.release(r.id, m.ID, m.Path), removeFiles(m.Original))
+	}
+	return removeFiles(m.Path, m.Original)
+}
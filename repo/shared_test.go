@@ -0,0 +1,297 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openTestSharedStore opens a shared store in a temporary directory.
+func openTestSharedStore(t *testing.T) *SharedStore {
+	t.Helper()
+
+	ss, err := OpenSharedStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open shared store: %v", err)
+	}
+	return ss
+}
+
+// storedFiles returns the names of the content files in a shared store.
+func storedFiles(t *testing.T, ss *SharedStore) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(ss.Path())
+	if err != nil {
+		t.Fatalf("failed to read shared store directory: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.Name() != sharedRefsName {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func TestSharedStore(t *testing.T) {
+	var (
+		ss = openTestSharedStore(t)
+		a  = newTestRepo(t, &Options{Shared: ss})
+		b  = newTestRepo(t, &Options{Shared: ss})
+
+		content = testPNG(t, 2, 2)
+		hash    = sha256.Sum256(content)
+	)
+
+	ma := mustCreate(t, a, content, nil)
+	mb, err := b.CreateFrom(context.Background(), bytes.NewReader(content), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if ma.Path != mb.Path || !ss.contains(ma.Path) {
+		t.Errorf("identical content is stored at %s and %s, want one shared file", ma.Path, mb.Path)
+	}
+	if names := storedFiles(t, ss); len(names) != 1 {
+		t.Errorf("expected 1 stored file, got %v", names)
+	}
+	if n := ss.References(hex.EncodeToString(hash[:])); n != 2 {
+		t.Errorf("expected 2 references, got %d", n)
+	}
+
+	if err := a.Remove(context.Background(), ma.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if _, err := os.Stat(mb.Path); err != nil {
+		t.Errorf("shared file was removed with references left: %v", err)
+	}
+
+	if err := b.Remove(context.Background(), mb.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if _, err := os.Stat(mb.Path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("shared file wasn't removed with the last reference: %v", err)
+	}
+	if n := ss.References(hex.EncodeToString(hash[:])); n != 0 {
+		t.Errorf("expected no references, got %d", n)
+	}
+}
+
+func TestSharedStoreSaveFailure(t *testing.T) {
+	var (
+		ss   = openTestSharedStore(t)
+		id   = uuid.New()
+		hash = "0123"
+	)
+
+	// writeTemp writes a fresh content file to be shared
+	writeTemp := func() string {
+		path := filepath.Join(t.TempDir(), id.String()+".png")
+		if err := os.WriteFile(path, testPNG(t, 1, 1), 0644); err != nil {
+			t.Fatalf("failed to write content file: %v", err)
+		}
+		return path
+	}
+
+	storePath, err := ss.share("test", id, hash, writeTemp())
+	if err != nil {
+		t.Fatalf("failed to share content: %v", err)
+	}
+
+	// the references can't be appended while their log is a directory
+	refsPath := filepath.Join(ss.Path(), sharedRefsName)
+	if err := os.Remove(refsPath); err != nil {
+		t.Fatalf("failed to remove references: %v", err)
+	}
+	if err := os.Mkdir(refsPath, 0755); err != nil {
+		t.Fatalf("failed to make directory: %v", err)
+	}
+	if _, err := ss.share("test", uuid.New(), hash, writeTemp()); err == nil {
+		t.Fatal("sharing content succeeded without saving the references")
+	}
+
+	// only the failed reference is dropped
+	if n := ss.References(hash); n != 1 {
+		t.Errorf("expected the existing reference to be kept, got %d references", n)
+	}
+	if _, err := os.Stat(storePath); err != nil {
+		t.Errorf("referenced shared file was removed: %v", err)
+	}
+}
+
+func TestSharedStoreReopen(t *testing.T) {
+	ss := openTestSharedStore(t)
+
+	var (
+		a, b = uuid.New(), uuid.New()
+		hash = "0123"
+	)
+	for _, id := range []uuid.UUID{a, b} {
+		path := filepath.Join(t.TempDir(), id.String()+".png")
+		if err := os.WriteFile(path, testPNG(t, 1, 1), 0644); err != nil {
+			t.Fatalf("failed to write content file: %v", err)
+		}
+		if _, err := ss.share("test", id, hash, path); err != nil {
+			t.Fatalf("failed to share content: %v", err)
+		}
+	}
+	if err := ss.release("test", a, filepath.Join(ss.Path(), hash+".png")); err != nil {
+		t.Fatalf("failed to release content: %v", err)
+	}
+
+	// simulate a crash in the middle of an append
+	f, err := os.OpenFile(filepath.Join(ss.Path(), sharedRefsName), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("failed to open references: %v", err)
+	}
+	if _, err := f.WriteString(`{"hash":"0123","ref":"te`); err != nil {
+		t.Fatalf("failed to write references: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("failed to close references: %v", err)
+	}
+
+	ss, err = OpenSharedStore(ss.Path())
+	if err != nil {
+		t.Fatalf("failed to reopen shared store: %v", err)
+	}
+	if n := ss.References(hash); n != 1 {
+		t.Errorf("expected 1 reference after reopening, got %d", n)
+	}
+
+	b0, err := os.ReadFile(filepath.Join(ss.Path(), sharedRefsName))
+	if err != nil {
+		t.Fatalf("failed to read references: %v", err)
+	}
+	if n := bytes.Count(b0, []byte("\n")); n != 1 || !bytes.HasSuffix(b0, []byte("\n")) {
+		t.Errorf("expected the reference log to be compacted to 1 line, got %q", b0)
+	}
+}
+
+func TestSharedInsertFailure(t *testing.T) {
+	var (
+		ss = openTestSharedStore(t)
+		r  = newTestRepo(t, &Options{Shared: ss})
+	)
+
+	// failSave makes the next index save fail by blocking its temporary file
+	failSave := func() {
+		if err := os.Mkdir(r.lockPath+".tmp", 0755); err != nil {
+			t.Fatalf("failed to make directory: %v", err)
+		}
+	}
+
+	create := map[string]func(b []byte) (*media.Media, error){
+		"Create": func(b []byte) (*media.Media, error) {
+			return r.Create(context.Background(), b, nil, nil)
+		},
+		"CreateFrom": func(b []byte) (*media.Media, error) {
+			return r.CreateFrom(context.Background(), bytes.NewReader(b), nil, nil)
+		},
+	}
+	for name, create := range create {
+		content := []byte(name) // not an image, so CreateFrom doesn't fall back to Create
+		if name == "Create" {
+			content = testPNG(t, 2, 2)
+		}
+
+		failSave()
+		if _, err := create(content); err == nil {
+			t.Fatalf("%s: creating media succeeded without saving the index", name)
+		}
+
+		hash := sha256.Sum256(content)
+		if n := ss.References(hex.EncodeToString(hash[:])); n != 0 {
+			t.Errorf("%s: expected the reference to be released, got %d", name, n)
+		}
+		if names := storedFiles(t, ss); len(names) != 0 {
+			t.Errorf("%s: expected no stored files, got %v", name, names)
+		}
+		if n := len(r.Items()); n != 0 {
+			t.Errorf("%s: expected no items, got %d", name, n)
+		}
+	}
+}
+
+func TestNormalizeExtensionsShared(t *testing.T) {
+	var (
+		ss = openTestSharedStore(t)
+		r  = newTestRepo(t, &Options{Shared: ss, KeepExtension: true})
+		m  = mustCreate(t, r, testJPEG(t, 1), &CreateOptions{Filename: "photo.jpeg"})
+	)
+	if filepath.Ext(m.Path) != ".jpeg" {
+		t.Fatalf("expected the .jpeg extension to be kept, got %s", m.Path)
+	}
+
+	changes, err := r.NormalizeExtensions(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to normalize extensions: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("shared files were renamed: %v", changes)
+	}
+	if _, err := os.Stat(m.Path); err != nil || r.Get(m.ID).Path != m.Path {
+		t.Errorf("shared file was moved: %v", err)
+	}
+}
+
+func TestExportShared(t *testing.T) {
+	var (
+		ss      = openTestSharedStore(t)
+		r       = newTestRepo(t, &Options{Shared: ss})
+		content = testPNG(t, 2, 2)
+		ms      = []*media.Media{mustCreate(t, r, content, nil), mustCreate(t, r, content, nil)}
+	)
+
+	var buf bytes.Buffer
+	if err := r.Export(&buf, ArchiveTar, ms); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	dst := newTestRepo(t, nil)
+	imported, skipped, err := dst.Import(context.Background(), &buf, ArchiveTar, ImportSkip)
+	if err != nil || imported != 2 || skipped != 0 {
+		t.Fatalf("Import = %d imported, %d skipped (%v), want 2 imported", imported, skipped, err)
+	}
+	for _, m := range ms {
+		if dst.Get(m.ID) == nil {
+			t.Errorf("media %s sharing its content wasn't imported", m.ID)
+		}
+	}
+}
+
+func TestReindexShared(t *testing.T) {
+	var (
+		ss   = openTestSharedStore(t)
+		r    = newTestRepo(t, &Options{Shared: ss})
+		kept = mustCreate(t, r, testPNG(t, 2, 2), nil)
+		lost = mustCreate(t, r, testPNG(t, 3, 3), nil)
+	)
+	if err := os.Remove(lost.Path); err != nil {
+		t.Fatalf("failed to remove shared file: %v", err)
+	}
+
+	if _, err := r.Reindex(context.Background()); err != nil {
+		t.Fatalf("failed to reindex: %v", err)
+	}
+	if r.Get(kept.ID) == nil {
+		t.Error("media in the shared store was dropped")
+	}
+	if r.Get(lost.ID) != nil {
+		t.Error("media without its shared file was kept")
+	}
+	if n := ss.References(kept.Hash); n != 1 {
+		t.Errorf("expected 1 reference to the kept file, got %d", n)
+	}
+	if n := ss.References(lost.Hash); n != 0 {
+		t.Errorf("expected the reference to the missing file to be released, got %d", n)
+	}
+}
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// storageError classifies a storage failure into ErrDiskFull, ErrStoragePermission or ErrStorageIO.
+// Nil errors and context errors are returned unchanged.
+func storageError(err error) error {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.Is(err, syscall.ENOSPC):
+		return &ErrDiskFull{Err: err}
+	case errors.Is(err, os.ErrPermission):
+		return &ErrStoragePermission{Err: err}
+	}
+
+	return &ErrStorageIO{Err: err}
+}
+
+// ensureDir recreates the storage directory if it was removed while the repository was open.
+// Returns ErrRepoDirMissing if it can't be recreated.
+func (r *Repository) ensureDir() error {
+	_, err := os.Stat(r.path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return nil // other failures surface on the actual write
+	}
+
+	r.logger.Warn("repository directory is missing, recreating it", zap.String("repo", r.id), zap.String("path", r.path))
+	if err := os.MkdirAll(r.path, 0755); err != nil {
+		return &ErrRepoDirMissing{Path: r.path, Err: err}
+	}
+
+	return nil
+}
+
+// formatDir returns the name of the storage subdirectory of a media format, see Options.FormatDirs.
+func formatDir(f media.Format) string {
+	switch f {
+	case media.FormatImage:
+		return "images"
+	case media.FormatAnimatedImage:
+		return "animated"
+	case media.FormatVideo:
+		return "videos"
+	}
+
+	return "other"
+}
+
+// mediaPath returns the storage path of a new media file, making its per-format subdirectory if enabled.
+func (r *Repository) mediaPath(format media.Format, name string) (string, error) {
+	if !r.opts.FormatDirs {
+		return filepath.Join(r.path, name), nil
+	}
+
+	dir := filepath.Join(r.path, formatDir(format))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", storageError(errors.Wrap(err, "failed to make format directory"))
+	}
+	return filepath.Join(dir, name), nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestStorageError(t *testing.T) {
+	var (
+		enospc = errors.Wrap(&fs.PathError{Op: "write", Path: "a.png", Err: syscall.ENOSPC}, "failed to write file")
+		eperm  = &fs.PathError{Op: "open", Path: "a.png", Err: os.ErrPermission}
+	)
+
+	var edf *ErrDiskFull
+	if err := storageError(enospc); !errors.As(err, &edf) || !errors.Is(err, syscall.ENOSPC) {
+		t.Errorf("storageError(ENOSPC) = %#v, want ErrDiskFull wrapping it", err)
+	}
+
+	var esp *ErrStoragePermission
+	if err := storageError(eperm); !errors.As(err, &esp) {
+		t.Errorf("storageError(EPERM) = %#v, want ErrStoragePermission", err)
+	}
+
+	var esi *ErrStorageIO
+	if err := storageError(errors.New("short write")); !errors.As(err, &esi) {
+		t.Errorf("storageError(other) = %#v, want ErrStorageIO", err)
+	}
+
+	if err := storageError(context.Canceled); err != context.Canceled {
+		t.Errorf("storageError(context.Canceled) = %#v, want it unchanged", err)
+	}
+	if err := storageError(nil); err != nil {
+		t.Errorf("storageError(nil) = %#v, want nil", err)
+	}
+}
+
+func TestCreateRecreatesDir(t *testing.T) {
+	var (
+		base = t.TempDir()
+		dir  = filepath.Join(base, "media")
+	)
+	r, err := NewFile("test", dir, filepath.Join(base, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer r.Close()
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("failed to remove repository directory: %v", err)
+	}
+
+	m := mustCreate(t, r, testPNG(t, 2, 2), nil)
+	if _, err := os.Stat(m.Path); err != nil {
+		t.Errorf("media wasn't stored in the recreated directory: %v", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("failed to remove repository directory: %v", err)
+	}
+	if _, err := r.CreateFrom(context.Background(), bytes.NewReader(testPNG(t, 3, 3)), nil, nil); err != nil {
+		t.Errorf("failed to stream media into the recreated directory: %v", err)
+	}
+}
+
+func TestFormatDirs(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, &Options{FormatDirs: true})
+	)
+
+	tests := []struct {
+		name string
+		b    []byte
+		dir  string
+	}{
+		{"image", testPNG(t, 2, 2), "images"},
+		{"animated", testGIF(t, 2, 2, 2), "animated"},
+		{"unknown", []byte("not media"), "other"},
+	}
+	ms := make([]*media.Media, len(tests))
+	for i, tt := range tests {
+		ms[i] = mustCreate(t, r, tt.b, nil)
+		if got := filepath.Dir(ms[i].Path); got != filepath.Join(dir, tt.dir) {
+			t.Errorf("%s media stored in %s, want the %s subdirectory", tt.name, got, tt.dir)
+		}
+	}
+
+	r0 := openTestRepo(t, dir, &Options{FormatDirs: true})
+	for i, m := range ms {
+		m0 := r0.Get(m.ID)
+		if m0 == nil || m0.Path != m.Path {
+			t.Errorf("%s media didn't reload with its path", tests[i].name)
+			continue
+		}
+		if _, err := os.Stat(m0.Path); err != nil {
+			t.Errorf("reloaded %s media file is missing: %v", tests[i].name, err)
+		}
+	}
+	if n := r0.LoadSummary().Missing; n != 0 {
+		t.Errorf("load summary reports %d missing items, want 0", n)
+	}
+}
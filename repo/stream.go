@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	mime "github.com/gabriel-vasile/mimetype"
+	"go.uber.org/multierr"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultWriteBufferSize is the default size of the copy buffer of streamed uploads in bytes.
+const DefaultWriteBufferSize = 256 << 10
+
+// sniffSize is the amount of leading content bytes used for detecting the content type.
+const sniffSize = 3072
+
+// CreateFrom creates and inserts new media into the repository, streaming its content from a reader, opts may be nil.
+// Content is streamed to disk without being held in memory, except for images needing further processing,
+// which are read back and handled like in Create.
+// Returns errors.ErrUnsupported for repositories without a backing storage directory.
+func (r *Repository) CreateFrom(ctx context.Context, rd io.Reader, m meta.Metadata, opts *CreateOptions) (_ *media.Media, err error) {
+	if r.path == "" {
+		return nil, errors.ErrUnsupported
+	}
+	if opts == nil {
+		opts = &CreateOptions{}
+	}
+	if err := r.checkCollection(opts.Collection); err != nil {
+		return nil, err
+	}
+	if err := r.ensureDir(); err != nil {
+		return nil, err
+	}
+	if err := r.checkFreeSpace(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(r.path, ".upload-*")
+	if err != nil {
+		return nil, storageError(errors.Wrap(err, "failed to create temporary file"))
+	}
+
+	tmpPath := f.Name()
+	defer func() {
+		if err0 := os.Remove(tmpPath); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to remove temporary file"))
+		}
+	}()
+
+	size, hash, head, err := r.stream(f, rd)
+	if err == nil {
+		err = r.syncFile(f)
+	}
+	if err0 := f.Close(); err0 != nil {
+		err = multierr.Append(err, errors.Wrap(err0, "failed to close temporary file"))
+	}
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	var (
+		type_  = mime.Detect(head)
+		format = detectFormat(type_, head)
+	)
+	if format == media.FormatImage || format == media.FormatAnimatedImage || type_.Is("image/svg+xml") {
+		b, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read temporary file")
+		}
+
+		return r.Create(ctx, b, m, opts)
+	}
+	if opts.Verify != nil {
+		if err := opts.Verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	if m, err = r.enrich(ctx, format, head, m); err != nil {
+		return nil, err
+	}
+	if err := r.checkMetaType(m); err != nil {
+		return nil, err
+	}
+	if r.opts.Normalization != nil {
+		m = r.opts.Normalization.metadata(m)
+	}
+	if err := r.checkQuota(size); err != nil {
+		return nil, err
+	}
+
+	id, err := r.newID()
+	if err != nil {
+		return nil, err
+	}
+	defer r.releaseID(id)
+
+	path, err := r.mediaPath(format, id.String()+r.extension(type_, opts.Filename))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, storageError(errors.Wrap(err, "failed to move temporary file"))
+	}
+	if err := r.syncDir(filepath.Dir(path)); err != nil {
+		return nil, storageError(err)
+	}
+	if r.opts.Shared != nil {
+		sharedPath, err := r.opts.Shared.share(r.id, id, hash, path)
+		if err != nil {
+			return nil, multierr.Append(err, removeFiles(path))
+		}
+		path = sharedPath
+	}
+
+	m0 := &media.Media{
+		ID:         id,
+		Format:     format,
+		Path:       path,
+		Meta:       m,
+		CreatedAt:  time.Now().UTC(),
+		Hash:       hash,
+		Size:       size,
+		Uploader:   r.uploader(opts.Uploader),
+		Collection: opts.Collection,
+		Batch:      opts.Batch,
+		ExpiresAt:  opts.expiry(),
+	}
+	if r.opts.StoreMIME {
+		m0.MIME = type_.String()
+	}
+	if err := r.insert(ctx, m0); err != nil {
+		return nil, multierr.Append(err, r.removeContent(m0))
+	}
+
+	r.uploadSizes.observe(m0)
+	if r.opts.Hook != nil {
+		r.opts.Hook.run(r.id, m0, r.logger)
+	}
+	return m0, nil
+}
+
+// stream copies content into a file, returning its size, hex-encoded SHA-256 hash and leading bytes for sniffing.
+func (r *Repository) stream(f *os.File, rd io.Reader) (int64, string, []byte, error) {
+	bufSize := r.opts.WriteBufferSize
+	if bufSize <= 0 {
+		bufSize = DefaultWriteBufferSize
+	}
+
+	var (
+		h    = sha256.New()
+		head = &headWriter{limit: sniffSize}
+	)
+	n, err := io.CopyBuffer(io.MultiWriter(f, h, head), rd, make([]byte, bufSize))
+	if err != nil {
+		return 0, "", nil, errors.Wrap(err, "failed to write temporary file")
+	}
+
+	return n, hex.EncodeToString(h.Sum(nil)), head.b, nil
+}
+
+// headWriter is a writer keeping the first bytes written to it.
+type headWriter struct {
+	b     []byte
+	limit int
+}
+
+func (hw *headWriter) Write(p []byte) (int, error) {
+	if rem := hw.limit - len(hw.b); rem > 0 {
+		hw.b = append(hw.b, p[:min(rem, len(p))]...)
+	}
+
+	return len(p), nil
+}
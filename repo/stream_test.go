@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func BenchmarkStream(b *testing.B) {
+	content := bytes.Repeat([]byte("nero"), 4<<20) // 16 MiB
+
+	for _, size := range []int{4 << 10, 32 << 10, DefaultWriteBufferSize, 1 << 20, 4 << 20} {
+		b.Run(strconv.Itoa(size>>10)+"KiB", func(b *testing.B) {
+			r := &Repository{opts: Options{WriteBufferSize: size}}
+
+			f, err := os.Create(filepath.Join(b.TempDir(), "content"))
+			if err != nil {
+				b.Fatalf("failed to create file: %v", err)
+			}
+			defer f.Close()
+
+			b.SetBytes(int64(len(content)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := f.Seek(0, io.SeekStart); err != nil {
+					b.Fatalf("failed to rewind file: %v", err)
+				}
+
+				// hides bytes.Reader.WriteTo, which would bypass the copy buffer
+				if _, _, _, err := r.stream(f, struct{ io.Reader }{bytes.NewReader(content)}); err != nil {
+					b.Fatalf("failed to stream content: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// heapPeak runs f and returns the peak growth of the heap while it ran, sampled every 100µs.
+func heapPeak(f func()) uint64 {
+	read := func(s []metrics.Sample) uint64 {
+		metrics.Read(s)
+		return s[0].Value.Uint64()
+	}
+
+	runtime.GC()
+	base := read([]metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}})
+
+	var (
+		peak atomic.Uint64
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		var (
+			s      = []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+			ticker = time.NewTicker(100 * time.Microsecond)
+		)
+		defer ticker.Stop()
+		for {
+			if v := read(s); v > peak.Load() {
+				peak.Store(v)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	f()
+	close(done)
+	wg.Wait()
+
+	if p := peak.Load(); p > base {
+		return p - base
+	}
+	return 0
+}
+
+// BenchmarkCreateBase64 compares the peak memory of creating media from base64-encoded content,
+// decoding it whole first or streaming the decoder into CreateFrom, as JSON uploads do.
+// Content is not an image, images are read back whole by CreateFrom for processing either way.
+func BenchmarkCreateBase64(b *testing.B) {
+	encoded := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("nero"), 4<<20)) // 16 MiB decoded
+
+	benchmarks := []struct {
+		name   string
+		create func(r *Repository) (*media.Media, error)
+	}{
+		{"decode", func(r *Repository) (*media.Media, error) {
+			d, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, err
+			}
+			return r.Create(context.Background(), d, nil, nil)
+		}},
+		{"stream", func(r *Repository) (*media.Media, error) {
+			return r.CreateFrom(context.Background(), base64.NewDecoder(base64.StdEncoding, strings.NewReader(encoded)), nil, nil)
+		}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			dir := b.TempDir()
+			r, err := NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+			if err != nil {
+				b.Fatalf("failed to open repository: %v", err)
+			}
+			defer r.Close()
+
+			var peak uint64
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var (
+					m   *media.Media
+					err error
+				)
+				peak = max(peak, heapPeak(func() {
+					m, err = bm.create(r)
+				}))
+				if err != nil {
+					b.Fatalf("failed to create media: %v", err)
+				}
+
+				b.StopTimer()
+				if err := r.Remove(context.Background(), m.ID); err != nil {
+					b.Fatalf("failed to remove media: %v", err)
+				}
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(peak), "peak-B/op")
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package repo
+
+// Subscribe registers a function called after every change of the media or collections in the repository,
+// e.g. to invalidate derived state. Changes of download counters and last access times are not reported.
+// f is called synchronously, possibly with the repository locked, so it must be quick and must not use the repository.
+func (r *Repository) Subscribe(f func()) {
+	r.subscribersMu.Lock()
+	defer r.subscribersMu.Unlock()
+
+	r.subscribers = append(r.subscribers, f)
+}
+
+// notify calls all functions registered with Subscribe.
+func (r *Repository) notify() {
+	r.subscribersMu.RLock()
+	defer r.subscribersMu.RUnlock()
+
+	for _, f := range r.subscribers {
+		f()
+	}
+}
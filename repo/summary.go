@@ -0,0 +1,27 @@
+package repo
+
+// LoadSummary counts the index items skipped while loading the index file.
+type LoadSummary struct {
+	// Unreadable is the amount of items that couldn't be parsed, only skipped with Options.AllowDegraded.
+	Unreadable int
+	// Duplicates is the amount of items sharing the ID of a previous item.
+	Duplicates int
+	// Collisions is the amount of items sharing the path of a previous item.
+	Collisions int
+	// Symlinks is the amount of symbolically linked items skipped with SymlinkReject.
+	Symlinks int
+	// Missing is the amount of items without a file.
+	Missing int
+	// Orphans is the amount of files not referenced by any item, only counted with Options.ReportOrphans.
+	Orphans int
+}
+
+// Warnings returns the total amount of skipped items, orphaned files aren't items and aren't included.
+func (ls LoadSummary) Warnings() int {
+	return ls.Unreadable + ls.Duplicates + ls.Collisions + ls.Symlinks + ls.Missing
+}
+
+// LoadSummary returns the summary of loading the index file, empty if there was none.
+func (r *Repository) LoadSummary() LoadSummary {
+	return r.loadSummary
+}
@@ -0,0 +1,264 @@
+package repo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/cephxdev/nero/internal/errors"
+	"io"
+	"strings"
+)
+
+// SVGPolicy is a policy for handling uploaded SVG images, which may carry scripts.
+type SVGPolicy string
+
+const (
+	// SVGInline stores and serves SVG images as they are, the default.
+	SVGInline SVGPolicy = "inline"
+	// SVGSanitize reduces SVG images to an allowlist of elements and attributes on upload,
+	// removing scripts, event handlers and external references.
+	SVGSanitize SVGPolicy = "sanitize"
+	// SVGAttachment serves SVG images as attachments with a restrictive content security policy.
+	SVGAttachment SVGPolicy = "attachment"
+)
+
+const (
+	svgNamespace   = "http://www.w3.org/2000/svg"
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// svgElements is the allowlist of SVG elements kept by sanitizeSVG.
+var svgElements = setOf(
+	"svg", "g", "defs", "desc", "title", "symbol", "use", "image", "switch", "a", "view",
+	"path", "rect", "circle", "ellipse", "line", "polyline", "polygon",
+	"text", "tspan", "textPath",
+	"linearGradient", "radialGradient", "stop", "pattern", "clipPath", "mask", "marker", "style",
+	"filter", "feBlend", "feColorMatrix", "feComponentTransfer", "feComposite", "feConvolveMatrix",
+	"feDiffuseLighting", "feDisplacementMap", "feDistantLight", "feDropShadow", "feFlood",
+	"feFuncA", "feFuncB", "feFuncG", "feFuncR", "feGaussianBlur", "feImage", "feMerge", "feMergeNode",
+	"feMorphology", "feOffset", "fePointLight", "feSpecularLighting", "feSpotLight", "feTile", "feTurbulence",
+	"animate", "animateMotion", "animateTransform", "set", "mpath",
+)
+
+// svgAttrs is the allowlist of unprefixed SVG attributes kept by sanitizeSVG, see safeSVGAttr for the others.
+var svgAttrs = setOf(
+	// core and structure
+	"id", "class", "style", "lang", "version", "baseProfile", "viewBox", "preserveAspectRatio", "transform",
+	"systemLanguage",
+	// geometry
+	"x", "y", "x1", "y1", "x2", "y2", "cx", "cy", "r", "rx", "ry", "fx", "fy", "fr", "width", "height",
+	"d", "points", "pathLength",
+	// presentation
+	"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-linecap", "stroke-linejoin",
+	"stroke-miterlimit", "stroke-dasharray", "stroke-dashoffset", "stroke-opacity", "opacity", "color",
+	"display", "visibility", "overflow", "clip-path", "clip-rule", "mask", "filter",
+	"marker-start", "marker-mid", "marker-end", "stop-color", "stop-opacity", "flood-color", "flood-opacity",
+	"lighting-color", "color-interpolation", "color-interpolation-filters", "shape-rendering",
+	"image-rendering", "text-rendering", "vector-effect", "paint-order",
+	// text
+	"font-family", "font-size", "font-style", "font-weight", "font-variant", "text-anchor",
+	"dominant-baseline", "alignment-baseline", "baseline-shift", "letter-spacing", "word-spacing",
+	"text-decoration", "writing-mode", "dx", "dy", "rotate", "textLength", "lengthAdjust",
+	"startOffset", "method", "spacing", "side",
+	// paint servers, clipping, masking and markers
+	"gradientUnits", "gradientTransform", "spreadMethod", "offset", "patternUnits", "patternContentUnits",
+	"patternTransform", "clipPathUnits", "maskUnits", "maskContentUnits", "markerWidth", "markerHeight",
+	"markerUnits", "refX", "refY", "orient",
+	// filters
+	"filterUnits", "primitiveUnits", "in", "in2", "result", "stdDeviation", "mode", "type", "values",
+	"operator", "k1", "k2", "k3", "k4", "scale", "xChannelSelector", "yChannelSelector", "radius",
+	"surfaceScale", "diffuseConstant", "specularConstant", "specularExponent", "kernelMatrix",
+	"kernelUnitLength", "order", "divisor", "bias", "targetX", "targetY", "edgeMode", "preserveAlpha",
+	"azimuth", "elevation", "pointsAtX", "pointsAtY", "pointsAtZ", "limitingConeAngle", "baseFrequency",
+	"numOctaves", "seed", "stitchTiles", "tableValues", "slope", "intercept", "amplitude", "exponent",
+	// animation
+	"attributeName", "attributeType", "begin", "dur", "end", "repeatCount", "repeatDur", "restart", "from",
+	"to", "by", "calcMode", "keyTimes", "keySplines", "keyPoints", "additive", "accumulate", "path",
+	"min", "max",
+)
+
+// sanitizeSVG reduces an SVG image to allowlisted elements and attributes, see svgElements and svgAttrs.
+// Comments, directives, references to anything but fragments and embedded raster images,
+// animations of references and style sheets with external references are removed.
+func sanitizeSVG(b []byte) ([]byte, error) {
+	var (
+		buf   bytes.Buffer
+		d     = xml.NewDecoder(bytes.NewReader(b))
+		depth int // depth inside a removed element, zero if outside
+
+		style    *xml.StartElement // style element being collected, nil if outside
+		styleBuf bytes.Buffer
+	)
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse SVG")
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			if depth > 0 || style != nil || !safeSVGElement(t) {
+				depth++
+				continue
+			}
+			if t.Name.Local == "style" {
+				style = &t
+				styleBuf.Reset()
+				continue
+			}
+
+			writeSVGStart(&buf, t)
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if style != nil {
+				if safeCSS(styleBuf.String()) {
+					writeSVGStart(&buf, *style)
+					_ = xml.EscapeText(&buf, styleBuf.Bytes())
+					buf.WriteString("</" + qualifiedName(style.Name) + ">")
+				}
+
+				style = nil
+				continue
+			}
+
+			buf.WriteString("</" + qualifiedName(t.Name) + ">")
+		case xml.CharData:
+			switch {
+			case depth > 0:
+			case style != nil:
+				styleBuf.Write(t)
+			default:
+				_ = xml.EscapeText(&buf, t)
+			}
+		case xml.ProcInst:
+			if depth == 0 && style == nil && t.Target == "xml" {
+				buf.WriteString("<?xml " + string(t.Inst) + "?>")
+			}
+		}
+		// comments and directives (i.e. DOCTYPE with entities) are dropped
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeSVGStart writes a start element with its allowed attributes.
+func writeSVGStart(buf *bytes.Buffer, t xml.StartElement) {
+	buf.WriteString("<" + qualifiedName(t.Name))
+	for _, a := range t.Attr {
+		if !safeSVGAttr(a) {
+			continue
+		}
+
+		buf.WriteString(" " + qualifiedName(a.Name) + "=\"")
+		_ = xml.EscapeText(buf, []byte(a.Value))
+		buf.WriteString("\"")
+	}
+	buf.WriteString(">")
+}
+
+// safeSVGElement checks whether an SVG element is allowlisted, unprefixed or in the svg prefix.
+// Animations of references are rejected, they could turn a safe reference into a script URL.
+func safeSVGElement(t xml.StartElement) bool {
+	if (t.Name.Space != "" && t.Name.Space != "svg") || !svgElements[t.Name.Local] {
+		return false
+	}
+
+	for _, a := range t.Attr {
+		if a.Name.Space == "" && a.Name.Local == "attributeName" {
+			target := strings.ToLower(strings.TrimSpace(a.Value))
+			if _, local, _ := strings.Cut(target, ":"); target == "href" || local == "href" || strings.HasPrefix(target, "on") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// safeSVGAttr checks whether an SVG attribute is allowlisted and doesn't reference external content.
+func safeSVGAttr(a xml.Attr) bool {
+	switch {
+	case a.Name.Space == "" && a.Name.Local == "xmlns":
+		return a.Value == svgNamespace
+	case a.Name.Space == "xmlns":
+		return (a.Name.Local == "svg" && a.Value == svgNamespace) || (a.Name.Local == "xlink" && a.Value == xlinkNamespace)
+	case a.Name.Space == "xml":
+		return a.Name.Local == "space" || a.Name.Local == "lang"
+	case a.Name.Space == "xlink" && a.Name.Local == "href", a.Name.Space == "" && a.Name.Local == "href":
+		return safeSVGRef(a.Value)
+	case a.Name.Space != "" || !svgAttrs[a.Name.Local]:
+		return false
+	case a.Name.Local == "style":
+		return safeCSS(a.Value)
+	}
+
+	return safeCSSURLs(a.Value) // presentation attributes and animation values may contain url(...)
+}
+
+// safeSVGRef checks whether a reference points to a fragment of the same document or an embedded raster image.
+func safeSVGRef(ref string) bool {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if strings.HasPrefix(ref, "#") {
+		return true
+	}
+
+	for _, type_ := range []string{"png", "jpeg", "gif", "webp"} {
+		if strings.HasPrefix(ref, "data:image/"+type_+";base64,") {
+			return true
+		}
+	}
+	return false
+}
+
+// safeCSS checks whether a style sheet or declaration is free of imports, escapes, scripts and external references.
+// Escapes are rejected entirely, they could hide any of the others.
+func safeCSS(css string) bool {
+	lower := strings.ToLower(css)
+	for _, s := range []string{"\\", "@import", "expression", "javascript:", "behavior", "-moz-binding"} {
+		if strings.Contains(lower, s) {
+			return false
+		}
+	}
+
+	return safeCSSURLs(lower)
+}
+
+// safeCSSURLs checks whether all url(...) functions in a value reference fragments of the same document.
+func safeCSSURLs(v string) bool {
+	v = strings.ToLower(v)
+	for {
+		i := strings.Index(v, "url(")
+		if i < 0 {
+			return true
+		}
+
+		v = strings.TrimLeft(v[i+len("url("):], " \t\r\n\"'")
+		if !strings.HasPrefix(v, "#") {
+			return false
+		}
+	}
+}
+
+// qualifiedName formats a raw XML name with its namespace prefix.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
+
+// setOf creates a set of strings.
+func setOf(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+
+	return set
+}
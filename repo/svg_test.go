@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeSVG(t *testing.T) {
+	const malicious = `<?xml version="1.0"?>
+<!DOCTYPE svg [<!ENTITY x "y">]>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:evil="urn:evil" onload="alert(1)" viewBox="0 0 10 10">
+<script>alert(2)</script>
+<style>@import url(https://evil.example/a.css);</style>
+<style>.safe { fill: url(#grad) }</style>
+<defs><linearGradient id="grad"><stop offset="0" stop-color="red"/></linearGradient></defs>
+<rect id="safe" width="10" height="10" fill="url(#grad)" style="stroke: red" onclick="alert(3)"/>
+<rect fill="url(https://evil.example/track)" style="background: url('https://evil.example/track')"/>
+<a xlink:href="javascript:alert(4)"><text>link</text></a>
+<use href="https://evil.example/sprite.svg#icon"/>
+<use xlink:href="#safe"/>
+<a href="#safe"><animate attributeName="href" to="javascript:alert(5)"/><set attributeName="xlink:href" to="javascript:alert(6)"/></a>
+<animate attributeName="opacity" from="0" to="1" dur="1s"/>
+<foreignObject><div>html</div></foreignObject>
+<evil:thing/>
+<metadata>unknown</metadata>
+</svg>`
+
+	b, err := sanitizeSVG([]byte(malicious))
+	if err != nil {
+		t.Fatalf("failed to sanitize: %v", err)
+	}
+	out := string(b)
+
+	for _, s := range []string{
+		"alert", "evil", "DOCTYPE", "ENTITY", "@import", "foreignObject", "html", "metadata", "unknown", "<set",
+		`attributeName="href"`,
+	} {
+		if strings.Contains(out, s) {
+			t.Errorf("sanitized SVG contains %q:\n%s", s, out)
+		}
+	}
+	for _, s := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 10 10">`,
+		`<style>.safe { fill: url(#grad) }</style>`,
+		`<stop offset="0" stop-color="red"></stop>`,
+		`<rect id="safe" width="10" height="10" fill="url(#grad)" style="stroke: red">`,
+		`<use xlink:href="#safe">`,
+		`<a href="#safe"></a>`,
+		`<animate attributeName="opacity" from="0" to="1" dur="1s">`,
+		`<text>link</text>`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("sanitized SVG lacks %q:\n%s", s, out)
+		}
+	}
+}
+
+func TestCreateSanitizedSVG(t *testing.T) {
+	r := newTestRepo(t, &Options{SVG: SVGSanitize})
+
+	m := mustCreate(t, r, []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><rect width="1" height="1"/></svg>`), nil)
+	b, err := os.ReadFile(m.Path)
+	if err != nil {
+		t.Fatalf("failed to read media file: %v", err)
+	}
+	if strings.Contains(string(b), "script") || !strings.Contains(string(b), "<rect") {
+		t.Errorf("stored SVG wasn't sanitized: %s", b)
+	}
+}
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/multierr"
+	"os"
+	"path/filepath"
+)
+
+// SymlinkPolicy is a policy for handling media paths that are symbolic links.
+type SymlinkPolicy string
+
+const (
+	// SymlinkFollow reads media content through symbolic links, the default.
+	SymlinkFollow SymlinkPolicy = "follow"
+	// SymlinkReject skips symbolic links when loading the index and refuses to open them.
+	SymlinkReject SymlinkPolicy = "reject"
+)
+
+// isSymlink returns whether a path is a symbolic link, false if it doesn't exist.
+func isSymlink(path string) (bool, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to stat media")
+	}
+
+	return fi.Mode()&os.ModeSymlink != 0, nil
+}
+
+// checkSymlink returns an error if the media path is a symbolic link and the repository rejects them.
+func (r *Repository) checkSymlink(path string) error {
+	if r.opts.Symlinks != SymlinkReject {
+		return nil
+	}
+
+	link, err := isSymlink(path)
+	if err != nil {
+		return err
+	}
+	if link {
+		return fmt.Errorf("media path %s is a symbolic link", path)
+	}
+
+	return nil
+}
+
+// removeSymlinkTarget removes a symbolically linked media file along with the link itself.
+// Media paths that aren't symbolic links are left alone.
+func removeSymlinkTarget(m *media.Media) error {
+	link, err := isSymlink(m.Path)
+	if err != nil || !link {
+		return err
+	}
+
+	target, err := filepath.EvalSymlinks(m.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) { // dangling links are removed regardless
+		return errors.Wrap(err, "failed to resolve symbolic link")
+	}
+	if target != "" {
+		if err0 := os.Remove(target); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = errors.Wrap(err0, "failed to remove symbolic link target")
+		}
+	}
+	if err0 := os.Remove(m.Path); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+		err = multierr.Append(err, errors.Wrap(err0, "failed to remove symbolic link"))
+	}
+
+	return err
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// linkTestMedia creates media stored as a symbolic link to a PNG image outside the repository directory.
+func linkTestMedia(t *testing.T, dir string) (m *media.Media, target string) {
+	t.Helper()
+
+	target = filepath.Join(t.TempDir(), "curated.png")
+	if err := os.WriteFile(target, testPNG(t, 2, 2), 0644); err != nil {
+		t.Fatalf("failed to write link target: %v", err)
+	}
+
+	m = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: filepath.Join(dir, "linked.png")}
+	if err := os.Symlink(target, m.Path); err != nil {
+		t.Skipf("symbolic links aren't supported: %v", err)
+	}
+	return m, target
+}
+
+func TestSymlinkFollow(t *testing.T) {
+	for _, removeTargets := range []bool{false, true} {
+		name := "keep target"
+		if removeTargets {
+			name = "remove target"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			m, target := linkTestMedia(t, dir)
+			writeTestIndex(t, dir, testIndexLine(t, dir, m))
+
+			r := openTestRepo(t, dir, &Options{Symlinks: SymlinkFollow, RemoveSymlinkTargets: removeTargets})
+			f, err := r.Open(context.Background(), r.Get(m.ID))
+			if err != nil {
+				t.Fatalf("failed to open symbolically linked media: %v", err)
+			}
+			b, err := io.ReadAll(f)
+			_ = f.Close()
+			if err != nil {
+				t.Fatalf("failed to read media: %v", err)
+			}
+			if !bytes.Equal(b, testPNG(t, 2, 2)) {
+				t.Error("read content differs from the link target")
+			}
+
+			if err := r.Remove(context.Background(), m.ID); err != nil {
+				t.Fatalf("failed to remove media: %v", err)
+			}
+			if _, err := os.Lstat(m.Path); removeTargets != errors.Is(err, os.ErrNotExist) {
+				t.Errorf("symbolic link exists after removal: %t, want %t", err == nil, !removeTargets)
+			}
+			if _, err := os.Stat(target); removeTargets != errors.Is(err, os.ErrNotExist) {
+				t.Errorf("link target exists after removal: %t, want %t", err == nil, !removeTargets)
+			}
+		})
+	}
+}
+
+func TestSymlinkReject(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		m, target = linkTestMedia(t, dir)
+		regular   = &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: "regular.png"}
+	)
+	writeTestIndex(t, dir, testIndexLine(t, dir, m), testIndexLine(t, dir, regular))
+
+	r := openTestRepo(t, dir, &Options{Symlinks: SymlinkReject})
+	if r.Get(m.ID) != nil || r.Get(regular.ID) == nil {
+		t.Error("loading didn't skip only the symbolically linked item")
+	}
+	if n := r.LoadSummary().Symlinks; n != 1 {
+		t.Errorf("load summary reports %d symbolic links, want 1", n)
+	}
+
+	// links created after loading are refused too
+	if err := os.Remove(filepath.Join(dir, "regular.png")); err != nil {
+		t.Fatalf("failed to remove media file: %v", err)
+	}
+	if err := os.Symlink(target, filepath.Join(dir, "regular.png")); err != nil {
+		t.Fatalf("failed to create symbolic link: %v", err)
+	}
+	if _, err := r.Open(context.Background(), r.Get(regular.ID)); err == nil {
+		t.Error("opening a symbolically linked media file succeeded")
+	}
+}
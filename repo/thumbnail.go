@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/multierr"
+	"golang.org/x/image/draw"
+	"image"
+	"image/jpeg"
+	"io"
+	"strconv"
+)
+
+// thumbnailQuality is the JPEG quality of thumbnails.
+const thumbnailQuality = 85
+
+// DefaultThumbnailCacheSize is the default size of the in-memory cache of thumbnails in bytes.
+const DefaultThumbnailCacheSize = 16 << 20
+
+// FrameExtractor renders the first frame of animated images the standard library cannot decode, i.e. animated WebP.
+type FrameExtractor interface {
+	// ExtractFrame renders the first frame of an animated image as a PNG image.
+	ExtractFrame(ctx context.Context, b []byte) ([]byte, error)
+}
+
+// FFmpegFrameExtractor is a FrameExtractor invoking an external ffmpeg executable.
+type FFmpegFrameExtractor struct {
+	// Path is the path of the ffmpeg executable, looked up in PATH if it is a bare name.
+	Path string
+}
+
+// ExtractFrame renders the first frame of an animated image as a PNG image.
+func (fe *FFmpegFrameExtractor) ExtractFrame(ctx context.Context, b []byte) ([]byte, error) {
+	return runFFmpeg(ctx, fe.Path, b, "out.png", "-frames:v", "1")
+}
+
+// Thumbnail renders a static JPEG thumbnail of image media fitting into a size×size box, thumbnails are cached in memory.
+// Only the first frame of animated images is used, images smaller than the box aren't upscaled.
+// Returns errors.ErrUnsupported for media that cannot be decoded as an image, i.e. videos,
+// and animated WebP images without Options.FrameExtractor.
+func (r *Repository) Thumbnail(ctx context.Context, m *media.Media, size int) (b []byte, err error) {
+	if m.Format != media.FormatImage && m.Format != media.FormatAnimatedImage {
+		return nil, errors.ErrUnsupported
+	}
+
+	variant := strconv.Itoa(size)
+	if r.thumbCache != nil {
+		if ce := r.thumbCache.get(m.ID, variant); ce != nil {
+			return ce.data, nil
+		}
+	}
+
+	f, err := r.Open(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close media"))
+		}
+	}()
+
+	src, err := r.firstFrame(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width > height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src) // flatten transparency
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, errors.Wrap(err, "failed to encode thumbnail")
+	}
+
+	if r.thumbCache != nil {
+		r.thumbCache.put(&cacheEntry{id: m.ID, variant: variant, data: buf.Bytes(), modTime: f.ModTime})
+	}
+	return buf.Bytes(), nil
+}
+
+// firstFrame decodes the first frame of image content.
+// Animated WebP images are rendered by Options.FrameExtractor, only the first frame of GIF and APNG images is decoded.
+func (r *Repository) firstFrame(ctx context.Context, rd io.Reader) (image.Image, error) {
+	b, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read media")
+	}
+
+	if animatedWebP(b) {
+		if r.opts.FrameExtractor == nil {
+			return nil, fmt.Errorf("%w: animated WebP images cannot be decoded", errors.ErrUnsupported)
+		}
+		if b, err = r.opts.FrameExtractor.ExtractFrame(ctx, b); err != nil {
+			return nil, errors.Wrap(err, "failed to extract first frame")
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"image"
+	"image/jpeg"
+	"os"
+	"testing"
+)
+
+// testAnimatedWebP is the header of an animated WebP image, enough for detecting it, but not decodable.
+var testAnimatedWebP = []byte("RIFF\x1a\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00\x02\x00\x00\x00\x07\x00\x00\x07\x00\x00")
+
+// fakeFrameExtractor is a FrameExtractor returning a fixed frame and counting its calls.
+type fakeFrameExtractor struct {
+	frame []byte
+	calls int
+}
+
+func (fe *fakeFrameExtractor) ExtractFrame(context.Context, []byte) ([]byte, error) {
+	fe.calls++
+	return fe.frame, nil
+}
+
+// decodeThumbnail decodes a JPEG thumbnail.
+func decodeThumbnail(t *testing.T, b []byte) image.Image {
+	t.Helper()
+
+	img, err := jpeg.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("thumbnail isn't a JPEG image: %v", err)
+	}
+	return img
+}
+
+func TestThumbnailAnimatedGIF(t *testing.T) {
+	var (
+		r = newTestRepo(t, nil)
+		m = mustCreate(t, r, testGIF(t, 64, 32, 4), nil)
+	)
+	if m.Format != media.FormatAnimatedImage {
+		t.Fatalf("test image is %s, want an animated image", m.Format)
+	}
+
+	b, err := r.Thumbnail(context.Background(), m, 16)
+	if err != nil {
+		t.Fatalf("failed to render thumbnail: %v", err)
+	}
+	// a JPEG image has a single frame
+	if size := decodeThumbnail(t, b).Bounds().Size(); size != image.Pt(16, 8) {
+		t.Errorf("thumbnail size = %v, want 16x8", size)
+	}
+
+	// thumbnails are cached
+	if err := os.Remove(m.Path); err != nil {
+		t.Fatalf("failed to remove media file: %v", err)
+	}
+	b0, err := r.Thumbnail(context.Background(), m, 16)
+	if err != nil || !bytes.Equal(b0, b) {
+		t.Errorf("second thumbnail wasn't served from the cache: %v", err)
+	}
+	if _, err := r.Thumbnail(context.Background(), m, 32); err == nil {
+		t.Error("thumbnail of another size was served from the cache")
+	}
+}
+
+func TestThumbnailAnimatedWebP(t *testing.T) {
+	var (
+		fe = &fakeFrameExtractor{frame: testPNG(t, 8, 8)}
+		r  = newTestRepo(t, &Options{FrameExtractor: fe})
+		m  = mustCreate(t, r, testAnimatedWebP, nil)
+	)
+	if m.Format != media.FormatAnimatedImage {
+		t.Fatalf("test image is %s, want an animated image", m.Format)
+	}
+
+	for i := 0; i < 2; i++ {
+		b, err := r.Thumbnail(context.Background(), m, 4)
+		if err != nil {
+			t.Fatalf("failed to render thumbnail: %v", err)
+		}
+		if size := decodeThumbnail(t, b).Bounds().Size(); size != image.Pt(4, 4) {
+			t.Errorf("thumbnail size = %v, want 4x4", size)
+		}
+	}
+	if fe.calls != 1 {
+		t.Errorf("first frame was extracted %d times, want once", fe.calls)
+	}
+
+	if err := r.Remove(context.Background(), m.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if _, err := r.Thumbnail(context.Background(), m, 4); err != nil || fe.calls != 2 {
+		t.Errorf("removing media didn't drop its cached thumbnail: %v", err)
+	}
+
+	r = newTestRepo(t, nil)
+	m = mustCreate(t, r, testAnimatedWebP, nil)
+	if _, err := r.Thumbnail(context.Background(), m, 4); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("thumbnail without a frame extractor error = %v, want errors.ErrUnsupported", err)
+	}
+}
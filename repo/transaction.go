@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"os"
+	"sync"
+)
+
+// Transaction is a set of media created atomically, either all of it is inserted into the repository or none of it.
+// Content files are written when media is staged, but the media stays invisible until the transaction is committed,
+// which inserts all of it and persists the index once. A Transaction is safe for concurrent use.
+type Transaction struct {
+	r *Repository
+
+	staged []*media.Media
+	size   int64 // total size of the staged content in bytes
+	done   bool  // whether the transaction was committed or rolled back
+	mu     sync.Mutex
+}
+
+// Begin starts a transaction of creating multiple media atomically.
+// Returns errors.ErrUnsupported for repositories without a backing storage directory.
+func (r *Repository) Begin() (*Transaction, error) {
+	if r.path == "" {
+		return nil, errors.ErrUnsupported
+	}
+
+	return &Transaction{r: r}, nil
+}
+
+// Create validates new media and writes its content, like Repository.Create, staging it for the commit, opts may be nil.
+// Any failure rolls back the whole transaction, removing the content staged so far.
+func (tx *Transaction) Create(ctx context.Context, b []byte, m meta.Metadata, opts *CreateOptions) (*media.Media, error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.done {
+		return nil, errors.New("transaction is already finished")
+	}
+
+	m0, err := tx.r.stage(ctx, b, m, opts, tx.size)
+	if err != nil {
+		return nil, multierr.Append(err, tx.rollback())
+	}
+
+	tx.staged = append(tx.staged, m0)
+	tx.size += m0.Size
+	return m0, nil
+}
+
+// Staged returns the media staged in the transaction so far.
+func (tx *Transaction) Staged() []*media.Media {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	return append([]*media.Media(nil), tx.staged...)
+}
+
+// Commit inserts all staged media into the repository and persists the index once.
+// If the index can't be saved, the transaction is rolled back and the repository is left unchanged.
+func (tx *Transaction) Commit(ctx context.Context) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.done {
+		return errors.New("transaction is already finished")
+	}
+	if len(tx.staged) == 0 {
+		tx.done = true
+		return nil
+	}
+
+	r := tx.r
+	if err := tx.insert(ctx); err != nil {
+		return multierr.Append(err, tx.rollback())
+	}
+
+	tx.done = true
+	for _, m := range tx.staged {
+		r.uploadSizes.observe(m)
+		if r.opts.Hook != nil {
+			r.opts.Hook.run(r.id, m, r.logger)
+		}
+	}
+	return nil
+}
+
+// insert adds the staged media to the repository and persists the index, reverting the in-memory change on failure.
+func (tx *Transaction) insert(ctx context.Context) error {
+	r := tx.r
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.items == nil {
+		r.items = make(map[uuid.UUID]*media.Media, len(tx.staged))
+	}
+	seen := make(map[uuid.UUID]struct{}, len(tx.staged))
+	for _, m := range tx.staged {
+		_, taken := r.items[m.ID]
+		if _, ok := seen[m.ID]; ok || taken {
+			return &ErrDuplicateID{ID: m.ID.String(), Repo: r.id}
+		}
+		seen[m.ID] = struct{}{}
+	}
+
+	for _, m := range tx.staged {
+		r.items[m.ID] = m
+		r.index(m)
+	}
+	if err := r.save(ctx); err != nil {
+		for _, m := range tx.staged {
+			r.drop(m)
+		}
+		r.notify()
+		return err
+	}
+
+	for _, m := range tx.staged {
+		delete(r.reserved, m.ID)
+	}
+	return nil
+}
+
+// Rollback discards the transaction, removing the content files of the staged media.
+// Does nothing if the transaction was committed or rolled back already.
+func (tx *Transaction) Rollback() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.done {
+		return nil
+	}
+	return tx.rollback()
+}
+
+// rollback removes (or releases, see SharedStore) the content files of the staged media, releases their IDs and finishes the transaction,
+// the caller must hold the lock.
+func (tx *Transaction) rollback() error {
+	tx.done = true
+
+	var err error
+	for _, m := range tx.staged {
+		err = multierr.Append(err, tx.r.removeContent(m))
+		tx.r.releaseID(m.ID)
+	}
+	return err
+}
+
+// removeFiles removes files by their paths, skipping empty and already missing ones.
+func removeFiles(paths ...string) error {
+	var err error
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if err0 := os.Remove(path); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = multierr.Append(err, storageError(errors.Wrap(err0, "failed to remove file")))
+		}
+	}
+	return err
+}
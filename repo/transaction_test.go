@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"os"
+	"testing"
+)
+
+// assertRemoved checks that the content files of media don't exist.
+func assertRemoved(t *testing.T, ms ...*media.Media) {
+	t.Helper()
+
+	for _, m := range ms {
+		if _, err := os.Stat(m.Path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("content file %s wasn't removed: %v", m.Path, err)
+		}
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		r   = openTestRepo(t, dir, nil)
+	)
+
+	tx, err := r.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	for i := 1; i <= 2; i++ {
+		if _, err := tx.Create(context.Background(), testPNG(t, i, i), nil, nil); err != nil {
+			t.Fatalf("failed to stage media: %v", err)
+		}
+	}
+	if n := len(r.Items()); n != 0 {
+		t.Errorf("staged media is visible before the commit, got %d items", n)
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatalf("failed to commit transaction: %v", err)
+	}
+	if err := tx.Commit(context.Background()); err == nil {
+		t.Error("committing a finished transaction succeeded")
+	}
+
+	if n := len(openTestRepo(t, dir, nil).Items()); n != 2 {
+		t.Errorf("expected 2 items after reload, got %d", n)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		r        = openTestRepo(t, dir, nil)
+		existing = mustCreate(t, r, testPNG(t, 1, 1), nil)
+	)
+	index, err := os.ReadFile(r.lockPath)
+	if err != nil {
+		t.Fatalf("failed to read index file: %v", err)
+	}
+
+	// assertUnchanged checks that the repository only contains the existing media
+	assertUnchanged := func() {
+		t.Helper()
+
+		if items := r.Items(); len(items) != 1 || items[0].ID != existing.ID {
+			t.Errorf("expected only the existing item, got %d items", len(items))
+		}
+		if b, err := os.ReadFile(r.lockPath); err != nil || !bytes.Equal(b, index) {
+			t.Errorf("index file changed: %v", err)
+		}
+		if len(r.reserved) != 0 {
+			t.Errorf("expected no reserved IDs, got %d", len(r.reserved))
+		}
+	}
+
+	// a failure midway rolls back the media staged so far
+	tx, err := r.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	staged, err := tx.Create(context.Background(), testPNG(t, 2, 2), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to stage media: %v", err)
+	}
+	if _, err := tx.Create(context.Background(), testPNG(t, 3, 3), nil, &CreateOptions{Collection: "missing"}); err == nil {
+		t.Fatal("staging media into a missing collection succeeded")
+	}
+	if _, err := tx.Create(context.Background(), testPNG(t, 4, 4), nil, nil); err == nil {
+		t.Error("staging media into a rolled back transaction succeeded")
+	}
+	if err := tx.Commit(context.Background()); err == nil {
+		t.Error("committing a rolled back transaction succeeded")
+	}
+	assertRemoved(t, staged)
+	assertUnchanged()
+
+	// so does a failure to save the index on commit
+	tx, err = r.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if staged, err = tx.Create(context.Background(), testPNG(t, 2, 2), nil, nil); err != nil {
+		t.Fatalf("failed to stage media: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := tx.Commit(ctx); err == nil {
+		t.Fatal("committing with a cancelled context succeeded")
+	}
+	assertRemoved(t, staged)
+	assertUnchanged()
+
+	// and an explicit rollback
+	tx, err = r.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if staged, err = tx.Create(context.Background(), testPNG(t, 2, 2), nil, nil); err != nil {
+		t.Fatalf("failed to stage media: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("failed to roll back transaction: %v", err)
+	}
+	assertRemoved(t, staged)
+	assertUnchanged()
+
+	if _, err := os.Stat(existing.Path); err != nil {
+		t.Errorf("existing content file is missing: %v", err)
+	}
+}
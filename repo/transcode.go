@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Transcoder transcodes animated images to video.
+type Transcoder interface {
+	// Transcode transcodes an animated image to an MP4 video.
+	Transcode(ctx context.Context, b []byte) ([]byte, error)
+}
+
+// FFmpegTranscoder is a Transcoder invoking an external ffmpeg executable.
+type FFmpegTranscoder struct {
+	// Path is the path of the ffmpeg executable, looked up in PATH if it is a bare name.
+	Path string
+}
+
+// Transcode transcodes an animated image to a muted, looping-friendly MP4 video.
+func (ft *FFmpegTranscoder) Transcode(ctx context.Context, b []byte) ([]byte, error) {
+	return runFFmpeg(
+		ctx, ft.Path, b, "out.mp4",
+		"-an", "-movflags", "+faststart", "-pix_fmt", "yuv420p",
+		"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2", // yuv420p requires even dimensions
+	)
+}
+
+// runFFmpeg runs ffmpeg on the input content with the output options, returning the content of the named output file.
+// The output format is inferred from the extension of the output file name.
+func runFFmpeg(ctx context.Context, path string, b []byte, outName string, opts ...string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "nero-transcode-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		in  = filepath.Join(dir, "in")
+		out = filepath.Join(dir, outName)
+	)
+	if err := os.WriteFile(in, b, 0600); err != nil {
+		return nil, errors.Wrap(err, "failed to write transcoder input")
+	}
+
+	args := append([]string{"-hide_banner", "-loglevel", "error", "-i", in}, opts...)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, append(args, out)...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	b, err = os.ReadFile(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read transcoder output")
+	}
+
+	return b, nil
+}
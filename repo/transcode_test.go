@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testMP4 is the header of an MP4 video, enough for detecting its type.
+var testMP4 = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+
+// fakeTranscoder is a Transcoder returning testMP4 and recording its inputs.
+type fakeTranscoder struct {
+	inputs [][]byte
+}
+
+func (ft *fakeTranscoder) Transcode(_ context.Context, b []byte) ([]byte, error) {
+	ft.inputs = append(ft.inputs, b)
+	return testMP4, nil
+}
+
+func TestCreateTranscode(t *testing.T) {
+	var (
+		large = testGIF(t, 64, 64, 8)
+		small = testGIF(t, 2, 2, 2)
+		ft    = &fakeTranscoder{}
+		r     = newTestRepo(t, &Options{Transcoder: ft, TranscodeThreshold: int64(len(small)), KeepOriginal: true})
+	)
+
+	m := mustCreate(t, r, large, nil)
+	if len(ft.inputs) != 1 || !bytes.Equal(ft.inputs[0], large) {
+		t.Fatal("large animated image wasn't transcoded")
+	}
+	if m.Format != media.FormatVideo || filepath.Ext(m.Path) != ".mp4" || m.Size != int64(len(testMP4)) {
+		t.Errorf("transcoded media is %s at %s with %d bytes, want an MP4 video", m.Format, m.Path, m.Size)
+	}
+	if b, err := os.ReadFile(m.Path); err != nil || !bytes.Equal(b, testMP4) {
+		t.Errorf("stored content isn't the video rendition: %v", err)
+	}
+	if b, err := os.ReadFile(m.Original); err != nil || !bytes.Equal(b, large) {
+		t.Errorf("original content wasn't kept: %v", err)
+	}
+
+	m = mustCreate(t, r, small, nil)
+	if len(ft.inputs) != 1 || m.Format != media.FormatAnimatedImage {
+		t.Error("animated image below the threshold was transcoded")
+	}
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/google/uuid"
+)
+
+// Update is a set of changes to media, nil fields are left unchanged.
+type Update struct {
+	// Meta is the new metadata.
+	Meta meta.Metadata
+	// ContentType is the new content type override, an empty string removes the override.
+	ContentType *string
+	// Pinned is the new pinned state.
+	Pinned *bool
+	// Collection is the ID of the new collection, it must exist, an empty string removes the media from its collection.
+	Collection *string
+}
+
+// Update applies changes to media by its ID and persists them.
+// Returns nil if the media isn't in the repository, ErrMetaTypeNotAllowed if the new metadata type isn't allowed
+// and ErrUnknownCollection if the new collection doesn't exist.
+func (r *Repository) Update(ctx context.Context, id uuid.UUID, u *Update) (*media.Media, error) {
+	if err := r.checkMetaType(u.Meta); err != nil {
+		return nil, err
+	}
+	if u.Collection != nil {
+		if err := r.checkCollection(*u.Collection); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.items[id]
+	if !ok {
+		return nil, nil
+	}
+
+	m0 := *m // copy-on-write, the old media may still be read concurrently
+	if u.Meta != nil {
+		m0.Meta = u.Meta
+		if r.opts.Normalization != nil {
+			m0.Meta = r.opts.Normalization.metadata(m0.Meta)
+		}
+	}
+	if u.ContentType != nil {
+		m0.ContentType = *u.ContentType
+	}
+	if u.Pinned != nil {
+		m0.Pinned = *u.Pinned
+	}
+	if u.Collection != nil {
+		m0.Collection = *u.Collection
+	}
+
+	r.unindex(m)
+	r.items[id] = &m0
+	r.index(&m0)
+	return &m0, r.save(ctx)
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/repo/media"
+)
+
+// uploader returns the uploader client info to be stored with new media, nil if recording is disabled.
+func (r *Repository) uploader(u *media.Uploader) *media.Uploader {
+	if !r.opts.RecordUploader || u == nil || *u == (media.Uploader{}) {
+		return nil
+	}
+
+	u0 := *u
+	if r.opts.HashUploaderIP && u0.IP != "" {
+		hash := sha256.Sum256([]byte(r.opts.UploaderIPSalt + u0.IP))
+		u0.IP = hex.EncodeToString(hash[:])
+	}
+
+	return &u0
+}
@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/repo/media"
+	"testing"
+)
+
+func TestRecordUploader(t *testing.T) {
+	client := &media.Uploader{IP: "203.0.113.7", UserAgent: "test-agent/1.0"}
+
+	r := newTestRepo(t, nil)
+	if m := mustCreate(t, r, testPNG(t, 2, 2), &CreateOptions{Uploader: client}); m.Uploader != nil {
+		t.Error("uploader was recorded while disabled")
+	}
+
+	dir := t.TempDir()
+	r = openTestRepo(t, dir, &Options{RecordUploader: true})
+	m := mustCreate(t, r, testPNG(t, 2, 2), &CreateOptions{Uploader: client})
+	if m.Uploader == nil || *m.Uploader != *client {
+		t.Errorf("recorded uploader = %+v, want %+v", m.Uploader, client)
+	}
+	if m0 := openTestRepo(t, dir, nil).Get(m.ID); m0 == nil || m0.Uploader == nil || *m0.Uploader != *client {
+		t.Error("recorded uploader didn't survive a reload")
+	}
+
+	hash := sha256.Sum256([]byte("salt" + client.IP))
+	r = newTestRepo(t, &Options{RecordUploader: true, HashUploaderIP: true, UploaderIPSalt: "salt"})
+	m0 := mustCreate(t, r, testPNG(t, 2, 2), &CreateOptions{Uploader: client})
+	if m0.Uploader == nil || m0.Uploader.IP != hex.EncodeToString(hash[:]) || m0.Uploader.UserAgent != client.UserAgent {
+		t.Errorf("recorded uploader = %+v, want a salted hash of the IP address", m0.Uploader)
+	}
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultUpstreamTimeout is the default time limit of upstream requests, including reading the response body.
+const DefaultUpstreamTimeout = 5 * time.Minute
+
+// ErrUpstreamNotFound is an error returned by an Upstream that doesn't have the requested media.
+var ErrUpstreamNotFound = errors.New("media not found upstream")
+
+// defaultUpstreamClient is the HTTP client of upstreams without one.
+var defaultUpstreamClient = &http.Client{Timeout: DefaultUpstreamTimeout}
+
+// Upstream is a remote source of media content missing locally, making the repository a pull-through cache.
+type Upstream interface {
+	// Fetch fetches the content of media, returns an error wrapping ErrUpstreamNotFound if the upstream doesn't have it.
+	Fetch(ctx context.Context, m *media.Media) (io.ReadCloser, error)
+}
+
+// HTTPUpstream is an Upstream fetching content from the serving endpoint of another nero instance.
+type HTTPUpstream struct {
+	// BaseURL is the base URL of the upstream nero v1 API, i.e. https://example.com/api/v1.
+	BaseURL string
+	// Repo is the ID of the upstream repository.
+	Repo string
+	// Client is the HTTP client used for fetching, a client with DefaultUpstreamTimeout if nil.
+	Client *http.Client
+}
+
+// Fetch fetches the content of media from the upstream repository.
+func (hu *HTTPUpstream) Fetch(ctx context.Context, m *media.Media) (io.ReadCloser, error) {
+	u := strings.TrimSuffix(hu.BaseURL, "/") + "/repos/" + url.PathEscape(hu.Repo) + "/" + m.ID.String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create upstream request")
+	}
+
+	c := hu.Client
+	if c == nil {
+		c = defaultUpstreamClient
+	}
+
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send upstream request")
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK:
+		return res.Body, nil
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusBadRequest: // unknown items are 400s in v1
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamNotFound, m.ID)
+	}
+
+	_ = res.Body.Close()
+	return nil, fmt.Errorf("upstream request completed with error status code %d", res.StatusCode)
+}
+
+// pull fetches missing media content from the upstream and stores it locally.
+// Concurrent pulls of the same media share a single fetch, which isn't cancelled with any one of their contexts.
+func (r *Repository) pull(ctx context.Context, m *media.Media) error {
+	_, err, _ := r.pulls.Do(m.ID.String(), func() (any, error) {
+		if _, err := os.Stat(m.Path); err == nil {
+			return nil, nil // completed by a previous pull in the meantime
+		}
+
+		return nil, r.fetch(context.WithoutCancel(ctx), m)
+	})
+
+	return err
+}
+
+// fetch fetches media content from the upstream and moves it into place once complete.
+func (r *Repository) fetch(ctx context.Context, m *media.Media) (err error) {
+	r.logger.Debug("fetching media from upstream", zap.String("repo", r.id), zap.String("id", m.ID.String()))
+
+	rc, err := r.opts.Upstream.Fetch(ctx, m)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := rc.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close upstream response"))
+		}
+	}()
+
+	if err := os.MkdirAll(filepath.Dir(m.Path), 0755); err != nil {
+		return storageError(errors.Wrap(err, "failed to create media directory"))
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(m.Path), ".upstream-*")
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to create temporary file"))
+	}
+
+	tmpPath := f.Name()
+	defer func() {
+		if err0 := os.Remove(tmpPath); err0 != nil && !errors.Is(err0, os.ErrNotExist) {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to remove temporary file"))
+		}
+	}()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), rc)
+	if err == nil {
+		err = r.syncFile(f)
+	}
+	if err0 := f.Close(); err0 != nil {
+		err = multierr.Append(err, errors.Wrap(err0, "failed to close temporary file"))
+	}
+	if err != nil {
+		return storageError(errors.Wrap(err, "failed to write upstream content"))
+	}
+
+	if m.Hash != "" && !strings.EqualFold(m.Hash, hex.EncodeToString(h.Sum(nil))) {
+		return fmt.Errorf("upstream content of media %s has a checksum mismatch", m.ID)
+	}
+
+	// move into place only once complete, so partial downloads are never served
+	if err := os.Rename(tmpPath, m.Path); err != nil {
+		return storageError(errors.Wrap(err, "failed to move upstream content"))
+	}
+
+	return storageError(r.syncDir(filepath.Dir(m.Path)))
+}
@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUpstream(t *testing.T) {
+	var (
+		content  = testPNG(t, 4, 4)
+		sum      = sha256.Sum256(content)
+		m        = &media.Media{ID: uuid.New(), Format: media.FormatImage, Hash: hex.EncodeToString(sum[:])}
+		requests atomic.Int32
+		release  = make(chan struct{})
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		<-release // holds the fetch until all readers are waiting for it
+
+		if req.URL.Path != "/api/v1/repos/primary/"+m.ID.String() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(ts.Close)
+
+	r := newTestRepo(t, &Options{Upstream: &HTTPUpstream{BaseURL: ts.URL + "/api/v1/", Repo: "primary"}})
+	m.Path = filepath.Join(r.Path(), "nested", m.ID.String()+".png") // the directory is created on the first pull
+	if err := r.Add(context.Background(), m); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+
+	read := func() ([]byte, error) {
+		f, err := r.Open(context.Background(), m)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return io.ReadAll(f)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		reads = make([][]byte, 4)
+		errs  = make([]error, len(reads))
+	)
+	for i := range reads {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reads[i], errs[i] = read()
+		}(i)
+	}
+	for requests.Load() == 0 {
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+
+	for i := range reads {
+		if errs[i] != nil {
+			t.Fatalf("failed to read media missing locally: %v", errs[i])
+		}
+		if !bytes.Equal(reads[i], content) {
+			t.Error("read content differs from the upstream content")
+		}
+	}
+
+	// later reads are served locally
+	if _, err := read(); err != nil {
+		t.Fatalf("failed to read pulled media: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("upstream received %d requests, want 1", n)
+	}
+
+	unknown := &media.Media{ID: uuid.New(), Format: media.FormatImage, Path: filepath.Join(r.Path(), "unknown.png")}
+	if _, err := r.Open(context.Background(), unknown); !errors.Is(err, ErrUpstreamNotFound) {
+		t.Errorf("reading media unknown upstream failed with %v, want ErrUpstreamNotFound", err)
+	}
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"github.com/cephxdev/nero/repo/media/meta"
+	"go.uber.org/zap"
+)
+
+// Usage is the storage usage of a repository.
+type Usage struct {
+	// Bytes is the total size of the media content in bytes.
+	Bytes int64
+	// Items is the amount of media in the repository.
+	Items int
+	// Capacity is the configured quota in bytes, zero if there is none.
+	Capacity int64
+	// SoftCapacity is the configured soft quota in bytes, zero if there is none.
+	SoftCapacity int64
+	// SoftItemLimit is the configured soft item limit, zero if there is none.
+	SoftItemLimit int
+	// Warning is whether the soft quota or item limit is reached.
+	Warning bool
+}
+
+// checkQuota checks whether content of the specified size fits into the repository quota.
+func (r *Repository) checkQuota(size int64) error {
+	if r.opts.Quota <= 0 {
+		return nil
+	}
+
+	r.mu.RLock()
+	used := r.used
+	r.mu.RUnlock()
+
+	if used+size > r.opts.Quota {
+		return &ErrQuotaExceeded{Repo: r.id, Quota: r.opts.Quota}
+	}
+	return nil
+}
+
+// overSoftLimits checks whether the soft quota or item limit is reached, the caller must hold the lock.
+func (r *Repository) overSoftLimits() bool {
+	return (r.opts.SoftQuota > 0 && r.used >= r.opts.SoftQuota) ||
+		(r.opts.SoftItemLimit > 0 && len(r.items) >= r.opts.SoftItemLimit)
+}
+
+// warnSoftLimits logs a warning once the soft usage limits are reached, again after usage dropped below them.
+// The caller must hold the write lock.
+func (r *Repository) warnSoftLimits() {
+	over := r.overSoftLimits()
+	if over && !r.softWarned {
+		r.logger.Warn(
+			"repository reached its soft usage limit",
+			zap.String("repo", r.id),
+			zap.Int64("bytes", r.used),
+			zap.Int64("soft_quota", r.opts.SoftQuota),
+			zap.Int("items", len(r.items)),
+			zap.Int("soft_item_limit", r.opts.SoftItemLimit),
+		)
+	}
+	r.softWarned = over
+}
+
+// itemOverhead is the approximate memory overhead of an indexed item in bytes,
+// excluding its variable-length strings.
+const itemOverhead = 384
+
+// IndexMemory returns a rough estimate of the memory held by the repository index in bytes.
+func (r *Repository) IndexMemory() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var n int64
+	for _, m := range r.items {
+		n += itemOverhead + int64(len(m.Path)+len(m.Hash)+len(m.Original)+len(m.ContentType)+len(m.MIME))
+		switch m := m.Meta.(type) {
+		case *meta.GenericMetadata:
+			n += int64(len(m.Source) + len(m.Artist) + len(m.ArtistLink))
+		case *meta.AnimeMetadata:
+			n += int64(2 * len(m.Name)) // the name is cached lower-cased for matching
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSoftQuota(t *testing.T) {
+	var (
+		b = testPNG(t, 4, 4)
+		r = newTestRepo(t, &Options{SoftQuota: int64(len(b)) + 1})
+	)
+
+	mustCreate(t, r, b, nil)
+	if r.Usage().Warning {
+		t.Error("warning is reported below the soft quota")
+	}
+	m := mustCreate(t, r, testPNG(t, 1, 1), nil)
+	if !r.Usage().Warning {
+		t.Error("warning isn't reported above the soft quota")
+	}
+
+	if err := r.Remove(context.Background(), m.ID); err != nil {
+		t.Fatalf("failed to remove media: %v", err)
+	}
+	if r.Usage().Warning {
+		t.Error("warning is still reported after usage dropped below the soft quota")
+	}
+}
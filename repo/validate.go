@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	mime "github.com/gabriel-vasile/mimetype"
+	"image"
+	"image/gif"
+	"io"
+	"slices"
+)
+
+// DefaultMaxDecodePixels is the default maximum pixel count of stored images decoded for serving converted.
+const DefaultMaxDecodePixels = 100_000_000
+
+// checkDecodePixels returns an error wrapping errors.ErrUnsupported if an image is too large to be decoded for serving,
+// uploads are checked against the dimension limits, but stored media predating them may exceed any size.
+func (r *Repository) checkDecodePixels(rd io.Reader) error {
+	cfg, _, err := image.DecodeConfig(rd)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrUnsupported, err)
+	}
+	if pixels := int64(cfg.Width) * int64(cfg.Height); pixels > r.opts.MaxDecodePixels {
+		return fmt.Errorf("%w: image has %d pixels, above the limit of %d", errors.ErrUnsupported, pixels, r.opts.MaxDecodePixels)
+	}
+
+	return nil
+}
+
+// validate checks media content against the repository rules before it is stored.
+func (r *Repository) validate(b []byte, type_ *mime.MIME, format media.Format) error {
+	if format != media.FormatImage && format != media.FormatAnimatedImage {
+		return nil
+	}
+
+	// check dimensions and frame counts before any full decode, to cap its memory usage
+	if err := r.validateDimensions(b); err != nil {
+		return err
+	}
+	if format == media.FormatAnimatedImage {
+		if err := r.validateFrames(b, type_); err != nil {
+			return err
+		}
+	}
+
+	if r.opts.ValidateImages {
+		var err error
+		switch {
+		case type_.Is("image/gif"):
+			_, err = gif.DecodeAll(bytes.NewReader(b)) // decode all frames
+		case type_.Is("image/webp") && animatedWebP(b):
+			_, _, err = image.DecodeConfig(bytes.NewReader(b)) // animated WebP decoding is unsupported
+		default:
+			_, _, err = image.Decode(bytes.NewReader(b))
+		}
+
+		if err != nil {
+			return &ErrInvalidMedia{Reason: "image failed to decode", Err: err}
+		}
+	}
+
+	return nil
+}
+
+// checkMetaType checks the type of metadata against the types allowed in the repository, nil metadata is always allowed.
+func (r *Repository) checkMetaType(m meta.Metadata) error {
+	if m == nil || len(r.opts.MetaTypes) == 0 || slices.Contains(r.opts.MetaTypes, m.Type()) {
+		return nil
+	}
+
+	return &ErrMetaTypeNotAllowed{Type: m.Type(), Repo: r.id}
+}
+
+// validateDimensions checks image dimensions against the repository limits.
+func (r *Repository) validateDimensions(b []byte) error {
+	o := r.opts
+	if o.MinWidth <= 0 && o.MinHeight <= 0 && o.MaxWidth <= 0 && o.MaxHeight <= 0 {
+		return nil
+	}
+
+	width, height := dimensions(b)
+	if width == 0 || height == 0 {
+		return &ErrInvalidMedia{Reason: "image dimensions could not be read"}
+	}
+
+	switch {
+	case o.MinWidth > 0 && width < o.MinWidth:
+		return &ErrInvalidMedia{Reason: fmt.Sprintf("image width %d is below the minimum of %d", width, o.MinWidth)}
+	case o.MinHeight > 0 && height < o.MinHeight:
+		return &ErrInvalidMedia{Reason: fmt.Sprintf("image height %d is below the minimum of %d", height, o.MinHeight)}
+	case o.MaxWidth > 0 && width > o.MaxWidth:
+		return &ErrInvalidMedia{Reason: fmt.Sprintf("image width %d is above the maximum of %d", width, o.MaxWidth)}
+	case o.MaxHeight > 0 && height > o.MaxHeight:
+		return &ErrInvalidMedia{Reason: fmt.Sprintf("image height %d is above the maximum of %d", height, o.MaxHeight)}
+	}
+
+	return nil
+}
+
+// animatedWebP checks whether WebP content has the animation flag set in its extended header.
+func animatedWebP(b []byte) bool {
+	const animationBit = 1 << 1
+
+	// RIFF header (12 bytes), VP8X chunk header (8 bytes), VP8X flags
+	return len(b) > 20 && string(b[12:16]) == "VP8X" && b[20]&animationBit != 0
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"testing"
+)
+
+func TestValidateTruncatedImage(t *testing.T) {
+	b := testPNG(t, 16, 16)
+	b = b[:len(b)/2]
+
+	if _, err := newTestRepo(t, nil).Create(context.Background(), b, nil, nil); err != nil {
+		t.Errorf("truncated image was rejected without validation: %v", err)
+	}
+
+	r := newTestRepo(t, &Options{ValidateImages: true})
+	_, err := r.Create(context.Background(), b, nil, nil)
+
+	var eim *ErrInvalidMedia
+	if !errors.As(err, &eim) {
+		t.Fatalf("Create error = %v, want ErrInvalidMedia", err)
+	}
+	if r.Usage().Items != 0 {
+		t.Error("rejected image was stored")
+	}
+
+	if _, err := r.Create(context.Background(), testPNG(t, 16, 16), nil, nil); err != nil {
+		t.Errorf("valid image was rejected: %v", err)
+	}
+}
+
+func TestValidateDimensions(t *testing.T) {
+	r := newTestRepo(t, &Options{MinWidth: 8, MinHeight: 8, MaxWidth: 32})
+
+	tests := []struct {
+		name   string
+		b      []byte
+		reason string // empty if accepted
+	}{
+		{"undersized", testPNG(t, 4, 16), "image width 4 is below the minimum of 8"},
+		{"short", testPNG(t, 16, 4), "image height 4 is below the minimum of 8"},
+		{"oversized", testPNG(t, 64, 16), "image width 64 is above the maximum of 32"},
+		{"within limits", testPNG(t, 16, 16), ""},
+		{"not an image", []byte("plain text"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Create(context.Background(), tt.b, nil, nil)
+			if tt.reason == "" {
+				if err != nil {
+					t.Errorf("Create failed: %v", err)
+				}
+				return
+			}
+
+			var eim *ErrInvalidMedia
+			if !errors.As(err, &eim) || eim.Reason != tt.reason {
+				t.Errorf("Create error = %v, want ErrInvalidMedia with reason %q", err, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo/media"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultWebPCacheSize is the default size of the in-memory cache of WebP conversions in bytes.
+const DefaultWebPCacheSize = 64 << 20
+
+// WebPEncoder converts still images to WebP.
+type WebPEncoder interface {
+	// EncodeWebP converts a PNG or JPEG image to WebP.
+	EncodeWebP(ctx context.Context, b []byte) ([]byte, error)
+}
+
+// FFmpegWebPEncoder is a WebPEncoder invoking an external ffmpeg executable built with libwebp.
+type FFmpegWebPEncoder struct {
+	// Path is the path of the ffmpeg executable, looked up in PATH if it is a bare name.
+	Path string
+	// Quality is the lossy compression quality from 0 to 100, the encoder default if zero.
+	Quality int
+}
+
+// EncodeWebP converts a PNG or JPEG image to WebP.
+func (fe *FFmpegWebPEncoder) EncodeWebP(ctx context.Context, b []byte) ([]byte, error) {
+	opts := []string{"-c:v", "libwebp", "-frames:v", "1"}
+	if fe.Quality > 0 {
+		opts = append(opts, "-quality", strconv.Itoa(fe.Quality))
+	}
+
+	return runFFmpeg(ctx, fe.Path, b, "out.webp", opts...)
+}
+
+// WebP returns whether media can be served converted to WebP.
+// Only still PNG and JPEG images without a content type override are converted.
+func (r *Repository) WebP(m *media.Media) bool {
+	if r.opts.WebP == nil || m.Format != media.FormatImage || m.ContentType != "" {
+		return false
+	}
+
+	switch strings.ToLower(filepath.Ext(m.Path)) {
+	case ".png", ".jpg", ".jpeg":
+		return true
+	}
+	return false
+}
+
+// OpenWebP opens the content of media converted to WebP for reading, conversions are cached in memory
+// and concurrent requests for the same media share one.
+// Returns an error wrapping errors.ErrUnsupported if the media can't be served as WebP, see WebP,
+// or if it has more pixels than Options.MaxDecodePixels.
+func (r *Repository) OpenWebP(ctx context.Context, m *media.Media) (*File, error) {
+	if !r.WebP(m) {
+		return nil, errors.ErrUnsupported
+	}
+	if ce := r.webpCache.get(m.ID, ""); ce != nil {
+		return &File{ReadSeeker: bytes.NewReader(ce.data), ModTime: ce.modTime}, nil
+	}
+
+	v, err, _ := r.conversions.Do("webp/"+m.ID.String(), func() (any, error) {
+		return r.convertWebP(ctx, m)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ce := v.(*cacheEntry)
+	return &File{ReadSeeker: bytes.NewReader(ce.data), ModTime: ce.modTime}, nil
+}
+
+// convertWebP converts media content to WebP and caches it.
+func (r *Repository) convertWebP(ctx context.Context, m *media.Media) (*cacheEntry, error) {
+	f, err := r.Open(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(f)
+	if err0 := f.Close(); err0 != nil && err == nil {
+		err = errors.Wrap(err0, "failed to close media")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read media")
+	}
+	if err := r.checkDecodePixels(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	b, err = r.opts.WebP.EncodeWebP(ctx, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert media to WebP")
+	}
+
+	r.logger.Debug(
+		"converted media to WebP",
+		zap.String("repo", r.id),
+		zap.String("id", m.ID.String()),
+		zap.Int("size", len(b)),
+		zap.Duration("took", time.Since(start)),
+	)
+
+	ce := &cacheEntry{id: m.ID, data: b, modTime: f.ModTime}
+	r.webpCache.put(ce)
+	return ce, nil
+}
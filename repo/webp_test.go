@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"github.com/cephxdev/nero/internal/errors"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeWebPEncoder is a WebPEncoder returning fixed content and counting its calls,
+// a conversion is held until the release channel is closed if it isn't nil.
+type fakeWebPEncoder struct {
+	webp    []byte
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (fe *fakeWebPEncoder) EncodeWebP(context.Context, []byte) ([]byte, error) {
+	fe.calls.Add(1)
+	if fe.release != nil {
+		<-fe.release
+	}
+
+	return fe.webp, nil
+}
+
+func TestOpenWebP(t *testing.T) {
+	var (
+		fe = &fakeWebPEncoder{webp: []byte("RIFF\x00\x00\x00\x00WEBP"), release: make(chan struct{})}
+		r  = newTestRepo(t, &Options{WebP: fe})
+		m  = mustCreate(t, r, testPNG(t, 4, 4), nil)
+	)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, 4)
+	)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			f, err := r.OpenWebP(context.Background(), m)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer f.Close()
+
+			if b, _ := io.ReadAll(f); string(b) != string(fe.webp) {
+				errs[i] = errors.New("read content differs from the conversion")
+			}
+		}(i)
+	}
+	for fe.calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	close(fe.release)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("failed to open media as WebP: %v", err)
+		}
+	}
+	if _, err := r.OpenWebP(context.Background(), m); err != nil {
+		t.Fatalf("failed to open media as WebP: %v", err)
+	}
+	if n := fe.calls.Load(); n != 1 {
+		t.Errorf("media was converted %d times, want 1", n)
+	}
+}
+
+func TestOpenWebPDecodeLimit(t *testing.T) {
+	var (
+		fe = &fakeWebPEncoder{}
+		r  = newTestRepo(t, &Options{WebP: fe, MaxDecodePixels: 15})
+		m  = mustCreate(t, r, testPNG(t, 4, 4), nil)
+	)
+
+	if _, err := r.OpenWebP(context.Background(), m); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("converting an image above the pixel limit failed with %v, want errors.ErrUnsupported", err)
+	}
+	if fe.calls.Load() != 0 {
+		t.Error("image above the pixel limit was converted")
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/cephxdev/nero/repo/media"
+	"net"
+	"net/http"
+)
+
+// ClientIP determines the IP address of the client making a request.
+// The X-Forwarded-For header is respected, falling back to the remote address.
+func ClientIP(r *http.Request) string {
+	if ip := forwardedValue(r.Header, "X-Forwarded-For"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Uploader returns the client info of the client making a request, nil if r is nil.
+func Uploader(r *http.Request) *media.Uploader {
+	if r == nil {
+		return nil
+	}
+
+	return &media.Uploader{IP: ClientIP(r), UserAgent: r.UserAgent()}
+}
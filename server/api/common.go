@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 // MakeOptString converts a string to its pointer if it's not a zero value.
 func MakeOptString(v string) *string {
 	if v == "" {
@@ -15,3 +17,59 @@ func MakeString(v *string) string {
 	}
 	return *v
 }
+
+// MakeOptTime converts a time to its pointer if it's not a zero value.
+func MakeOptTime(v time.Time) *time.Time {
+	if v.IsZero() {
+		return nil
+	}
+	return &v
+}
+
+// MakeTime converts a time pointer to a time or a zero value if it's nil.
+func MakeTime(v *time.Time) time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+	return *v
+}
+
+// MakeOptInt converts an int to its pointer if it's not a zero value.
+func MakeOptInt(v int) *int {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// MakeInt converts an int pointer to an int or a zero value if it's nil.
+func MakeInt(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// MakeOptInt64 converts an int64 to its pointer if it's not a zero value.
+func MakeOptInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// MakeOptBool converts a bool to its pointer if it's not a zero value.
+func MakeOptBool(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return &v
+}
+
+// MakeSlice converts a slice pointer to a slice or nil if it's nil.
+func MakeSlice[T any](v *[]T) []T {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
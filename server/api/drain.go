@@ -0,0 +1,68 @@
+package api
+
+import "sync"
+
+// Drain is the lame duck phase of a shutting down server, in which new uploads are rejected
+// while in-flight ones finish and other requests are still served.
+type Drain struct {
+	started bool
+	uploads sync.WaitGroup
+	mu      sync.Mutex
+}
+
+// Start enters the lame duck phase.
+func (d *Drain) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.started = true
+}
+
+// Started returns whether the lame duck phase was entered, false if d is nil.
+func (d *Drain) Started() bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.started
+}
+
+// Begin tracks an in-flight upload until Done is called, returns false if the lame duck phase was entered.
+// Always succeeds if d is nil.
+func (d *Drain) Begin() bool {
+	if d == nil {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started {
+		return false
+	}
+
+	d.uploads.Add(1)
+	return true
+}
+
+// Done finishes tracking an upload started with Begin, no-op if d is nil.
+func (d *Drain) Done() {
+	if d != nil {
+		d.uploads.Done()
+	}
+}
+
+// Wait returns a channel closed once the uploads in flight when the lame duck phase was entered finished.
+// It must only be called after Start.
+func (d *Drain) Wait() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		d.uploads.Wait()
+		close(done)
+	}()
+
+	return done
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// AcceptsWebP returns whether the client of a request accepts WebP images.
+func AcceptsWebP(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(v, ",") {
+			type_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err == nil && type_ == "image/webp" && params["q"] != "0" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OpenFile opens media content for serving, negotiating the content format and setting the related response headers.
+// Media that can't be converted, i.e. too large images, is served as is.
+// The returned name is the file name the content should be served with.
+func OpenFile(w http.ResponseWriter, r *http.Request, rp *repo.Repository, m *media.Media) (f *repo.File, name string, err error) {
+	name = filepath.Base(m.Path)
+	if rp.WebP(m) {
+		w.Header().Add("Vary", "Accept")
+		if AcceptsWebP(r) {
+			f, err = rp.OpenWebP(r.Context(), m)
+			if err == nil {
+				recordServe(r, rp, m)
+
+				return f, strings.TrimSuffix(name, filepath.Ext(name)) + ".webp", nil
+			}
+			if !errors.Is(err, errors.ErrUnsupported) {
+				return nil, "", err
+			}
+		}
+	}
+
+	if f, err = rp.Open(r.Context(), m); err != nil {
+		return nil, "", err
+	}
+	recordServe(r, rp, m)
+
+	if m.ContentType != "" {
+		w.Header().Set("Content-Type", m.ContentType)
+	} else if m.MIME != "" {
+		w.Header().Set("Content-Type", m.MIME) // avoids sniffing the content again
+	}
+	if rp.Attachment(m) {
+		SetAttachment(w.Header(), name)
+	}
+
+	return f, name, nil
+}
+
+// OpenResized opens resized media content for serving, see repo.Repository.OpenResized.
+// The returned name is the file name the content should be served with.
+func OpenResized(w http.ResponseWriter, r *http.Request, rp *repo.Repository, m *media.Media, ro repo.ResizeOptions) (*repo.File, string, error) {
+	f, err := rp.OpenResized(r.Context(), m, ro)
+	if err != nil {
+		return nil, "", err
+	}
+	recordServe(r, rp, m)
+
+	name := repo.ResizedName(m)
+	w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(name)))
+
+	return f, name, nil
+}
+
+// recordServe updates the last access time of served media and counts full downloads,
+// partial and HEAD requests aren't counted.
+func recordServe(r *http.Request, rp *repo.Repository, m *media.Media) {
+	rp.Touch(m.ID)
+	if r.Method == http.MethodGet && r.Header.Get("Range") == "" {
+		rp.CountDownload(m.ID)
+	}
+}
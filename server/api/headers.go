@@ -0,0 +1,13 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+)
+
+// SetAttachment marks a response as a downloadable attachment that must not be rendered or executed inline.
+func SetAttachment(h http.Header, filename string) {
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	h.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	h.Set("X-Content-Type-Options", "nosniff")
+}
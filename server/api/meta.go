@@ -0,0 +1,8 @@
+package api
+
+import "net/http"
+
+// OmitMeta returns whether a request asked for media descriptors without metadata with the meta=omit query parameter.
+func OmitMeta(r *http.Request) bool {
+	return r != nil && r.URL.Query().Get("meta") == "omit"
+}
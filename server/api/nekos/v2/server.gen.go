@@ -504,6 +504,9 @@ type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
 type StrictHTTPServerOptions struct {
 	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
 	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// DisallowUnknownFieldsFunc reports whether JSON request bodies of an operation with unknown fields should be rejected,
+	// unknown fields are ignored if nil.
+	DisallowUnknownFieldsFunc func(r *http.Request, operationID string) bool
 }
 
 func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
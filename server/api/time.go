@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp is a point in time serialized as an RFC 3339 string (default) or as Unix epoch seconds.
+type Timestamp struct {
+	time.Time
+
+	// Unix is whether the timestamp should be serialized as Unix epoch seconds.
+	Unix bool
+}
+
+// MarshalJSON serializes the timestamp as an RFC 3339 string or as Unix epoch seconds.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.Unix {
+		return strconv.AppendInt(nil, t.Time.Unix(), 10), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+// UnmarshalJSON deserializes a timestamp from either an RFC 3339 string or Unix epoch seconds.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] != '"' && !bytes.Equal(b, []byte("null")) {
+		var secs int64
+		if err := json.Unmarshal(b, &secs); err != nil {
+			return err
+		}
+
+		t.Time, t.Unix = time.Unix(secs, 0).UTC(), true
+		return nil
+	}
+
+	t.Unix = false
+	return t.Time.UnmarshalJSON(b)
+}
+
+// MakeOptTimestamp converts a time to a timestamp pointer if it's not a zero value.
+func MakeOptTimestamp(v time.Time, unix bool) *Timestamp {
+	if v.IsZero() {
+		return nil
+	}
+	return &Timestamp{Time: v, Unix: unix}
+}
+
+// UnixTimestamps returns whether a request asked for timestamps as Unix epoch seconds,
+// either with the timestamps=unix query parameter or with a timestamps=unix parameter of an accepted media type.
+func UnixTimestamps(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	if v := r.URL.Query().Get("timestamps"); v != "" {
+		return v == "unix"
+	}
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mt := range strings.Split(accept, ",") {
+			if _, params, err := mime.ParseMediaType(strings.TrimSpace(mt)); err == nil && params["timestamps"] == "unix" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// requestKey is the context key of the current HTTP request.
+type requestKey struct{}
+
+// WithRequest stores the current HTTP request in a context.
+func WithRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// Request returns the HTTP request stored in a context, nil if there is none.
+func Request(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(requestKey{}).(*http.Request)
+	return r
+}
+
+// BaseURL determines the public base URL (scheme, host and path prefix) a request was made to.
+// base is preferred if it is not nil, otherwise the X-Forwarded-Proto and X-Forwarded-Host headers are respected,
+// falling back to the request host.
+func BaseURL(r *http.Request, base *url.URL) *url.URL {
+	if base != nil {
+		return &url.URL{Scheme: base.Scheme, Host: base.Host, Path: strings.TrimSuffix(base.Path, "/")}
+	}
+
+	u := &url.URL{Scheme: "http", Host: r.Host}
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	if proto := forwardedValue(r.Header, "X-Forwarded-Proto"); proto != "" {
+		u.Scheme = proto
+	}
+	if host := forwardedValue(r.Header, "X-Forwarded-Host"); host != "" {
+		u.Host = host
+	}
+
+	return u
+}
+
+// forwardedValue returns the value set by the first proxy in a X-Forwarded-* header.
+func forwardedValue(h http.Header, key string) string {
+	v, _, _ := strings.Cut(h.Get(key), ",")
+	return strings.TrimSpace(v)
+}
@@ -90,17 +90,101 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetHealthz request
+	GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRandom request
+	GetRandom(ctx context.Context, params *GetRandomParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepos request
+	GetRepos(ctx context.Context, params *GetReposParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepo request
+	GetRepo(ctx context.Context, repo string, params *GetRepoParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostRepoWithBody request with any body
 	PostRepoWithBody(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostRepo(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteRepoBatch request
+	DeleteRepoBatch(ctx context.Context, repo string, batch string, params *DeleteRepoBatchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoCollections request
+	GetRepoCollections(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteRepoCollection request
+	DeleteRepoCollection(ctx context.Context, repo string, collection string, params *DeleteRepoCollectionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutRepoCollectionWithBody request with any body
+	PutRepoCollectionWithBody(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutRepoCollection(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, body PutRepoCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRepoExistsBatchWithBody request with any body
+	PostRepoExistsBatchWithBody(ctx context.Context, repo string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRepoExistsBatch(ctx context.Context, repo string, body PostRepoExistsBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoExportTar request
+	GetRepoExportTar(ctx context.Context, repo string, params *GetRepoExportTarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoExportZip request
+	GetRepoExportZip(ctx context.Context, repo string, params *GetRepoExportZipParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoFacets request
+	GetRepoFacets(ctx context.Context, repo string, params *GetRepoFacetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoFeedJson request
+	GetRepoFeedJson(ctx context.Context, repo string, params *GetRepoFeedJsonParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoFeedRss request
+	GetRepoFeedRss(ctx context.Context, repo string, params *GetRepoFeedRssParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRepoImportTarWithBody request with any body
+	PostRepoImportTarWithBody(ctx context.Context, repo string, params *PostRepoImportTarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRepoImportZipWithBody request with any body
+	PostRepoImportZipWithBody(ctx context.Context, repo string, params *PostRepoImportZipParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoManifest request
+	GetRepoManifest(ctx context.Context, repo string, params *GetRepoManifestParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoRandom request
+	GetRepoRandom(ctx context.Context, repo string, params *GetRepoRandomParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoStats request
+	GetRepoStats(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRepoUploadTokens request
+	PostRepoUploadTokens(ctx context.Context, repo string, params *PostRepoUploadTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteRepoId request
 	DeleteRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoId request
+	GetRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchRepoIdWithBody request with any body
+	PatchRepoIdWithBody(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, body PatchRepoIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoIdLocation request
+	GetRepoIdLocation(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdLocationParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteRepoIdPin request
+	DeleteRepoIdPin(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdPinParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRepoIdPin request
+	PostRepoIdPin(ctx context.Context, repo string, id openapi_types.UUID, params *PostRepoIdPinParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepoIdThumbnail request
+	GetRepoIdThumbnail(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) PostRepoWithBody(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostRepoRequestWithBody(c.Server, repo, params, contentType, body)
+func (c *Client) GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthzRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +195,8 @@ func (c *Client) PostRepoWithBody(ctx context.Context, repo string, params *Post
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostRepo(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostRepoRequest(c.Server, repo, params, body)
+func (c *Client) GetRandom(ctx context.Context, params *GetRandomParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRandomRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -123,8 +207,8 @@ func (c *Client) PostRepo(ctx context.Context, repo string, params *PostRepoPara
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteRepoIdRequest(c.Server, repo, id, params)
+func (c *Client) GetRepos(ctx context.Context, params *GetReposParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReposRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -135,259 +219,4480 @@ func (c *Client) DeleteRepoId(ctx context.Context, repo string, id openapi_types
 	return c.Client.Do(req)
 }
 
-// NewPostRepoRequest calls the generic PostRepo builder with application/json body
-func NewPostRepoRequest(server string, repo string, params *PostRepoParams, body PostRepoJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetRepo(ctx context.Context, repo string, params *GetRepoParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoRequest(c.Server, repo, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostRepoRequestWithBody(server, repo, params, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostRepoRequestWithBody generates requests for PostRepo with any type of body
-func NewPostRepoRequestWithBody(server string, repo string, params *PostRepoParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+func (c *Client) PostRepoWithBody(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoRequestWithBody(c.Server, repo, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam0 string
+func (c *Client) PostRepo(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoRequest(c.Server, repo, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+func (c *Client) DeleteRepoBatch(ctx context.Context, repo string, batch string, params *DeleteRepoBatchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteRepoBatchRequest(c.Server, repo, batch, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetRepoCollections(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoCollectionsRequest(c.Server, repo)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/repos/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) DeleteRepoCollection(ctx context.Context, repo string, collection string, params *DeleteRepoCollectionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteRepoCollectionRequest(c.Server, repo, collection, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PutRepoCollectionWithBody(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutRepoCollectionRequestWithBody(c.Server, repo, collection, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) PutRepoCollection(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, body PutRepoCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutRepoCollectionRequest(c.Server, repo, collection, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req.Header.Add("Content-Type", contentType)
+func (c *Client) PostRepoExistsBatchWithBody(ctx context.Context, repo string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoExistsBatchRequestWithBody(c.Server, repo, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	if params != nil {
+func (c *Client) PostRepoExistsBatch(ctx context.Context, repo string, body PostRepoExistsBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoExistsBatchRequest(c.Server, repo, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		if params.XNeroKey != nil {
-			var headerParam0 string
+func (c *Client) GetRepoExportTar(ctx context.Context, repo string, params *GetRepoExportTarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoExportTarRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
-			if err != nil {
-				return nil, err
-			}
+func (c *Client) GetRepoExportZip(ctx context.Context, repo string, params *GetRepoExportZipParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoExportZipRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-			req.Header.Set("X-Nero-Key", headerParam0)
-		}
+func (c *Client) GetRepoFacets(ctx context.Context, repo string, params *GetRepoFacetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoFacetsRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
+func (c *Client) GetRepoFeedJson(ctx context.Context, repo string, params *GetRepoFeedJsonParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoFeedJsonRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) GetRepoFeedRss(ctx context.Context, repo string, params *GetRepoFeedRssParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoFeedRssRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewDeleteRepoIdRequest generates requests for DeleteRepoId
-func NewDeleteRepoIdRequest(server string, repo string, id openapi_types.UUID, params *DeleteRepoIdParams) (*http.Request, error) {
-	var err error
+func (c *Client) PostRepoImportTarWithBody(ctx context.Context, repo string, params *PostRepoImportTarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoImportTarRequestWithBody(c.Server, repo, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam0 string
+func (c *Client) PostRepoImportZipWithBody(ctx context.Context, repo string, params *PostRepoImportZipParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoImportZipRequestWithBody(c.Server, repo, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+func (c *Client) GetRepoManifest(ctx context.Context, repo string, params *GetRepoManifestParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoManifestRequest(c.Server, repo, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	var pathParam1 string
+func (c *Client) GetRepoRandom(ctx context.Context, repo string, params *GetRepoRandomParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoRandomRequest(c.Server, repo, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) GetRepoStats(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoStatsRequest(c.Server, repo)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostRepoUploadTokens(ctx context.Context, repo string, params *PostRepoUploadTokensParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoUploadTokensRequest(c.Server, repo, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/repos/%s/%s", pathParam0, pathParam1)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) DeleteRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteRepoIdRequest(c.Server, repo, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoIdRequest(c.Server, repo, id, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRepoIdWithBody(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRepoIdRequestWithBody(c.Server, repo, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchRepoId(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, body PatchRepoIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRepoIdRequest(c.Server, repo, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRepoIdLocation(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdLocationParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoIdLocationRequest(c.Server, repo, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteRepoIdPin(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdPinParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteRepoIdPinRequest(c.Server, repo, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRepoIdPin(ctx context.Context, repo string, id openapi_types.UUID, params *PostRepoIdPinParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRepoIdPinRequest(c.Server, repo, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRepoIdThumbnail(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdThumbnailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepoIdThumbnailRequest(c.Server, repo, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetHealthzRequest generates requests for GetHealthz
+func NewGetHealthzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRandomRequest generates requests for GetRandom
+func NewGetRandomRequest(server string, params *GetRandomParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/random")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Repos != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "repos", runtime.ParamLocationQuery, *params.Repos); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Count != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "count", runtime.ParamLocationQuery, *params.Count); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Spread != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "spread", runtime.ParamLocationQuery, *params.Spread); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReposRequest generates requests for GetRepos
+func NewGetReposRequest(server string, params *GetReposParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Writable != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "writable", runtime.ParamLocationQuery, *params.Writable); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MetaType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "metaType", runtime.ParamLocationQuery, *params.MetaType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRepoRequest generates requests for GetRepo
+func NewGetRepoRequest(server string, repo string, params *GetRepoParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Pinned != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pinned", runtime.ParamLocationQuery, *params.Pinned); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IncludeUnknown != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "includeUnknown", runtime.ParamLocationQuery, *params.IncludeUnknown); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Collection != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "collection", runtime.ParamLocationQuery, *params.Collection); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Batch != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "batch", runtime.ParamLocationQuery, *params.Batch); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.HashPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "hashPrefix", runtime.ParamLocationQuery, *params.HashPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Missing != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "missing", runtime.ParamLocationQuery, *params.Missing); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Artist != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "artist", runtime.ParamLocationQuery, *params.Artist); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Source != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "source", runtime.ParamLocationQuery, *params.Source); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Name != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "name", runtime.ParamLocationQuery, *params.Name); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostRepoRequest calls the generic PostRepo builder with application/json body
+func NewPostRepoRequest(server string, repo string, params *PostRepoParams, body PostRepoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRepoRequestWithBody(server, repo, params, "application/json", bodyReader)
+}
+
+// NewPostRepoRequestWithBody generates requests for PostRepo with any type of body
+func NewPostRepoRequestWithBody(server string, repo string, params *PostRepoParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Token != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "token", runtime.ParamLocationQuery, *params.Token); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+		if params.XContentSHA256 != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-Content-SHA256", runtime.ParamLocationHeader, *params.XContentSHA256)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Content-SHA256", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteRepoBatchRequest generates requests for DeleteRepoBatch
+func NewDeleteRepoBatchRequest(server string, repo string, batch string, params *DeleteRepoBatchParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "batch", runtime.ParamLocationPath, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/batches/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoCollectionsRequest generates requests for GetRepoCollections
+func NewGetRepoCollectionsRequest(server string, repo string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/collections", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteRepoCollectionRequest generates requests for DeleteRepoCollection
+func NewDeleteRepoCollectionRequest(server string, repo string, collection string, params *DeleteRepoCollectionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/collections/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPutRepoCollectionRequest calls the generic PutRepoCollection builder with application/json body
+func NewPutRepoCollectionRequest(server string, repo string, collection string, params *PutRepoCollectionParams, body PutRepoCollectionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutRepoCollectionRequestWithBody(server, repo, collection, params, "application/json", bodyReader)
+}
+
+// NewPutRepoCollectionRequestWithBody generates requests for PutRepoCollection with any type of body
+func NewPutRepoCollectionRequestWithBody(server string, repo string, collection string, params *PutRepoCollectionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "collection", runtime.ParamLocationPath, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/collections/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostRepoExistsBatchRequest calls the generic PostRepoExistsBatch builder with application/json body
+func NewPostRepoExistsBatchRequest(server string, repo string, body PostRepoExistsBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRepoExistsBatchRequestWithBody(server, repo, "application/json", bodyReader)
+}
+
+// NewPostRepoExistsBatchRequestWithBody generates requests for PostRepoExistsBatch with any type of body
+func NewPostRepoExistsBatchRequestWithBody(server string, repo string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/exists-batch", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRepoExportTarRequest generates requests for GetRepoExportTar
+func NewGetRepoExportTarRequest(server string, repo string, params *GetRepoExportTarParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/export.tar", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoExportZipRequest generates requests for GetRepoExportZip
+func NewGetRepoExportZipRequest(server string, repo string, params *GetRepoExportZipParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/export.zip", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoFacetsRequest generates requests for GetRepoFacets
+func NewGetRepoFacetsRequest(server string, repo string, params *GetRepoFacetsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/facets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "field", runtime.ParamLocationQuery, params.Field); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRepoFeedJsonRequest generates requests for GetRepoFeedJson
+func NewGetRepoFeedJsonRequest(server string, repo string, params *GetRepoFeedJsonParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/feed.json", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRepoFeedRssRequest generates requests for GetRepoFeedRss
+func NewGetRepoFeedRssRequest(server string, repo string, params *GetRepoFeedRssParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/feed.rss", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostRepoImportTarRequestWithBody generates requests for PostRepoImportTar with any type of body
+func NewPostRepoImportTarRequestWithBody(server string, repo string, params *PostRepoImportTarParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/import.tar", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Collision != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "collision", runtime.ParamLocationQuery, *params.Collision); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostRepoImportZipRequestWithBody generates requests for PostRepoImportZip with any type of body
+func NewPostRepoImportZipRequestWithBody(server string, repo string, params *PostRepoImportZipParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/import.zip", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Collision != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "collision", runtime.ParamLocationQuery, *params.Collision); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoManifestRequest generates requests for GetRepoManifest
+func NewGetRepoManifestRequest(server string, repo string, params *GetRepoManifestParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/manifest", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoRandomRequest generates requests for GetRepoRandom
+func NewGetRepoRandomRequest(server string, repo string, params *GetRepoRandomParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/random", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Count != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "count", runtime.ParamLocationQuery, *params.Count); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Weight != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "weight", runtime.ParamLocationQuery, *params.Weight); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Exclude != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclude", runtime.ParamLocationQuery, *params.Exclude); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IncludeUnknown != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "includeUnknown", runtime.ParamLocationQuery, *params.IncludeUnknown); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRepoStatsRequest generates requests for GetRepoStats
+func NewGetRepoStatsRequest(server string, repo string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostRepoUploadTokensRequest generates requests for PostRepoUploadTokens
+func NewPostRepoUploadTokensRequest(server string, repo string, params *PostRepoUploadTokensParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/upload-tokens", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Ttl != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ttl", runtime.ParamLocationQuery, *params.Ttl); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteRepoIdRequest generates requests for DeleteRepoId
+func NewDeleteRepoIdRequest(server string, repo string, id openapi_types.UUID, params *DeleteRepoIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoIdRequest generates requests for GetRepoId
+func NewGetRepoIdRequest(server string, repo string, id openapi_types.UUID, params *GetRepoIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.W != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "w", runtime.ParamLocationQuery, *params.W); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.H != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "h", runtime.ParamLocationQuery, *params.H); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fit", runtime.ParamLocationQuery, *params.Fit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchRepoIdRequest calls the generic PatchRepoId builder with application/json body
+func NewPatchRepoIdRequest(server string, repo string, id openapi_types.UUID, params *PatchRepoIdParams, body PatchRepoIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRepoIdRequestWithBody(server, repo, id, params, "application/json", bodyReader)
+}
+
+// NewPatchRepoIdRequestWithBody generates requests for PatchRepoId with any type of body
+func NewPatchRepoIdRequestWithBody(server string, repo string, id openapi_types.UUID, params *PatchRepoIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoIdLocationRequest generates requests for GetRepoIdLocation
+func NewGetRepoIdLocationRequest(server string, repo string, id openapi_types.UUID, params *GetRepoIdLocationParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s/location", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteRepoIdPinRequest generates requests for DeleteRepoIdPin
+func NewDeleteRepoIdPinRequest(server string, repo string, id openapi_types.UUID, params *DeleteRepoIdPinParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s/pin", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostRepoIdPinRequest generates requests for PostRepoIdPin
+func NewPostRepoIdPinRequest(server string, repo string, id openapi_types.UUID, params *PostRepoIdPinParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s/pin", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XNeroKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Nero-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRepoIdThumbnailRequest generates requests for GetRepoIdThumbnail
+func NewGetRepoIdThumbnailRequest(server string, repo string, id openapi_types.UUID, params *GetRepoIdThumbnailParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "repo", runtime.ParamLocationPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/repos/%s/%s/thumbnail", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Size != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "size", runtime.ParamLocationQuery, *params.Size); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetHealthzWithResponse request
+	GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error)
+
+	// GetRandomWithResponse request
+	GetRandomWithResponse(ctx context.Context, params *GetRandomParams, reqEditors ...RequestEditorFn) (*GetRandomResponse, error)
+
+	// GetReposWithResponse request
+	GetReposWithResponse(ctx context.Context, params *GetReposParams, reqEditors ...RequestEditorFn) (*GetReposResponse, error)
+
+	// GetRepoWithResponse request
+	GetRepoWithResponse(ctx context.Context, repo string, params *GetRepoParams, reqEditors ...RequestEditorFn) (*GetRepoResponse, error)
+
+	// PostRepoWithBodyWithResponse request with any body
+	PostRepoWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoResponse, error)
+
+	PostRepoWithResponse(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoResponse, error)
+
+	// DeleteRepoBatchWithResponse request
+	DeleteRepoBatchWithResponse(ctx context.Context, repo string, batch string, params *DeleteRepoBatchParams, reqEditors ...RequestEditorFn) (*DeleteRepoBatchResponse, error)
+
+	// GetRepoCollectionsWithResponse request
+	GetRepoCollectionsWithResponse(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*GetRepoCollectionsResponse, error)
+
+	// DeleteRepoCollectionWithResponse request
+	DeleteRepoCollectionWithResponse(ctx context.Context, repo string, collection string, params *DeleteRepoCollectionParams, reqEditors ...RequestEditorFn) (*DeleteRepoCollectionResponse, error)
+
+	// PutRepoCollectionWithBodyWithResponse request with any body
+	PutRepoCollectionWithBodyWithResponse(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRepoCollectionResponse, error)
+
+	PutRepoCollectionWithResponse(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, body PutRepoCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRepoCollectionResponse, error)
+
+	// PostRepoExistsBatchWithBodyWithResponse request with any body
+	PostRepoExistsBatchWithBodyWithResponse(ctx context.Context, repo string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoExistsBatchResponse, error)
+
+	PostRepoExistsBatchWithResponse(ctx context.Context, repo string, body PostRepoExistsBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoExistsBatchResponse, error)
+
+	// GetRepoExportTarWithResponse request
+	GetRepoExportTarWithResponse(ctx context.Context, repo string, params *GetRepoExportTarParams, reqEditors ...RequestEditorFn) (*GetRepoExportTarResponse, error)
+
+	// GetRepoExportZipWithResponse request
+	GetRepoExportZipWithResponse(ctx context.Context, repo string, params *GetRepoExportZipParams, reqEditors ...RequestEditorFn) (*GetRepoExportZipResponse, error)
+
+	// GetRepoFacetsWithResponse request
+	GetRepoFacetsWithResponse(ctx context.Context, repo string, params *GetRepoFacetsParams, reqEditors ...RequestEditorFn) (*GetRepoFacetsResponse, error)
+
+	// GetRepoFeedJsonWithResponse request
+	GetRepoFeedJsonWithResponse(ctx context.Context, repo string, params *GetRepoFeedJsonParams, reqEditors ...RequestEditorFn) (*GetRepoFeedJsonResponse, error)
+
+	// GetRepoFeedRssWithResponse request
+	GetRepoFeedRssWithResponse(ctx context.Context, repo string, params *GetRepoFeedRssParams, reqEditors ...RequestEditorFn) (*GetRepoFeedRssResponse, error)
+
+	// PostRepoImportTarWithBodyWithResponse request with any body
+	PostRepoImportTarWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoImportTarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoImportTarResponse, error)
+
+	// PostRepoImportZipWithBodyWithResponse request with any body
+	PostRepoImportZipWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoImportZipParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoImportZipResponse, error)
+
+	// GetRepoManifestWithResponse request
+	GetRepoManifestWithResponse(ctx context.Context, repo string, params *GetRepoManifestParams, reqEditors ...RequestEditorFn) (*GetRepoManifestResponse, error)
+
+	// GetRepoRandomWithResponse request
+	GetRepoRandomWithResponse(ctx context.Context, repo string, params *GetRepoRandomParams, reqEditors ...RequestEditorFn) (*GetRepoRandomResponse, error)
+
+	// GetRepoStatsWithResponse request
+	GetRepoStatsWithResponse(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*GetRepoStatsResponse, error)
+
+	// PostRepoUploadTokensWithResponse request
+	PostRepoUploadTokensWithResponse(ctx context.Context, repo string, params *PostRepoUploadTokensParams, reqEditors ...RequestEditorFn) (*PostRepoUploadTokensResponse, error)
+
+	// DeleteRepoIdWithResponse request
+	DeleteRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdResponse, error)
+
+	// GetRepoIdWithResponse request
+	GetRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdParams, reqEditors ...RequestEditorFn) (*GetRepoIdResponse, error)
+
+	// PatchRepoIdWithBodyWithResponse request with any body
+	PatchRepoIdWithBodyWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRepoIdResponse, error)
+
+	PatchRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, body PatchRepoIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRepoIdResponse, error)
+
+	// GetRepoIdLocationWithResponse request
+	GetRepoIdLocationWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdLocationParams, reqEditors ...RequestEditorFn) (*GetRepoIdLocationResponse, error)
+
+	// DeleteRepoIdPinWithResponse request
+	DeleteRepoIdPinWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdPinParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdPinResponse, error)
+
+	// PostRepoIdPinWithResponse request
+	PostRepoIdPinWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PostRepoIdPinParams, reqEditors ...RequestEditorFn) (*PostRepoIdPinResponse, error)
+
+	// GetRepoIdThumbnailWithResponse request
+	GetRepoIdThumbnailWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdThumbnailParams, reqEditors ...RequestEditorFn) (*GetRepoIdThumbnailResponse, error)
+}
+
+type GetHealthzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRandomResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Media
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRandomResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRandomResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReposResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RepoInfo
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReposResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReposResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Media
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Media
+	JSON400      *Error
+	JSON401      *Error
+	JSON422      *Error
+	JSON429      *Error
+	JSON503      *Error
+	JSON507      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRepoBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Media
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRepoBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRepoBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoCollectionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Collection
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoCollectionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoCollectionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRepoCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Collection
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRepoCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRepoCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutRepoCollectionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Collection
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutRepoCollectionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutRepoCollectionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoExistsBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]bool
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoExistsBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoExistsBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoExportTarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoExportTarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoExportTarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoExportZipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoExportZipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoExportZipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoFacetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Facet
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoFacetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoFacetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoFeedJsonResponse struct {
+	Body                   []byte
+	HTTPResponse           *http.Response
+	ApplicationfeedJSON200 *JSONFeed
+	JSON400                *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoFeedJsonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoFeedJsonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoFeedRssResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoFeedRssResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoFeedRssResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoImportTarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON422      *Error
+	JSON503      *Error
+	JSON507      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoImportTarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoImportTarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoImportZipResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON422      *Error
+	JSON503      *Error
+	JSON507      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoImportZipResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoImportZipResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoManifestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoManifestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoManifestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoRandomResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Media
+	JSON400      *Error
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoRandomResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoRandomResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RepoInfo
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoUploadTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UploadToken
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoUploadTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoUploadTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRepoIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Media
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRepoIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRepoIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchRepoIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Media
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchRepoIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchRepoIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoIdLocationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Location
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoIdLocationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoIdLocationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRepoIdPinResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Media
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteRepoIdPinResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteRepoIdPinResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRepoIdPinResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Media
+	JSON400      *Error
+	JSON401      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRepoIdPinResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRepoIdPinResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRepoIdThumbnailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRepoIdThumbnailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRepoIdThumbnailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetHealthzWithResponse request returning *GetHealthzResponse
+func (c *ClientWithResponses) GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error) {
+	rsp, err := c.GetHealthz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthzResponse(rsp)
+}
+
+// GetRandomWithResponse request returning *GetRandomResponse
+func (c *ClientWithResponses) GetRandomWithResponse(ctx context.Context, params *GetRandomParams, reqEditors ...RequestEditorFn) (*GetRandomResponse, error) {
+	rsp, err := c.GetRandom(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRandomResponse(rsp)
+}
+
+// GetReposWithResponse request returning *GetReposResponse
+func (c *ClientWithResponses) GetReposWithResponse(ctx context.Context, params *GetReposParams, reqEditors ...RequestEditorFn) (*GetReposResponse, error) {
+	rsp, err := c.GetRepos(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReposResponse(rsp)
+}
+
+// GetRepoWithResponse request returning *GetRepoResponse
+func (c *ClientWithResponses) GetRepoWithResponse(ctx context.Context, repo string, params *GetRepoParams, reqEditors ...RequestEditorFn) (*GetRepoResponse, error) {
+	rsp, err := c.GetRepo(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoResponse(rsp)
+}
+
+// PostRepoWithBodyWithResponse request with arbitrary body returning *PostRepoResponse
+func (c *ClientWithResponses) PostRepoWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoResponse, error) {
+	rsp, err := c.PostRepoWithBody(ctx, repo, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRepoWithResponse(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoResponse, error) {
+	rsp, err := c.PostRepo(ctx, repo, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoResponse(rsp)
+}
+
+// DeleteRepoBatchWithResponse request returning *DeleteRepoBatchResponse
+func (c *ClientWithResponses) DeleteRepoBatchWithResponse(ctx context.Context, repo string, batch string, params *DeleteRepoBatchParams, reqEditors ...RequestEditorFn) (*DeleteRepoBatchResponse, error) {
+	rsp, err := c.DeleteRepoBatch(ctx, repo, batch, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRepoBatchResponse(rsp)
+}
+
+// GetRepoCollectionsWithResponse request returning *GetRepoCollectionsResponse
+func (c *ClientWithResponses) GetRepoCollectionsWithResponse(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*GetRepoCollectionsResponse, error) {
+	rsp, err := c.GetRepoCollections(ctx, repo, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoCollectionsResponse(rsp)
+}
+
+// DeleteRepoCollectionWithResponse request returning *DeleteRepoCollectionResponse
+func (c *ClientWithResponses) DeleteRepoCollectionWithResponse(ctx context.Context, repo string, collection string, params *DeleteRepoCollectionParams, reqEditors ...RequestEditorFn) (*DeleteRepoCollectionResponse, error) {
+	rsp, err := c.DeleteRepoCollection(ctx, repo, collection, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRepoCollectionResponse(rsp)
+}
+
+// PutRepoCollectionWithBodyWithResponse request with arbitrary body returning *PutRepoCollectionResponse
+func (c *ClientWithResponses) PutRepoCollectionWithBodyWithResponse(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutRepoCollectionResponse, error) {
+	rsp, err := c.PutRepoCollectionWithBody(ctx, repo, collection, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRepoCollectionResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutRepoCollectionWithResponse(ctx context.Context, repo string, collection string, params *PutRepoCollectionParams, body PutRepoCollectionJSONRequestBody, reqEditors ...RequestEditorFn) (*PutRepoCollectionResponse, error) {
+	rsp, err := c.PutRepoCollection(ctx, repo, collection, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutRepoCollectionResponse(rsp)
+}
+
+// PostRepoExistsBatchWithBodyWithResponse request with arbitrary body returning *PostRepoExistsBatchResponse
+func (c *ClientWithResponses) PostRepoExistsBatchWithBodyWithResponse(ctx context.Context, repo string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoExistsBatchResponse, error) {
+	rsp, err := c.PostRepoExistsBatchWithBody(ctx, repo, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoExistsBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRepoExistsBatchWithResponse(ctx context.Context, repo string, body PostRepoExistsBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoExistsBatchResponse, error) {
+	rsp, err := c.PostRepoExistsBatch(ctx, repo, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoExistsBatchResponse(rsp)
+}
+
+// GetRepoExportTarWithResponse request returning *GetRepoExportTarResponse
+func (c *ClientWithResponses) GetRepoExportTarWithResponse(ctx context.Context, repo string, params *GetRepoExportTarParams, reqEditors ...RequestEditorFn) (*GetRepoExportTarResponse, error) {
+	rsp, err := c.GetRepoExportTar(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoExportTarResponse(rsp)
+}
+
+// GetRepoExportZipWithResponse request returning *GetRepoExportZipResponse
+func (c *ClientWithResponses) GetRepoExportZipWithResponse(ctx context.Context, repo string, params *GetRepoExportZipParams, reqEditors ...RequestEditorFn) (*GetRepoExportZipResponse, error) {
+	rsp, err := c.GetRepoExportZip(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoExportZipResponse(rsp)
+}
+
+// GetRepoFacetsWithResponse request returning *GetRepoFacetsResponse
+func (c *ClientWithResponses) GetRepoFacetsWithResponse(ctx context.Context, repo string, params *GetRepoFacetsParams, reqEditors ...RequestEditorFn) (*GetRepoFacetsResponse, error) {
+	rsp, err := c.GetRepoFacets(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoFacetsResponse(rsp)
+}
+
+// GetRepoFeedJsonWithResponse request returning *GetRepoFeedJsonResponse
+func (c *ClientWithResponses) GetRepoFeedJsonWithResponse(ctx context.Context, repo string, params *GetRepoFeedJsonParams, reqEditors ...RequestEditorFn) (*GetRepoFeedJsonResponse, error) {
+	rsp, err := c.GetRepoFeedJson(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoFeedJsonResponse(rsp)
+}
+
+// GetRepoFeedRssWithResponse request returning *GetRepoFeedRssResponse
+func (c *ClientWithResponses) GetRepoFeedRssWithResponse(ctx context.Context, repo string, params *GetRepoFeedRssParams, reqEditors ...RequestEditorFn) (*GetRepoFeedRssResponse, error) {
+	rsp, err := c.GetRepoFeedRss(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoFeedRssResponse(rsp)
+}
+
+// PostRepoImportTarWithBodyWithResponse request with arbitrary body returning *PostRepoImportTarResponse
+func (c *ClientWithResponses) PostRepoImportTarWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoImportTarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoImportTarResponse, error) {
+	rsp, err := c.PostRepoImportTarWithBody(ctx, repo, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoImportTarResponse(rsp)
+}
+
+// PostRepoImportZipWithBodyWithResponse request with arbitrary body returning *PostRepoImportZipResponse
+func (c *ClientWithResponses) PostRepoImportZipWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoImportZipParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoImportZipResponse, error) {
+	rsp, err := c.PostRepoImportZipWithBody(ctx, repo, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoImportZipResponse(rsp)
+}
+
+// GetRepoManifestWithResponse request returning *GetRepoManifestResponse
+func (c *ClientWithResponses) GetRepoManifestWithResponse(ctx context.Context, repo string, params *GetRepoManifestParams, reqEditors ...RequestEditorFn) (*GetRepoManifestResponse, error) {
+	rsp, err := c.GetRepoManifest(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoManifestResponse(rsp)
+}
+
+// GetRepoRandomWithResponse request returning *GetRepoRandomResponse
+func (c *ClientWithResponses) GetRepoRandomWithResponse(ctx context.Context, repo string, params *GetRepoRandomParams, reqEditors ...RequestEditorFn) (*GetRepoRandomResponse, error) {
+	rsp, err := c.GetRepoRandom(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoRandomResponse(rsp)
+}
+
+// GetRepoStatsWithResponse request returning *GetRepoStatsResponse
+func (c *ClientWithResponses) GetRepoStatsWithResponse(ctx context.Context, repo string, reqEditors ...RequestEditorFn) (*GetRepoStatsResponse, error) {
+	rsp, err := c.GetRepoStats(ctx, repo, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoStatsResponse(rsp)
+}
+
+// PostRepoUploadTokensWithResponse request returning *PostRepoUploadTokensResponse
+func (c *ClientWithResponses) PostRepoUploadTokensWithResponse(ctx context.Context, repo string, params *PostRepoUploadTokensParams, reqEditors ...RequestEditorFn) (*PostRepoUploadTokensResponse, error) {
+	rsp, err := c.PostRepoUploadTokens(ctx, repo, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoUploadTokensResponse(rsp)
+}
+
+// DeleteRepoIdWithResponse request returning *DeleteRepoIdResponse
+func (c *ClientWithResponses) DeleteRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdResponse, error) {
+	rsp, err := c.DeleteRepoId(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRepoIdResponse(rsp)
+}
+
+// GetRepoIdWithResponse request returning *GetRepoIdResponse
+func (c *ClientWithResponses) GetRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdParams, reqEditors ...RequestEditorFn) (*GetRepoIdResponse, error) {
+	rsp, err := c.GetRepoId(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoIdResponse(rsp)
+}
+
+// PatchRepoIdWithBodyWithResponse request with arbitrary body returning *PatchRepoIdResponse
+func (c *ClientWithResponses) PatchRepoIdWithBodyWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchRepoIdResponse, error) {
+	rsp, err := c.PatchRepoIdWithBody(ctx, repo, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRepoIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PatchRepoIdParams, body PatchRepoIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRepoIdResponse, error) {
+	rsp, err := c.PatchRepoId(ctx, repo, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRepoIdResponse(rsp)
+}
+
+// GetRepoIdLocationWithResponse request returning *GetRepoIdLocationResponse
+func (c *ClientWithResponses) GetRepoIdLocationWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdLocationParams, reqEditors ...RequestEditorFn) (*GetRepoIdLocationResponse, error) {
+	rsp, err := c.GetRepoIdLocation(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoIdLocationResponse(rsp)
+}
+
+// DeleteRepoIdPinWithResponse request returning *DeleteRepoIdPinResponse
+func (c *ClientWithResponses) DeleteRepoIdPinWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdPinParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdPinResponse, error) {
+	rsp, err := c.DeleteRepoIdPin(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteRepoIdPinResponse(rsp)
+}
+
+// PostRepoIdPinWithResponse request returning *PostRepoIdPinResponse
+func (c *ClientWithResponses) PostRepoIdPinWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *PostRepoIdPinParams, reqEditors ...RequestEditorFn) (*PostRepoIdPinResponse, error) {
+	rsp, err := c.PostRepoIdPin(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRepoIdPinResponse(rsp)
+}
+
+// GetRepoIdThumbnailWithResponse request returning *GetRepoIdThumbnailResponse
+func (c *ClientWithResponses) GetRepoIdThumbnailWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *GetRepoIdThumbnailParams, reqEditors ...RequestEditorFn) (*GetRepoIdThumbnailResponse, error) {
+	rsp, err := c.GetRepoIdThumbnail(ctx, repo, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepoIdThumbnailResponse(rsp)
+}
+
+// ParseGetHealthzResponse parses an HTTP response from a GetHealthzWithResponse call
+func ParseGetHealthzResponse(rsp *http.Response) (*GetHealthzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRandomResponse parses an HTTP response from a GetRandomWithResponse call
+func ParseGetRandomResponse(rsp *http.Response) (*GetRandomResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRandomResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReposResponse parses an HTTP response from a GetReposWithResponse call
+func ParseGetReposResponse(rsp *http.Response) (*GetReposResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReposResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RepoInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoResponse parses an HTTP response from a GetRepoWithResponse call
+func ParseGetRepoResponse(rsp *http.Response) (*GetRepoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRepoResponse parses an HTTP response from a PostRepoWithResponse call
+func ParsePostRepoResponse(rsp *http.Response) (*PostRepoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRepoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteRepoBatchResponse parses an HTTP response from a DeleteRepoBatchWithResponse call
+func ParseDeleteRepoBatchResponse(rsp *http.Response) (*DeleteRepoBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteRepoBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoCollectionsResponse parses an HTTP response from a GetRepoCollectionsWithResponse call
+func ParseGetRepoCollectionsResponse(rsp *http.Response) (*GetRepoCollectionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoCollectionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Collection
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteRepoCollectionResponse parses an HTTP response from a DeleteRepoCollectionWithResponse call
+func ParseDeleteRepoCollectionResponse(rsp *http.Response) (*DeleteRepoCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteRepoCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Collection
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutRepoCollectionResponse parses an HTTP response from a PutRepoCollectionWithResponse call
+func ParsePutRepoCollectionResponse(rsp *http.Response) (*PutRepoCollectionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutRepoCollectionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Collection
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRepoExistsBatchResponse parses an HTTP response from a PostRepoExistsBatchWithResponse call
+func ParsePostRepoExistsBatchResponse(rsp *http.Response) (*PostRepoExistsBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRepoExistsBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]bool
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoExportTarResponse parses an HTTP response from a GetRepoExportTarWithResponse call
+func ParseGetRepoExportTarResponse(rsp *http.Response) (*GetRepoExportTarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoExportTarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoExportZipResponse parses an HTTP response from a GetRepoExportZipWithResponse call
+func ParseGetRepoExportZipResponse(rsp *http.Response) (*GetRepoExportZipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoExportZipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoFacetsResponse parses an HTTP response from a GetRepoFacetsWithResponse call
+func ParseGetRepoFacetsResponse(rsp *http.Response) (*GetRepoFacetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoFacetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Facet
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoFeedJsonResponse parses an HTTP response from a GetRepoFeedJsonWithResponse call
+func ParseGetRepoFeedJsonResponse(rsp *http.Response) (*GetRepoFeedJsonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoFeedJsonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest JSONFeed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationfeedJSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoFeedRssResponse parses an HTTP response from a GetRepoFeedRssWithResponse call
+func ParseGetRepoFeedRssResponse(rsp *http.Response) (*GetRepoFeedRssResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoFeedRssResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRepoImportTarResponse parses an HTTP response from a PostRepoImportTarWithResponse call
+func ParsePostRepoImportTarResponse(rsp *http.Response) (*PostRepoImportTarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRepoImportTarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRepoImportZipResponse parses an HTTP response from a PostRepoImportZipWithResponse call
+func ParsePostRepoImportZipResponse(rsp *http.Response) (*PostRepoImportZipResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRepoImportZipResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 507:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON507 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoManifestResponse parses an HTTP response from a GetRepoManifestWithResponse call
+func ParseGetRepoManifestResponse(rsp *http.Response) (*GetRepoManifestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoManifestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoRandomResponse parses an HTTP response from a GetRepoRandomWithResponse call
+func ParseGetRepoRandomResponse(rsp *http.Response) (*GetRepoRandomResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoRandomResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRepoStatsResponse parses an HTTP response from a GetRepoStatsWithResponse call
+func ParseGetRepoStatsResponse(rsp *http.Response) (*GetRepoStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RepoInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+// ParsePostRepoUploadTokensResponse parses an HTTP response from a PostRepoUploadTokensWithResponse call
+func ParsePostRepoUploadTokensResponse(rsp *http.Response) (*PostRepoUploadTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
+	response := &PostRepoUploadTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
-		if params.XNeroKey != nil {
-			var headerParam0 string
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UploadToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Nero-Key", runtime.ParamLocationHeader, *params.XNeroKey)
-			if err != nil {
-				return nil, err
-			}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-			req.Header.Set("X-Nero-Key", headerParam0)
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
+		response.JSON401 = &dest
 
 	}
 
-	return req, nil
+	return response, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// ParseDeleteRepoIdResponse parses an HTTP response from a DeleteRepoIdWithResponse call
+func ParseDeleteRepoIdResponse(rsp *http.Response) (*DeleteRepoIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+
+	response := &DeleteRepoIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
+		response.JSON401 = &dest
+
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return response, nil
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// ParseGetRepoIdResponse parses an HTTP response from a GetRepoIdWithResponse call
+func ParseGetRepoIdResponse(rsp *http.Response) (*GetRepoIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	response := &GetRepoIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-}
-
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostRepoWithBodyWithResponse request with any body
-	PostRepoWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoResponse, error)
 
-	PostRepoWithResponse(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-	// DeleteRepoIdWithResponse request
-	DeleteRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdResponse, error)
-}
+	}
 
-type PostRepoResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Media
-	JSON400      *Error
-	JSON401      *Error
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PostRepoResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePatchRepoIdResponse parses an HTTP response from a PatchRepoIdWithResponse call
+func ParsePatchRepoIdResponse(rsp *http.Response) (*PatchRepoIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostRepoResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PatchRepoIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type DeleteRepoIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Media
-	JSON400      *Error
-	JSON401      *Error
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r DeleteRepoIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteRepoIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// PostRepoWithBodyWithResponse request with arbitrary body returning *PostRepoResponse
-func (c *ClientWithResponses) PostRepoWithBodyWithResponse(ctx context.Context, repo string, params *PostRepoParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRepoResponse, error) {
-	rsp, err := c.PostRepoWithBody(ctx, repo, params, contentType, body, reqEditors...)
+// ParseGetRepoIdLocationResponse parses an HTTP response from a GetRepoIdLocationWithResponse call
+func ParseGetRepoIdLocationResponse(rsp *http.Response) (*GetRepoIdLocationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostRepoResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostRepoWithResponse(ctx context.Context, repo string, params *PostRepoParams, body PostRepoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRepoResponse, error) {
-	rsp, err := c.PostRepo(ctx, repo, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetRepoIdLocationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostRepoResponse(rsp)
-}
 
-// DeleteRepoIdWithResponse request returning *DeleteRepoIdResponse
-func (c *ClientWithResponses) DeleteRepoIdWithResponse(ctx context.Context, repo string, id openapi_types.UUID, params *DeleteRepoIdParams, reqEditors ...RequestEditorFn) (*DeleteRepoIdResponse, error) {
-	rsp, err := c.DeleteRepoId(ctx, repo, id, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Location
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	}
-	return ParseDeleteRepoIdResponse(rsp)
+
+	return response, nil
 }
 
-// ParsePostRepoResponse parses an HTTP response from a PostRepoWithResponse call
-func ParsePostRepoResponse(rsp *http.Response) (*PostRepoResponse, error) {
+// ParseDeleteRepoIdPinResponse parses an HTTP response from a DeleteRepoIdPinWithResponse call
+func ParseDeleteRepoIdPinResponse(rsp *http.Response) (*DeleteRepoIdPinResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostRepoResponse{
+	response := &DeleteRepoIdPinResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -419,15 +4724,15 @@ func ParsePostRepoResponse(rsp *http.Response) (*PostRepoResponse, error) {
 	return response, nil
 }
 
-// ParseDeleteRepoIdResponse parses an HTTP response from a DeleteRepoIdWithResponse call
-func ParseDeleteRepoIdResponse(rsp *http.Response) (*DeleteRepoIdResponse, error) {
+// ParsePostRepoIdPinResponse parses an HTTP response from a PostRepoIdPinWithResponse call
+func ParsePostRepoIdPinResponse(rsp *http.Response) (*PostRepoIdPinResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteRepoIdResponse{
+	response := &PostRepoIdPinResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -458,3 +4763,29 @@ func ParseDeleteRepoIdResponse(rsp *http.Response) (*DeleteRepoIdResponse, error
 
 	return response, nil
 }
+
+// ParseGetRepoIdThumbnailResponse parses an HTTP response from a GetRepoIdThumbnailWithResponse call
+func ParseGetRepoIdThumbnailResponse(rsp *http.Response) (*GetRepoIdThumbnailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRepoIdThumbnailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
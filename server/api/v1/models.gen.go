@@ -6,17 +6,51 @@ package v1
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
+	"github.com/cephxdev/nero/server/api"
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for DerivedField.
+const (
+	Dimensions DerivedField = "dimensions"
+	Hash       DerivedField = "hash"
+	Mime       DerivedField = "mime"
+)
+
 // Defines values for ErrorType.
 const (
-	BadRequest    ErrorType = "bad_request"
-	InternalError ErrorType = "internal_error"
-	NotFound      ErrorType = "not_found"
-	Unauthorized  ErrorType = "unauthorized"
+	BadRequest          ErrorType = "bad_request"
+	InsufficientStorage ErrorType = "insufficient_storage"
+	InternalError       ErrorType = "internal_error"
+	InvalidMedia        ErrorType = "invalid_media"
+	NotFound            ErrorType = "not_found"
+	QuotaExceeded       ErrorType = "quota_exceeded"
+	TooManyRequests     ErrorType = "too_many_requests"
+	Unauthorized        ErrorType = "unauthorized"
+	Unavailable         ErrorType = "unavailable"
+)
+
+// Defines values for FacetField.
+const (
+	Artist FacetField = "artist"
+	Name   FacetField = "name"
+	Source FacetField = "source"
+)
+
+// Defines values for HealthStatus.
+const (
+	Degraded HealthStatus = "degraded"
+	Ok       HealthStatus = "ok"
+)
+
+// Defines values for ImportPolicy.
+const (
+	NewId     ImportPolicy = "new_id"
+	Overwrite ImportPolicy = "overwrite"
+	Skip      ImportPolicy = "skip"
 )
 
 // Defines values for MediaFormat.
@@ -24,6 +58,7 @@ const (
 	AnimatedImage MediaFormat = "animated_image"
 	Image         MediaFormat = "image"
 	Unknown       MediaFormat = "unknown"
+	Video         MediaFormat = "video"
 )
 
 // Defines values for MetadataType.
@@ -32,12 +67,71 @@ const (
 	Generic MetadataType = "generic"
 )
 
+// Defines values for RandomSpread.
+const (
+	Equal RandomSpread = "equal"
+	Size  RandomSpread = "size"
+)
+
+// Defines values for RandomWeight.
+const (
+	Recency RandomWeight = "recency"
+	Uniform RandomWeight = "uniform"
+)
+
+// Defines values for ResizeFit.
+const (
+	Contain ResizeFit = "contain"
+	Cover   ResizeFit = "cover"
+	Fill    ResizeFit = "fill"
+)
+
+// Defines values for SortOrder.
+const (
+	CreatedAsc  SortOrder = "created_asc"
+	CreatedDesc SortOrder = "created_desc"
+	Popular     SortOrder = "popular"
+)
+
 // AnimeMetadata defines model for AnimeMetadata.
 type AnimeMetadata struct {
 	Name *string      `json:"name"`
 	Type MetadataType `json:"type"`
 }
 
+// Collection defines model for Collection.
+type Collection struct {
+	Id string `json:"id"`
+
+	// Meta The metadata inherited by the members of the collection, fields set by members take precedence.
+	Meta *Collection_Meta `json:"meta"`
+
+	// Name The display name of the collection, absent if there is none.
+	Name *string `json:"name,omitempty"`
+}
+
+// Collection_Meta The metadata inherited by the members of the collection, fields set by members take precedence.
+type Collection_Meta struct {
+	union json.RawMessage
+}
+
+// CollectionUpdate defines model for CollectionUpdate.
+type CollectionUpdate struct {
+	// Meta The metadata inherited by the members of the collection.
+	Meta *CollectionUpdate_Meta `json:"meta"`
+
+	// Name The display name of the collection.
+	Name *string `json:"name,omitempty"`
+}
+
+// CollectionUpdate_Meta The metadata inherited by the members of the collection.
+type CollectionUpdate_Meta struct {
+	union json.RawMessage
+}
+
+// DerivedField defines model for DerivedField.
+type DerivedField string
+
 // Error defines model for Error.
 type Error struct {
 	// Description The error description.
@@ -48,6 +142,15 @@ type Error struct {
 // ErrorType defines model for ErrorType.
 type ErrorType string
 
+// Facet defines model for Facet.
+type Facet struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// FacetField defines model for FacetField.
+type FacetField string
+
 // GenericMetadata defines model for GenericMetadata.
 type GenericMetadata struct {
 	Artist     *string      `json:"artist"`
@@ -56,16 +159,149 @@ type GenericMetadata struct {
 	Type       MetadataType `json:"type"`
 }
 
+// Health defines model for Health.
+type Health struct {
+	Repos  []RepoHealth `json:"repos"`
+	Status HealthStatus `json:"status"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
+
+// ImportPolicy defines model for ImportPolicy.
+type ImportPolicy string
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	// Imported The amount of imported media.
+	Imported int `json:"imported"`
+
+	// Skipped The amount of skipped archive files.
+	Skipped int `json:"skipped"`
+}
+
+// JSONFeed defines model for JSONFeed.
+type JSONFeed struct {
+	FeedUrl     *string        `json:"feed_url,omitempty"`
+	HomePageUrl *string        `json:"home_page_url,omitempty"`
+	Items       []JSONFeedItem `json:"items"`
+	Title       string         `json:"title"`
+	Version     string         `json:"version"`
+}
+
+// JSONFeedAttachment defines model for JSONFeedAttachment.
+type JSONFeedAttachment struct {
+	MimeType    string `json:"mime_type"`
+	SizeInBytes *int64 `json:"size_in_bytes,omitempty"`
+	Url         string `json:"url"`
+}
+
+// JSONFeedItem defines model for JSONFeedItem.
+type JSONFeedItem struct {
+	Attachments   *[]JSONFeedAttachment `json:"attachments,omitempty"`
+	ContentText   *string               `json:"content_text,omitempty"`
+	DatePublished *time.Time            `json:"date_published,omitempty"`
+	Id            string                `json:"id"`
+	Title         *string               `json:"title,omitempty"`
+	Url           *string               `json:"url,omitempty"`
+}
+
+// LoadSummary The amounts of index items skipped while loading the repository, by reason.
+type LoadSummary struct {
+	// Collisions Items sharing the path of a previous item.
+	Collisions int `json:"collisions"`
+
+	// Duplicates Items sharing the ID of a previous item.
+	Duplicates int `json:"duplicates"`
+
+	// Missing Items without a file.
+	Missing int `json:"missing"`
+
+	// Symlinks Symbolically linked items, if they're rejected.
+	Symlinks int `json:"symlinks"`
+
+	// Unreadable Items that couldn't be parsed.
+	Unreadable int `json:"unreadable"`
+}
+
+// Location defines model for Location.
+type Location struct {
+	// Original The absolute path of the original file of transcoded media, absent if there is none.
+	Original *string `json:"original,omitempty"`
+
+	// Path The absolute path of the media file.
+	Path string `json:"path"`
+
+	// Size The size of the media file in bytes.
+	Size int64 `json:"size"`
+}
+
+// ManifestEntry defines model for ManifestEntry.
+type ManifestEntry struct {
+	Format MediaFormat `json:"format"`
+
+	// Hash The hex-encoded SHA-256 hash of the media content, absent if unknown.
+	Hash *string            `json:"hash,omitempty"`
+	Id   openapi_types.UUID `json:"id"`
+
+	// Size The size of the media content in bytes.
+	Size int64 `json:"size"`
+}
+
 // Media defines model for Media.
 type Media struct {
-	Format MediaFormat        `json:"format"`
+	// Batch The ID of the import batch the media was uploaded in, absent if there is none.
+	Batch *string `json:"batch,omitempty"`
+
+	// Collection The ID of the collection the media inherits metadata from, absent if there is none.
+	Collection *string `json:"collection,omitempty"`
+
+	// CreatedAt The time of the media's creation, absent if unknown.
+	// Serialized as Unix epoch seconds instead of RFC 3339 if requested with the `timestamps=unix` query parameter
+	// or an `Accept: application/json; timestamps=unix` header.
+	CreatedAt *api.Timestamp `json:"created_at,omitempty"`
+
+	// Downloads The amount of times the media was served, absent if counting is disabled or zero.
+	Downloads *int64 `json:"downloads,omitempty"`
+
+	// ExpiresAt The time the media expires and is removed at, absent if it's kept forever.
+	// Serialized like `created_at`.
+	ExpiresAt *api.Timestamp `json:"expires_at,omitempty"`
+	Format    MediaFormat    `json:"format"`
+
+	// Hash The hex-encoded SHA-256 hash of the media content, absent if unknown.
+	Hash *string `json:"hash,omitempty"`
+
+	// Height The height of the media in pixels, absent if unknown.
+	Height *int               `json:"height,omitempty"`
 	Id     openapi_types.UUID `json:"id"`
 
-	// Meta The media metadata.
-	Meta *Media_Meta `json:"meta"`
+	// LastAccessed The time the media content was last served, absent if unknown or if access tracking is disabled.
+	// Serialized like `created_at`.
+	LastAccessed *api.Timestamp `json:"last_accessed,omitempty"`
+
+	// Meta The media metadata, null if there is none.
+	// Absent if omitted with the `meta=omit` query parameter, which speeds up large listings.
+	Meta *Media_Meta `json:"meta,omitempty"`
+
+	// Pinned Whether the media is pinned.
+	Pinned *bool `json:"pinned,omitempty"`
+
+	// Repo The ID of the repository containing the media.
+	Repo *string `json:"repo,omitempty"`
+
+	// Size The size of the media content in bytes.
+	Size *int64 `json:"size,omitempty"`
+
+	// Url The absolute URL of the media content.
+	Url *string `json:"url,omitempty"`
+
+	// Width The width of the media in pixels, absent if unknown.
+	Width *int `json:"width,omitempty"`
 }
 
-// Media_Meta The media metadata.
+// Media_Meta The media metadata, null if there is none.
+// Absent if omitted with the `meta=omit` query parameter, which speeds up large listings.
 type Media_Meta struct {
 	union json.RawMessage
 }
@@ -73,6 +309,23 @@ type Media_Meta struct {
 // MediaFormat defines model for MediaFormat.
 type MediaFormat string
 
+// MediaUpdate defines model for MediaUpdate.
+type MediaUpdate struct {
+	// Collection The ID of an existing collection to move the media to, an empty string removes it from its collection.
+	Collection *string `json:"collection,omitempty"`
+
+	// ContentType The content type the media is served with, an empty string restores detection from the content.
+	ContentType *string `json:"content_type,omitempty"`
+
+	// Meta The new metadata, left unchanged if absent.
+	Meta *MediaUpdate_Meta `json:"meta,omitempty"`
+}
+
+// MediaUpdate_Meta The new metadata, left unchanged if absent.
+type MediaUpdate_Meta struct {
+	union json.RawMessage
+}
+
 // Metadata defines model for Metadata.
 type Metadata struct {
 	Type MetadataType `json:"type"`
@@ -83,8 +336,24 @@ type MetadataType string
 
 // ProtoMedia defines model for ProtoMedia.
 type ProtoMedia struct {
-	Data string           `json:"data"`
-	Meta *ProtoMedia_Meta `json:"meta"`
+	// Batch The ID of the import batch the media is uploaded in, for listing or removing the whole batch later.
+	Batch *string `json:"batch,omitempty"`
+
+	// Collection The ID of an existing collection to add the media to.
+	Collection *string `json:"collection,omitempty"`
+	Data       string  `json:"data"`
+
+	// ExpiresAt The time the media expires and is removed at, overriding the repository default.
+	// Mutually exclusive with `expires_in`.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ExpiresIn The time in seconds until the media expires and is removed, overriding the repository default.
+	// Zero keeps the media forever. Mutually exclusive with `expires_at`.
+	ExpiresIn *int64 `json:"expires_in,omitempty"`
+
+	// Filename The original name of the uploaded file, used for its extension if the repository allows it.
+	Filename *string          `json:"filename,omitempty"`
+	Meta     *ProtoMedia_Meta `json:"meta"`
 }
 
 // ProtoMedia_Meta defines model for ProtoMedia.Meta.
@@ -92,8 +361,250 @@ type ProtoMedia_Meta struct {
 	union json.RawMessage
 }
 
+// RandomSpread The spread of random picks across repositories, either proportional to their size,
+// i.e. uniform across all their media, or equal, i.e. each pick from a uniformly chosen repository.
+type RandomSpread string
+
+// RandomWeight defines model for RandomWeight.
+type RandomWeight string
+
+// RepoCapabilities defines model for RepoCapabilities.
+type RepoCapabilities struct {
+	// MetaTypes The metadata types accepted in uploads and updates.
+	MetaTypes []MetadataType `json:"meta_types"`
+
+	// Writable Whether the repository accepts uploads.
+	Writable bool `json:"writable"`
+}
+
+// RepoHealth defines model for RepoHealth.
+type RepoHealth struct {
+	Id string `json:"id"`
+
+	// Load The amounts of index items skipped while loading the repository, by reason.
+	Load   *LoadSummary `json:"load,omitempty"`
+	Status HealthStatus `json:"status"`
+	Usage  *Usage       `json:"usage,omitempty"`
+}
+
+// RepoInfo defines model for RepoInfo.
+type RepoInfo struct {
+	Capabilities RepoCapabilities `json:"capabilities"`
+	Id           string           `json:"id"`
+	Usage        Usage            `json:"usage"`
+}
+
+// ResizeFit defines model for ResizeFit.
+type ResizeFit string
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
+
+// UploadToken defines model for UploadToken.
+type UploadToken struct {
+	// ExpiresAt The time the token expires at.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Token The upload token, passed in the token query parameter of an upload.
+	Token string `json:"token"`
+
+	// Url The upload URL with the token, if it can be determined.
+	Url *string `json:"url,omitempty"`
+}
+
+// Usage defines model for Usage.
+type Usage struct {
+	// Bytes The total size of the media content in bytes.
+	Bytes int64 `json:"bytes"`
+
+	// Capacity The configured quota in bytes, null if there is none.
+	Capacity *int64 `json:"capacity"`
+
+	// Items The amount of media in the repository.
+	Items int `json:"items"`
+
+	// SoftCapacity The configured soft quota in bytes, null if there is none.
+	SoftCapacity *int64 `json:"soft_capacity"`
+
+	// SoftItemLimit The configured soft item limit, null if there is none.
+	SoftItemLimit *int `json:"soft_item_limit"`
+
+	// Warning Whether the soft quota or item limit is reached, informational only.
+	Warning bool `json:"warning"`
+}
+
+// GetRandomParams defines parameters for GetRandom.
+type GetRandomParams struct {
+	// Repos IDs of the repositories to pick from, defaults to all repositories.
+	Repos *[]string `form:"repos,omitempty" json:"repos,omitempty"`
+
+	// Count The amount of media to pick, defaults to 1.
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+
+	// Spread How picks are spread across the repositories, defaults to size.
+	Spread *RandomSpread `form:"spread,omitempty" json:"spread,omitempty"`
+}
+
+// GetReposParams defines parameters for GetRepos.
+type GetReposParams struct {
+	// Writable Only lists repositories that accept uploads if true, or ones that don't if false.
+	Writable *bool `form:"writable,omitempty" json:"writable,omitempty"`
+
+	// MetaType Only lists repositories accepting uploads with metadata of this type.
+	MetaType *MetadataType `form:"metaType,omitempty" json:"metaType,omitempty"`
+}
+
+// GetRepoParams defines parameters for GetRepo.
+type GetRepoParams struct {
+	// CreatedAfter Only lists media created after this time.
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Only lists media created before this time.
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+	Offset        *int       `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit         *int       `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Pinned Only lists pinned or unpinned media.
+	Pinned *bool `form:"pinned,omitempty" json:"pinned,omitempty"`
+
+	// IncludeUnknown Whether media of an unknown format is included, defaults to the repository setting.
+	IncludeUnknown *bool `form:"includeUnknown,omitempty" json:"includeUnknown,omitempty"`
+
+	// Sort The sort order, defaults to created_asc.
+	Sort *SortOrder `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Collection Only lists media in the collection.
+	Collection *string `form:"collection,omitempty" json:"collection,omitempty"`
+
+	// Batch Only lists media uploaded in the import batch.
+	Batch *string `form:"batch,omitempty" json:"batch,omitempty"`
+
+	// HashPrefix Only lists media whose hex-encoded SHA-256 content hash starts with this prefix, like a short git hash. Ambiguous prefixes list all matching media.
+	HashPrefix *string `form:"hashPrefix,omitempty" json:"hashPrefix,omitempty"`
+
+	// Missing Only lists media lacking this data derived from its content, e.g. to track backfill progress.
+	Missing *DerivedField `form:"missing,omitempty" json:"missing,omitempty"`
+
+	// Artist Only lists media by this artist, including metadata inherited from collections.
+	Artist *string `form:"artist,omitempty" json:"artist,omitempty"`
+
+	// Source Only lists media from this source, including metadata inherited from collections.
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+
+	// Name Only lists media of this anime, including metadata inherited from collections.
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+}
+
 // PostRepoParams defines parameters for PostRepo.
 type PostRepoParams struct {
+	// Token A pre-signed upload token authorizing a single upload instead of X-Nero-Key.
+	Token    *string `form:"token,omitempty" json:"token,omitempty"`
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+
+	// XContentSHA256 The hex-encoded SHA-256 digest of the decoded data, verified if present.
+	XContentSHA256 *string `json:"X-Content-SHA256,omitempty"`
+}
+
+// DeleteRepoBatchParams defines parameters for DeleteRepoBatch.
+type DeleteRepoBatchParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// DeleteRepoCollectionParams defines parameters for DeleteRepoCollection.
+type DeleteRepoCollectionParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// PutRepoCollectionParams defines parameters for PutRepoCollection.
+type PutRepoCollectionParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// PostRepoExistsBatchJSONBody defines parameters for PostRepoExistsBatch.
+type PostRepoExistsBatchJSONBody = []openapi_types.UUID
+
+// GetRepoExportTarParams defines parameters for GetRepoExportTar.
+type GetRepoExportTarParams struct {
+	// CreatedAfter Only exports media created after this time.
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Only exports media created before this time.
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+	Offset        *int       `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit         *int       `form:"limit,omitempty" json:"limit,omitempty"`
+	XNeroKey      *string    `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoExportZipParams defines parameters for GetRepoExportZip.
+type GetRepoExportZipParams struct {
+	// CreatedAfter Only exports media created after this time.
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Only exports media created before this time.
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+	Offset        *int       `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit         *int       `form:"limit,omitempty" json:"limit,omitempty"`
+	XNeroKey      *string    `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoFacetsParams defines parameters for GetRepoFacets.
+type GetRepoFacetsParams struct {
+	Field FacetField `form:"field" json:"field"`
+
+	// Limit The maximum amount of values to return, defaults to and is capped at 1000.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRepoFeedJsonParams defines parameters for GetRepoFeedJson.
+type GetRepoFeedJsonParams struct {
+	// Limit The amount of the most recent media listed, defaults to 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRepoFeedRssParams defines parameters for GetRepoFeedRss.
+type GetRepoFeedRssParams struct {
+	// Limit The amount of the most recent media listed, defaults to 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostRepoImportTarParams defines parameters for PostRepoImportTar.
+type PostRepoImportTarParams struct {
+	// Collision The handling of archive items whose ID is already in the repository, defaults to skip.
+	Collision *ImportPolicy `form:"collision,omitempty" json:"collision,omitempty"`
+	XNeroKey  *string       `json:"X-Nero-Key,omitempty"`
+}
+
+// PostRepoImportZipParams defines parameters for PostRepoImportZip.
+type PostRepoImportZipParams struct {
+	// Collision The handling of archive items whose ID is already in the repository, defaults to skip.
+	Collision *ImportPolicy `form:"collision,omitempty" json:"collision,omitempty"`
+	XNeroKey  *string       `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoManifestParams defines parameters for GetRepoManifest.
+type GetRepoManifestParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoRandomParams defines parameters for GetRepoRandom.
+type GetRepoRandomParams struct {
+	// Count The amount of media to pick, defaults to 1.
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+
+	// Weight The selection weighting, defaults to uniform.
+	Weight *RandomWeight `form:"weight,omitempty" json:"weight,omitempty"`
+
+	// Exclude IDs of media to avoid, picked only if there isn't enough other media.
+	Exclude *[]openapi_types.UUID `form:"exclude,omitempty" json:"exclude,omitempty"`
+
+	// IncludeUnknown Whether media of an unknown format is included, defaults to the repository setting.
+	IncludeUnknown *bool `form:"includeUnknown,omitempty" json:"includeUnknown,omitempty"`
+}
+
+// PostRepoUploadTokensParams defines parameters for PostRepoUploadTokens.
+type PostRepoUploadTokensParams struct {
+	// Ttl The lifetime of the token in seconds, defaults to 300, at most 3600.
+	Ttl      *int    `form:"ttl,omitempty" json:"ttl,omitempty"`
 	XNeroKey *string `json:"X-Nero-Key,omitempty"`
 }
 
@@ -102,9 +613,237 @@ type DeleteRepoIdParams struct {
 	XNeroKey *string `json:"X-Nero-Key,omitempty"`
 }
 
+// GetRepoIdParams defines parameters for GetRepoId.
+type GetRepoIdParams struct {
+	// W The width to resize still images to in pixels, derived from the height keeping the aspect ratio if omitted.
+	W *int `form:"w,omitempty" json:"w,omitempty"`
+
+	// H The height to resize still images to in pixels, derived from the width keeping the aspect ratio if omitted.
+	H *int `form:"h,omitempty" json:"h,omitempty"`
+
+	// Fit The way the resized image fits into the width and height, defaults to contain.
+	Fit *ResizeFit `form:"fit,omitempty" json:"fit,omitempty"`
+
+	// Sort The sort order of the sequence the media is served in, its collection or the whole repository, for prefetching the media following it. Defaults to the repository default order.
+	Sort *SortOrder `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// PatchRepoIdParams defines parameters for PatchRepoId.
+type PatchRepoIdParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoIdLocationParams defines parameters for GetRepoIdLocation.
+type GetRepoIdLocationParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// DeleteRepoIdPinParams defines parameters for DeleteRepoIdPin.
+type DeleteRepoIdPinParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// PostRepoIdPinParams defines parameters for PostRepoIdPin.
+type PostRepoIdPinParams struct {
+	XNeroKey *string `json:"X-Nero-Key,omitempty"`
+}
+
+// GetRepoIdThumbnailParams defines parameters for GetRepoIdThumbnail.
+type GetRepoIdThumbnailParams struct {
+	// Size The maximum width and height of the thumbnail in pixels, defaults to 256.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
 // PostRepoJSONRequestBody defines body for PostRepo for application/json ContentType.
 type PostRepoJSONRequestBody = ProtoMedia
 
+// PutRepoCollectionJSONRequestBody defines body for PutRepoCollection for application/json ContentType.
+type PutRepoCollectionJSONRequestBody = CollectionUpdate
+
+// PostRepoExistsBatchJSONRequestBody defines body for PostRepoExistsBatch for application/json ContentType.
+type PostRepoExistsBatchJSONRequestBody = PostRepoExistsBatchJSONBody
+
+// PatchRepoIdJSONRequestBody defines body for PatchRepoId for application/json ContentType.
+type PatchRepoIdJSONRequestBody = MediaUpdate
+
+// AsGenericMetadata returns the union data inside the Collection_Meta as a GenericMetadata
+func (t Collection_Meta) AsGenericMetadata() (GenericMetadata, error) {
+	var body GenericMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGenericMetadata overwrites any union data inside the Collection_Meta as the provided GenericMetadata
+func (t *Collection_Meta) FromGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGenericMetadata performs a merge with any union data inside the Collection_Meta, using the provided GenericMetadata
+func (t *Collection_Meta) MergeGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsAnimeMetadata returns the union data inside the Collection_Meta as a AnimeMetadata
+func (t Collection_Meta) AsAnimeMetadata() (AnimeMetadata, error) {
+	var body AnimeMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAnimeMetadata overwrites any union data inside the Collection_Meta as the provided AnimeMetadata
+func (t *Collection_Meta) FromAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAnimeMetadata performs a merge with any union data inside the Collection_Meta, using the provided AnimeMetadata
+func (t *Collection_Meta) MergeAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Collection_Meta) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t Collection_Meta) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "anime":
+		return t.AsAnimeMetadata()
+	case "generic":
+		return t.AsGenericMetadata()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t Collection_Meta) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Collection_Meta) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsGenericMetadata returns the union data inside the CollectionUpdate_Meta as a GenericMetadata
+func (t CollectionUpdate_Meta) AsGenericMetadata() (GenericMetadata, error) {
+	var body GenericMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGenericMetadata overwrites any union data inside the CollectionUpdate_Meta as the provided GenericMetadata
+func (t *CollectionUpdate_Meta) FromGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGenericMetadata performs a merge with any union data inside the CollectionUpdate_Meta, using the provided GenericMetadata
+func (t *CollectionUpdate_Meta) MergeGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsAnimeMetadata returns the union data inside the CollectionUpdate_Meta as a AnimeMetadata
+func (t CollectionUpdate_Meta) AsAnimeMetadata() (AnimeMetadata, error) {
+	var body AnimeMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAnimeMetadata overwrites any union data inside the CollectionUpdate_Meta as the provided AnimeMetadata
+func (t *CollectionUpdate_Meta) FromAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAnimeMetadata performs a merge with any union data inside the CollectionUpdate_Meta, using the provided AnimeMetadata
+func (t *CollectionUpdate_Meta) MergeAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t CollectionUpdate_Meta) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t CollectionUpdate_Meta) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "anime":
+		return t.AsAnimeMetadata()
+	case "generic":
+		return t.AsGenericMetadata()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t CollectionUpdate_Meta) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *CollectionUpdate_Meta) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
 // AsGenericMetadata returns the union data inside the Media_Meta as a GenericMetadata
 func (t Media_Meta) AsGenericMetadata() (GenericMetadata, error) {
 	var body GenericMetadata
@@ -194,6 +933,95 @@ func (t *Media_Meta) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// AsGenericMetadata returns the union data inside the MediaUpdate_Meta as a GenericMetadata
+func (t MediaUpdate_Meta) AsGenericMetadata() (GenericMetadata, error) {
+	var body GenericMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGenericMetadata overwrites any union data inside the MediaUpdate_Meta as the provided GenericMetadata
+func (t *MediaUpdate_Meta) FromGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGenericMetadata performs a merge with any union data inside the MediaUpdate_Meta, using the provided GenericMetadata
+func (t *MediaUpdate_Meta) MergeGenericMetadata(v GenericMetadata) error {
+	v.Type = "generic"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsAnimeMetadata returns the union data inside the MediaUpdate_Meta as a AnimeMetadata
+func (t MediaUpdate_Meta) AsAnimeMetadata() (AnimeMetadata, error) {
+	var body AnimeMetadata
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromAnimeMetadata overwrites any union data inside the MediaUpdate_Meta as the provided AnimeMetadata
+func (t *MediaUpdate_Meta) FromAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeAnimeMetadata performs a merge with any union data inside the MediaUpdate_Meta, using the provided AnimeMetadata
+func (t *MediaUpdate_Meta) MergeAnimeMetadata(v AnimeMetadata) error {
+	v.Type = "anime"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t MediaUpdate_Meta) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t MediaUpdate_Meta) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "anime":
+		return t.AsAnimeMetadata()
+	case "generic":
+		return t.AsGenericMetadata()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t MediaUpdate_Meta) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *MediaUpdate_Meta) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
 // AsGenericMetadata returns the union data inside the ProtoMedia_Meta as a GenericMetadata
 func (t ProtoMedia_Meta) AsGenericMetadata() (GenericMetadata, error) {
 	var body GenericMetadata
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -18,27 +19,235 @@ import (
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /healthz)
+	GetHealthz(w http.ResponseWriter, r *http.Request)
+
+	// (GET /random)
+	GetRandom(w http.ResponseWriter, r *http.Request, params GetRandomParams)
+
+	// (GET /repos)
+	GetRepos(w http.ResponseWriter, r *http.Request, params GetReposParams)
+
+	// (GET /repos/{repo})
+	GetRepo(w http.ResponseWriter, r *http.Request, repo string, params GetRepoParams)
+
 	// (POST /repos/{repo})
 	PostRepo(w http.ResponseWriter, r *http.Request, repo string, params PostRepoParams)
 
+	// (DELETE /repos/{repo}/batches/{batch})
+	DeleteRepoBatch(w http.ResponseWriter, r *http.Request, repo string, batch string, params DeleteRepoBatchParams)
+
+	// (GET /repos/{repo}/collections)
+	GetRepoCollections(w http.ResponseWriter, r *http.Request, repo string)
+
+	// (DELETE /repos/{repo}/collections/{collection})
+	DeleteRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params DeleteRepoCollectionParams)
+
+	// (PUT /repos/{repo}/collections/{collection})
+	PutRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params PutRepoCollectionParams)
+
+	// (POST /repos/{repo}/exists-batch)
+	PostRepoExistsBatch(w http.ResponseWriter, r *http.Request, repo string)
+
+	// (GET /repos/{repo}/export.tar)
+	GetRepoExportTar(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportTarParams)
+
+	// (GET /repos/{repo}/export.zip)
+	GetRepoExportZip(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportZipParams)
+
+	// (GET /repos/{repo}/facets)
+	GetRepoFacets(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFacetsParams)
+
+	// (GET /repos/{repo}/feed.json)
+	GetRepoFeedJson(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedJsonParams)
+
+	// (GET /repos/{repo}/feed.rss)
+	GetRepoFeedRss(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedRssParams)
+
+	// (POST /repos/{repo}/import.tar)
+	PostRepoImportTar(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportTarParams)
+
+	// (POST /repos/{repo}/import.zip)
+	PostRepoImportZip(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportZipParams)
+
+	// (GET /repos/{repo}/manifest)
+	GetRepoManifest(w http.ResponseWriter, r *http.Request, repo string, params GetRepoManifestParams)
+
+	// (GET /repos/{repo}/random)
+	GetRepoRandom(w http.ResponseWriter, r *http.Request, repo string, params GetRepoRandomParams)
+
+	// (GET /repos/{repo}/stats)
+	GetRepoStats(w http.ResponseWriter, r *http.Request, repo string)
+
+	// (POST /repos/{repo}/upload-tokens)
+	PostRepoUploadTokens(w http.ResponseWriter, r *http.Request, repo string, params PostRepoUploadTokensParams)
+
 	// (DELETE /repos/{repo}/{id})
 	DeleteRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdParams)
+
+	// (GET /repos/{repo}/{id})
+	GetRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdParams)
+
+	// (PATCH /repos/{repo}/{id})
+	PatchRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PatchRepoIdParams)
+
+	// (GET /repos/{repo}/{id}/location)
+	GetRepoIdLocation(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdLocationParams)
+
+	// (DELETE /repos/{repo}/{id}/pin)
+	DeleteRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdPinParams)
+
+	// (POST /repos/{repo}/{id}/pin)
+	PostRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PostRepoIdPinParams)
+
+	// (GET /repos/{repo}/{id}/thumbnail)
+	GetRepoIdThumbnail(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdThumbnailParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// (GET /healthz)
+func (_ Unimplemented) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /random)
+func (_ Unimplemented) GetRandom(w http.ResponseWriter, r *http.Request, params GetRandomParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos)
+func (_ Unimplemented) GetRepos(w http.ResponseWriter, r *http.Request, params GetReposParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo})
+func (_ Unimplemented) GetRepo(w http.ResponseWriter, r *http.Request, repo string, params GetRepoParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (POST /repos/{repo})
 func (_ Unimplemented) PostRepo(w http.ResponseWriter, r *http.Request, repo string, params PostRepoParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (DELETE /repos/{repo}/batches/{batch})
+func (_ Unimplemented) DeleteRepoBatch(w http.ResponseWriter, r *http.Request, repo string, batch string, params DeleteRepoBatchParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/collections)
+func (_ Unimplemented) GetRepoCollections(w http.ResponseWriter, r *http.Request, repo string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /repos/{repo}/collections/{collection})
+func (_ Unimplemented) DeleteRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params DeleteRepoCollectionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /repos/{repo}/collections/{collection})
+func (_ Unimplemented) PutRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params PutRepoCollectionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /repos/{repo}/exists-batch)
+func (_ Unimplemented) PostRepoExistsBatch(w http.ResponseWriter, r *http.Request, repo string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/export.tar)
+func (_ Unimplemented) GetRepoExportTar(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportTarParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/export.zip)
+func (_ Unimplemented) GetRepoExportZip(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportZipParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/facets)
+func (_ Unimplemented) GetRepoFacets(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFacetsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/feed.json)
+func (_ Unimplemented) GetRepoFeedJson(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedJsonParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/feed.rss)
+func (_ Unimplemented) GetRepoFeedRss(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedRssParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /repos/{repo}/import.tar)
+func (_ Unimplemented) PostRepoImportTar(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportTarParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /repos/{repo}/import.zip)
+func (_ Unimplemented) PostRepoImportZip(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportZipParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/manifest)
+func (_ Unimplemented) GetRepoManifest(w http.ResponseWriter, r *http.Request, repo string, params GetRepoManifestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/random)
+func (_ Unimplemented) GetRepoRandom(w http.ResponseWriter, r *http.Request, repo string, params GetRepoRandomParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/stats)
+func (_ Unimplemented) GetRepoStats(w http.ResponseWriter, r *http.Request, repo string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /repos/{repo}/upload-tokens)
+func (_ Unimplemented) PostRepoUploadTokens(w http.ResponseWriter, r *http.Request, repo string, params PostRepoUploadTokensParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /repos/{repo}/{id})
 func (_ Unimplemented) DeleteRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /repos/{repo}/{id})
+func (_ Unimplemented) GetRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PATCH /repos/{repo}/{id})
+func (_ Unimplemented) PatchRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PatchRepoIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/{id}/location)
+func (_ Unimplemented) GetRepoIdLocation(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdLocationParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /repos/{repo}/{id}/pin)
+func (_ Unimplemented) DeleteRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdPinParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /repos/{repo}/{id}/pin)
+func (_ Unimplemented) PostRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PostRepoIdPinParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /repos/{repo}/{id}/thumbnail)
+func (_ Unimplemented) GetRepoIdThumbnail(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdThumbnailParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -48,47 +257,92 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// PostRepo operation middleware
-func (siw *ServerInterfaceWrapper) PostRepo(w http.ResponseWriter, r *http.Request) {
+// GetHealthz operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRandom operation middleware
+func (siw *ServerInterfaceWrapper) GetRandom(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "repo" -------------
-	var repo string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRandomParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "repos" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "repos", r.URL.Query(), &params.Repos)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repos", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostRepoParams
+	// ------------- Optional query parameter "count" -------------
 
-	headers := r.Header
+	err = runtime.BindQueryParameter("form", true, false, "count", r.URL.Query(), &params.Count)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		return
+	}
 
-	// ------------- Optional header parameter "X-Nero-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
-		var XNeroKey string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
-			return
-		}
+	// ------------- Optional query parameter "spread" -------------
 
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "spread", r.URL.Query(), &params.Spread)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "spread", Err: err})
+		return
+	}
 
-		params.XNeroKey = &XNeroKey
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRandom(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepos operation middleware
+func (siw *ServerInterfaceWrapper) GetRepos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReposParams
+
+	// ------------- Optional query parameter "writable" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "writable", r.URL.Query(), &params.Writable)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "writable", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "metaType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "metaType", r.URL.Query(), &params.MetaType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "metaType", Err: err})
+		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostRepo(w, r, repo, params)
+		siw.Handler.GetRepos(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -98,8 +352,8 @@ func (siw *ServerInterfaceWrapper) PostRepo(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteRepoId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteRepoId(w http.ResponseWriter, r *http.Request) {
+// GetRepo operation middleware
+func (siw *ServerInterfaceWrapper) GetRepo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -113,312 +367,3591 @@ func (siw *ServerInterfaceWrapper) DeleteRepoId(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteRepoIdParams
+	// ------------- Optional query parameter "createdBefore" -------------
 
-	headers := r.Header
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
 
-	// ------------- Optional header parameter "X-Nero-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
-		var XNeroKey string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
-			return
-		}
+	// ------------- Optional query parameter "offset" -------------
 
-		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
 
-		params.XNeroKey = &XNeroKey
+	// ------------- Optional query parameter "limit" -------------
 
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteRepoId(w, r, repo, id, params)
-	}))
+	// ------------- Optional query parameter "pinned" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "pinned", r.URL.Query(), &params.Pinned)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pinned", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
-
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "includeUnknown" -------------
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "includeUnknown", r.URL.Query(), &params.IncludeUnknown)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeUnknown", Err: err})
+		return
+	}
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "sort" -------------
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "collection" -------------
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "collection", r.URL.Query(), &params.Collection)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "collection", Err: err})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	// ------------- Optional query parameter "batch" -------------
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "batch", r.URL.Query(), &params.Batch)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "batch", Err: err})
+		return
+	}
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "hashPrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hashPrefix", r.URL.Query(), &params.HashPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hashPrefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "missing" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "missing", r.URL.Query(), &params.Missing)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "missing", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "artist" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artist", r.URL.Query(), &params.Artist)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "artist", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepo(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepo operation middleware
+func (siw *ServerInterfaceWrapper) PostRepo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRepoParams
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	// ------------- Optional header parameter "X-Content-SHA256" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Content-SHA256")]; found {
+		var XContentSHA256 string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Content-SHA256", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Content-SHA256", valueList[0], &XContentSHA256, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Content-SHA256", Err: err})
+			return
+		}
+
+		params.XContentSHA256 = &XContentSHA256
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepo(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepoBatch operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepoBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "batch" -------------
+	var batch string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "batch", chi.URLParam(r, "batch"), &batch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "batch", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRepoBatchParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepoBatch(w, r, repo, batch, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoCollections operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoCollections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoCollections(w, r, repo)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepoCollection operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepoCollection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "collection" -------------
+	var collection string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "collection", chi.URLParam(r, "collection"), &collection, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "collection", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRepoCollectionParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepoCollection(w, r, repo, collection, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutRepoCollection operation middleware
+func (siw *ServerInterfaceWrapper) PutRepoCollection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "collection" -------------
+	var collection string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "collection", chi.URLParam(r, "collection"), &collection, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "collection", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutRepoCollectionParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutRepoCollection(w, r, repo, collection, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepoExistsBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostRepoExistsBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepoExistsBatch(w, r, repo)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoExportTar operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoExportTar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoExportTarParams
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoExportTar(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoExportZip operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoExportZip(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoExportZipParams
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoExportZip(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoFacets operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoFacets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoFacetsParams
+
+	// ------------- Required query parameter "field" -------------
+
+	if paramValue := r.URL.Query().Get("field"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "field"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "field", r.URL.Query(), &params.Field)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "field", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoFacets(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoFeedJson operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoFeedJson(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoFeedJsonParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoFeedJson(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoFeedRss operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoFeedRss(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoFeedRssParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoFeedRss(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepoImportTar operation middleware
+func (siw *ServerInterfaceWrapper) PostRepoImportTar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRepoImportTarParams
+
+	// ------------- Optional query parameter "collision" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "collision", r.URL.Query(), &params.Collision)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "collision", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepoImportTar(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepoImportZip operation middleware
+func (siw *ServerInterfaceWrapper) PostRepoImportZip(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRepoImportZipParams
+
+	// ------------- Optional query parameter "collision" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "collision", r.URL.Query(), &params.Collision)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "collision", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepoImportZip(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoManifest operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoManifestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoManifest(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoRandom operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoRandom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoRandomParams
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "count", r.URL.Query(), &params.Count)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "weight" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "weight", r.URL.Query(), &params.Weight)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "weight", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "exclude" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "exclude", r.URL.Query(), &params.Exclude)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exclude", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeUnknown" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeUnknown", r.URL.Query(), &params.IncludeUnknown)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeUnknown", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoRandom(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoStats operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoStats(w, r, repo)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepoUploadTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostRepoUploadTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRepoUploadTokensParams
+
+	// ------------- Optional query parameter "ttl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "ttl", r.URL.Query(), &params.Ttl)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ttl", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepoUploadTokens(w, r, repo, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepoId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepoId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRepoIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepoId(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoId operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoIdParams
+
+	// ------------- Optional query parameter "w" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "w", r.URL.Query(), &params.W)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "w", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "h" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "h", r.URL.Query(), &params.H)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "h", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fit", r.URL.Query(), &params.Fit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoId(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchRepoId operation middleware
+func (siw *ServerInterfaceWrapper) PatchRepoId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchRepoIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchRepoId(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoIdLocation operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoIdLocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoIdLocationParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoIdLocation(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepoIdPin operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepoIdPin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRepoIdPinParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepoIdPin(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostRepoIdPin operation middleware
+func (siw *ServerInterfaceWrapper) PostRepoIdPin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRepoIdPinParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Nero-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Nero-Key")]; found {
+		var XNeroKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Nero-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Nero-Key", valueList[0], &XNeroKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Nero-Key", Err: err})
+			return
+		}
+
+		params.XNeroKey = &XNeroKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRepoIdPin(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRepoIdThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) GetRepoIdThumbnail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "repo" -------------
+	var repo string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "repo", chi.URLParam(r, "repo"), &repo, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repo", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepoIdThumbnailParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRepoIdThumbnail(w, r, repo, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
 
 func (e *RequiredHeaderError) Error() string {
 	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/healthz", wrapper.GetHealthz)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/random", wrapper.GetRandom)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos", wrapper.GetRepos)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}", wrapper.GetRepo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}", wrapper.PostRepo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/repos/{repo}/batches/{batch}", wrapper.DeleteRepoBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/collections", wrapper.GetRepoCollections)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/repos/{repo}/collections/{collection}", wrapper.DeleteRepoCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/repos/{repo}/collections/{collection}", wrapper.PutRepoCollection)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}/exists-batch", wrapper.PostRepoExistsBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/export.tar", wrapper.GetRepoExportTar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/export.zip", wrapper.GetRepoExportZip)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/facets", wrapper.GetRepoFacets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/feed.json", wrapper.GetRepoFeedJson)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/feed.rss", wrapper.GetRepoFeedRss)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}/import.tar", wrapper.PostRepoImportTar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}/import.zip", wrapper.PostRepoImportZip)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/manifest", wrapper.GetRepoManifest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/random", wrapper.GetRepoRandom)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/stats", wrapper.GetRepoStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}/upload-tokens", wrapper.PostRepoUploadTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/repos/{repo}/{id}", wrapper.DeleteRepoId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/{id}", wrapper.GetRepoId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/repos/{repo}/{id}", wrapper.PatchRepoId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/{id}/location", wrapper.GetRepoIdLocation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/repos/{repo}/{id}/pin", wrapper.DeleteRepoIdPin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/repos/{repo}/{id}/pin", wrapper.PostRepoIdPin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/repos/{repo}/{id}/thumbnail", wrapper.GetRepoIdThumbnail)
+	})
+
+	return r
+}
+
+type GetHealthzRequestObject struct {
+}
+
+type GetHealthzResponseObject interface {
+	VisitGetHealthzResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetHealthz200JSONResponse Health
+
+func (response GetHealthz200JSONResponse) VisitGetHealthzResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRandomRequestObject struct {
+	Params GetRandomParams
+}
+
+type GetRandomResponseObject interface {
+	VisitGetRandomResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRandom200JSONResponse []Media
+
+func (response GetRandom200JSONResponse) VisitGetRandomResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRandom400JSONResponse Error
+
+func (response GetRandom400JSONResponse) VisitGetRandomResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReposRequestObject struct {
+	Params GetReposParams
+}
+
+type GetReposResponseObject interface {
+	VisitGetReposResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepos200JSONResponse []RepoInfo
+
+func (response GetRepos200JSONResponse) VisitGetReposResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepos400JSONResponse Error
+
+func (response GetRepos400JSONResponse) VisitGetReposResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoParams
+}
+
+type GetRepoResponseObject interface {
+	VisitGetRepoResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepo200JSONResponse []Media
+
+func (response GetRepo200JSONResponse) VisitGetRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepo400JSONResponse Error
+
+func (response GetRepo400JSONResponse) VisitGetRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoRequestObject struct {
+	Repo   string `json:"repo"`
+	Params PostRepoParams
+	Body   *PostRepoJSONRequestBody
+}
+
+type PostRepoResponseObject interface {
+	VisitPostRepoResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepo200JSONResponse Media
+
+func (response PostRepo200JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo400JSONResponse Error
+
+func (response PostRepo400JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo401JSONResponse Error
+
+func (response PostRepo401JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo422JSONResponse Error
+
+func (response PostRepo422JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo429JSONResponse Error
+
+func (response PostRepo429JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo503JSONResponse Error
+
+func (response PostRepo503JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepo507JSONResponse Error
+
+func (response PostRepo507JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(507)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoBatchRequestObject struct {
+	Repo   string `json:"repo"`
+	Batch  string `json:"batch"`
+	Params DeleteRepoBatchParams
+}
+
+type DeleteRepoBatchResponseObject interface {
+	VisitDeleteRepoBatchResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type DeleteRepoBatch200JSONResponse []Media
+
+func (response DeleteRepoBatch200JSONResponse) VisitDeleteRepoBatchResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoBatch400JSONResponse Error
+
+func (response DeleteRepoBatch400JSONResponse) VisitDeleteRepoBatchResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoBatch401JSONResponse Error
+
+func (response DeleteRepoBatch401JSONResponse) VisitDeleteRepoBatchResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoCollectionsRequestObject struct {
+	Repo string `json:"repo"`
+}
+
+type GetRepoCollectionsResponseObject interface {
+	VisitGetRepoCollectionsResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoCollections200JSONResponse []Collection
+
+func (response GetRepoCollections200JSONResponse) VisitGetRepoCollectionsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoCollections400JSONResponse Error
+
+func (response GetRepoCollections400JSONResponse) VisitGetRepoCollectionsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoCollectionRequestObject struct {
+	Repo       string `json:"repo"`
+	Collection string `json:"collection"`
+	Params     DeleteRepoCollectionParams
+}
+
+type DeleteRepoCollectionResponseObject interface {
+	VisitDeleteRepoCollectionResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type DeleteRepoCollection200JSONResponse Collection
+
+func (response DeleteRepoCollection200JSONResponse) VisitDeleteRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoCollection400JSONResponse Error
+
+func (response DeleteRepoCollection400JSONResponse) VisitDeleteRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoCollection401JSONResponse Error
+
+func (response DeleteRepoCollection401JSONResponse) VisitDeleteRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutRepoCollectionRequestObject struct {
+	Repo       string `json:"repo"`
+	Collection string `json:"collection"`
+	Params     PutRepoCollectionParams
+	Body       *PutRepoCollectionJSONRequestBody
+}
+
+type PutRepoCollectionResponseObject interface {
+	VisitPutRepoCollectionResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PutRepoCollection200JSONResponse Collection
+
+func (response PutRepoCollection200JSONResponse) VisitPutRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutRepoCollection400JSONResponse Error
+
+func (response PutRepoCollection400JSONResponse) VisitPutRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutRepoCollection401JSONResponse Error
+
+func (response PutRepoCollection401JSONResponse) VisitPutRepoCollectionResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoExistsBatchRequestObject struct {
+	Repo string `json:"repo"`
+	Body *PostRepoExistsBatchJSONRequestBody
+}
+
+type PostRepoExistsBatchResponseObject interface {
+	VisitPostRepoExistsBatchResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepoExistsBatch200JSONResponse map[string]bool
+
+func (response PostRepoExistsBatch200JSONResponse) VisitPostRepoExistsBatchResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoExistsBatch400JSONResponse Error
+
+func (response PostRepoExistsBatch400JSONResponse) VisitPostRepoExistsBatchResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoExportTarRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoExportTarParams
+}
+
+type GetRepoExportTarResponseObject interface {
+	VisitGetRepoExportTarResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoExportTar200ApplicationxTarResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoExportTar200ApplicationxTarResponse) VisitGetRepoExportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/x-tar")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoExportTar400JSONResponse Error
+
+func (response GetRepoExportTar400JSONResponse) VisitGetRepoExportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoExportTar401JSONResponse Error
+
+func (response GetRepoExportTar401JSONResponse) VisitGetRepoExportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoExportZipRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoExportZipParams
+}
+
+type GetRepoExportZipResponseObject interface {
+	VisitGetRepoExportZipResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoExportZip200ApplicationzipResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoExportZip200ApplicationzipResponse) VisitGetRepoExportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/zip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoExportZip400JSONResponse Error
+
+func (response GetRepoExportZip400JSONResponse) VisitGetRepoExportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoExportZip401JSONResponse Error
+
+func (response GetRepoExportZip401JSONResponse) VisitGetRepoExportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoFacetsRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoFacetsParams
+}
+
+type GetRepoFacetsResponseObject interface {
+	VisitGetRepoFacetsResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoFacets200JSONResponse []Facet
+
+func (response GetRepoFacets200JSONResponse) VisitGetRepoFacetsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoFacets400JSONResponse Error
+
+func (response GetRepoFacets400JSONResponse) VisitGetRepoFacetsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoFeedJsonRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoFeedJsonParams
+}
+
+type GetRepoFeedJsonResponseObject interface {
+	VisitGetRepoFeedJsonResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoFeedJson200ApplicationFeedPlusJSONResponse JSONFeed
+
+func (response GetRepoFeedJson200ApplicationFeedPlusJSONResponse) VisitGetRepoFeedJsonResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/feed+json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoFeedJson400JSONResponse Error
+
+func (response GetRepoFeedJson400JSONResponse) VisitGetRepoFeedJsonResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoFeedRssRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoFeedRssParams
+}
+
+type GetRepoFeedRssResponseObject interface {
+	VisitGetRepoFeedRssResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoFeedRss200ApplicationrssXmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoFeedRss200ApplicationrssXmlResponse) VisitGetRepoFeedRssResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/rss+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoFeedRss400JSONResponse Error
+
+func (response GetRepoFeedRss400JSONResponse) VisitGetRepoFeedRssResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTarRequestObject struct {
+	Repo   string `json:"repo"`
+	Params PostRepoImportTarParams
+	Body   io.Reader
+}
+
+type PostRepoImportTarResponseObject interface {
+	VisitPostRepoImportTarResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepoImportTar200JSONResponse ImportResult
+
+func (response PostRepoImportTar200JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTar400JSONResponse Error
+
+func (response PostRepoImportTar400JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTar401JSONResponse Error
+
+func (response PostRepoImportTar401JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTar422JSONResponse Error
+
+func (response PostRepoImportTar422JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTar503JSONResponse Error
+
+func (response PostRepoImportTar503JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportTar507JSONResponse Error
+
+func (response PostRepoImportTar507JSONResponse) VisitPostRepoImportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(507)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZipRequestObject struct {
+	Repo   string `json:"repo"`
+	Params PostRepoImportZipParams
+	Body   io.Reader
+}
+
+type PostRepoImportZipResponseObject interface {
+	VisitPostRepoImportZipResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepoImportZip200JSONResponse ImportResult
+
+func (response PostRepoImportZip200JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZip400JSONResponse Error
+
+func (response PostRepoImportZip400JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZip401JSONResponse Error
+
+func (response PostRepoImportZip401JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZip422JSONResponse Error
+
+func (response PostRepoImportZip422JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZip503JSONResponse Error
+
+func (response PostRepoImportZip503JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoImportZip507JSONResponse Error
+
+func (response PostRepoImportZip507JSONResponse) VisitPostRepoImportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(507)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoManifestRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoManifestParams
+}
+
+type GetRepoManifestResponseObject interface {
+	VisitGetRepoManifestResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoManifest200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoManifest200ApplicationxNdjsonResponse) VisitGetRepoManifestResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoManifest400JSONResponse Error
+
+func (response GetRepoManifest400JSONResponse) VisitGetRepoManifestResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoManifest401JSONResponse Error
+
+func (response GetRepoManifest401JSONResponse) VisitGetRepoManifestResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoRandomRequestObject struct {
+	Repo   string `json:"repo"`
+	Params GetRepoRandomParams
+}
+
+type GetRepoRandomResponseObject interface {
+	VisitGetRepoRandomResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoRandom200JSONResponse []Media
+
+func (response GetRepoRandom200JSONResponse) VisitGetRepoRandomResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoRandom400JSONResponse Error
+
+func (response GetRepoRandom400JSONResponse) VisitGetRepoRandomResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoRandom404JSONResponse Error
+
+func (response GetRepoRandom404JSONResponse) VisitGetRepoRandomResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoStatsRequestObject struct {
+	Repo string `json:"repo"`
+}
+
+type GetRepoStatsResponseObject interface {
+	VisitGetRepoStatsResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoStats200JSONResponse RepoInfo
+
+func (response GetRepoStats200JSONResponse) VisitGetRepoStatsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoStats400JSONResponse Error
+
+func (response GetRepoStats400JSONResponse) VisitGetRepoStatsResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoUploadTokensRequestObject struct {
+	Repo   string `json:"repo"`
+	Params PostRepoUploadTokensParams
+}
+
+type PostRepoUploadTokensResponseObject interface {
+	VisitPostRepoUploadTokensResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepoUploadTokens200JSONResponse UploadToken
+
+func (response PostRepoUploadTokens200JSONResponse) VisitPostRepoUploadTokensResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoUploadTokens400JSONResponse Error
+
+func (response PostRepoUploadTokens400JSONResponse) VisitPostRepoUploadTokensResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoUploadTokens401JSONResponse Error
+
+func (response PostRepoUploadTokens401JSONResponse) VisitPostRepoUploadTokensResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoIdRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params DeleteRepoIdParams
+}
+
+type DeleteRepoIdResponseObject interface {
+	VisitDeleteRepoIdResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type DeleteRepoId200JSONResponse Media
+
+func (response DeleteRepoId200JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoId400JSONResponse Error
+
+func (response DeleteRepoId400JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoId401JSONResponse Error
+
+func (response DeleteRepoId401JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoIdRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params GetRepoIdParams
+}
+
+type GetRepoIdResponseObject interface {
+	VisitGetRepoIdResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoId200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoId200ApplicationoctetStreamResponse) VisitGetRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoId400JSONResponse Error
+
+func (response GetRepoId400JSONResponse) VisitGetRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRepoIdRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params PatchRepoIdParams
+	Body   *PatchRepoIdJSONRequestBody
+}
+
+type PatchRepoIdResponseObject interface {
+	VisitPatchRepoIdResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PatchRepoId200JSONResponse Media
+
+func (response PatchRepoId200JSONResponse) VisitPatchRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRepoId400JSONResponse Error
+
+func (response PatchRepoId400JSONResponse) VisitPatchRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRepoId401JSONResponse Error
+
+func (response PatchRepoId401JSONResponse) VisitPatchRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoIdLocationRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params GetRepoIdLocationParams
+}
+
+type GetRepoIdLocationResponseObject interface {
+	VisitGetRepoIdLocationResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoIdLocation200JSONResponse Location
+
+func (response GetRepoIdLocation200JSONResponse) VisitGetRepoIdLocationResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoIdLocation400JSONResponse Error
+
+func (response GetRepoIdLocation400JSONResponse) VisitGetRepoIdLocationResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoIdLocation401JSONResponse Error
+
+func (response GetRepoIdLocation401JSONResponse) VisitGetRepoIdLocationResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoIdPinRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params DeleteRepoIdPinParams
+}
+
+type DeleteRepoIdPinResponseObject interface {
+	VisitDeleteRepoIdPinResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type DeleteRepoIdPin200JSONResponse Media
+
+func (response DeleteRepoIdPin200JSONResponse) VisitDeleteRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoIdPin400JSONResponse Error
+
+func (response DeleteRepoIdPin400JSONResponse) VisitDeleteRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepoIdPin401JSONResponse Error
+
+func (response DeleteRepoIdPin401JSONResponse) VisitDeleteRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoIdPinRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params PostRepoIdPinParams
+}
+
+type PostRepoIdPinResponseObject interface {
+	VisitPostRepoIdPinResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type PostRepoIdPin200JSONResponse Media
+
+func (response PostRepoIdPin200JSONResponse) VisitPostRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoIdPin400JSONResponse Error
+
+func (response PostRepoIdPin400JSONResponse) VisitPostRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRepoIdPin401JSONResponse Error
+
+func (response PostRepoIdPin401JSONResponse) VisitPostRepoIdPinResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepoIdThumbnailRequestObject struct {
+	Repo   string             `json:"repo"`
+	Id     openapi_types.UUID `json:"id"`
+	Params GetRepoIdThumbnailParams
+}
+
+type GetRepoIdThumbnailResponseObject interface {
+	VisitGetRepoIdThumbnailResponse(w http.ResponseWriter, r *http.Request) error
+}
+
+type GetRepoIdThumbnail200ImagejpegResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetRepoIdThumbnail200ImagejpegResponse) VisitGetRepoIdThumbnailResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "image/jpeg")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetRepoIdThumbnail400JSONResponse Error
+
+func (response GetRepoIdThumbnail400JSONResponse) VisitGetRepoIdThumbnailResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /healthz)
+	GetHealthz(ctx context.Context, request GetHealthzRequestObject) (GetHealthzResponseObject, error)
+
+	// (GET /random)
+	GetRandom(ctx context.Context, request GetRandomRequestObject) (GetRandomResponseObject, error)
+
+	// (GET /repos)
+	GetRepos(ctx context.Context, request GetReposRequestObject) (GetReposResponseObject, error)
+
+	// (GET /repos/{repo})
+	GetRepo(ctx context.Context, request GetRepoRequestObject) (GetRepoResponseObject, error)
+
+	// (POST /repos/{repo})
+	PostRepo(ctx context.Context, request PostRepoRequestObject) (PostRepoResponseObject, error)
+
+	// (DELETE /repos/{repo}/batches/{batch})
+	DeleteRepoBatch(ctx context.Context, request DeleteRepoBatchRequestObject) (DeleteRepoBatchResponseObject, error)
+
+	// (GET /repos/{repo}/collections)
+	GetRepoCollections(ctx context.Context, request GetRepoCollectionsRequestObject) (GetRepoCollectionsResponseObject, error)
+
+	// (DELETE /repos/{repo}/collections/{collection})
+	DeleteRepoCollection(ctx context.Context, request DeleteRepoCollectionRequestObject) (DeleteRepoCollectionResponseObject, error)
+
+	// (PUT /repos/{repo}/collections/{collection})
+	PutRepoCollection(ctx context.Context, request PutRepoCollectionRequestObject) (PutRepoCollectionResponseObject, error)
+
+	// (POST /repos/{repo}/exists-batch)
+	PostRepoExistsBatch(ctx context.Context, request PostRepoExistsBatchRequestObject) (PostRepoExistsBatchResponseObject, error)
+
+	// (GET /repos/{repo}/export.tar)
+	GetRepoExportTar(ctx context.Context, request GetRepoExportTarRequestObject) (GetRepoExportTarResponseObject, error)
+
+	// (GET /repos/{repo}/export.zip)
+	GetRepoExportZip(ctx context.Context, request GetRepoExportZipRequestObject) (GetRepoExportZipResponseObject, error)
+
+	// (GET /repos/{repo}/facets)
+	GetRepoFacets(ctx context.Context, request GetRepoFacetsRequestObject) (GetRepoFacetsResponseObject, error)
+
+	// (GET /repos/{repo}/feed.json)
+	GetRepoFeedJson(ctx context.Context, request GetRepoFeedJsonRequestObject) (GetRepoFeedJsonResponseObject, error)
+
+	// (GET /repos/{repo}/feed.rss)
+	GetRepoFeedRss(ctx context.Context, request GetRepoFeedRssRequestObject) (GetRepoFeedRssResponseObject, error)
+
+	// (POST /repos/{repo}/import.tar)
+	PostRepoImportTar(ctx context.Context, request PostRepoImportTarRequestObject) (PostRepoImportTarResponseObject, error)
+
+	// (POST /repos/{repo}/import.zip)
+	PostRepoImportZip(ctx context.Context, request PostRepoImportZipRequestObject) (PostRepoImportZipResponseObject, error)
+
+	// (GET /repos/{repo}/manifest)
+	GetRepoManifest(ctx context.Context, request GetRepoManifestRequestObject) (GetRepoManifestResponseObject, error)
+
+	// (GET /repos/{repo}/random)
+	GetRepoRandom(ctx context.Context, request GetRepoRandomRequestObject) (GetRepoRandomResponseObject, error)
+
+	// (GET /repos/{repo}/stats)
+	GetRepoStats(ctx context.Context, request GetRepoStatsRequestObject) (GetRepoStatsResponseObject, error)
+
+	// (POST /repos/{repo}/upload-tokens)
+	PostRepoUploadTokens(ctx context.Context, request PostRepoUploadTokensRequestObject) (PostRepoUploadTokensResponseObject, error)
+
+	// (DELETE /repos/{repo}/{id})
+	DeleteRepoId(ctx context.Context, request DeleteRepoIdRequestObject) (DeleteRepoIdResponseObject, error)
+
+	// (GET /repos/{repo}/{id})
+	GetRepoId(ctx context.Context, request GetRepoIdRequestObject) (GetRepoIdResponseObject, error)
+
+	// (PATCH /repos/{repo}/{id})
+	PatchRepoId(ctx context.Context, request PatchRepoIdRequestObject) (PatchRepoIdResponseObject, error)
+
+	// (GET /repos/{repo}/{id}/location)
+	GetRepoIdLocation(ctx context.Context, request GetRepoIdLocationRequestObject) (GetRepoIdLocationResponseObject, error)
+
+	// (DELETE /repos/{repo}/{id}/pin)
+	DeleteRepoIdPin(ctx context.Context, request DeleteRepoIdPinRequestObject) (DeleteRepoIdPinResponseObject, error)
+
+	// (POST /repos/{repo}/{id}/pin)
+	PostRepoIdPin(ctx context.Context, request PostRepoIdPinRequestObject) (PostRepoIdPinResponseObject, error)
+
+	// (GET /repos/{repo}/{id}/thumbnail)
+	GetRepoIdThumbnail(ctx context.Context, request GetRepoIdThumbnailRequestObject) (GetRepoIdThumbnailResponseObject, error)
+}
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// DisallowUnknownFieldsFunc reports whether JSON request bodies of an operation with unknown fields should be rejected,
+	// unknown fields are ignored if nil.
+	DisallowUnknownFieldsFunc func(r *http.Request, operationID string) bool
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetHealthz operation middleware
+func (sh *strictHandler) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthzRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthz(ctx, request.(GetHealthzRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthz")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthzResponseObject); ok {
+		if err := validResponse.VisitGetHealthzResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRandom operation middleware
+func (sh *strictHandler) GetRandom(w http.ResponseWriter, r *http.Request, params GetRandomParams) {
+	var request GetRandomRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRandom(ctx, request.(GetRandomRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRandom")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRandomResponseObject); ok {
+		if err := validResponse.VisitGetRandomResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRepos operation middleware
+func (sh *strictHandler) GetRepos(w http.ResponseWriter, r *http.Request, params GetReposParams) {
+	var request GetReposRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepos(ctx, request.(GetReposRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepos")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReposResponseObject); ok {
+		if err := validResponse.VisitGetReposResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRepo operation middleware
+func (sh *strictHandler) GetRepo(w http.ResponseWriter, r *http.Request, repo string, params GetRepoParams) {
+	var request GetRepoRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepo(ctx, request.(GetRepoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoResponseObject); ok {
+		if err := validResponse.VisitGetRepoResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRepo operation middleware
+func (sh *strictHandler) PostRepo(w http.ResponseWriter, r *http.Request, repo string, params PostRepoParams) {
+	var request PostRepoRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	var body PostRepoJSONRequestBody
+	dec := json.NewDecoder(r.Body)
+	if sh.options.DisallowUnknownFieldsFunc != nil && sh.options.DisallowUnknownFieldsFunc(r, "PostRepo") {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRepo(ctx, request.(PostRepoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRepo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRepoResponseObject); ok {
+		if err := validResponse.VisitPostRepoResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteRepoBatch operation middleware
+func (sh *strictHandler) DeleteRepoBatch(w http.ResponseWriter, r *http.Request, repo string, batch string, params DeleteRepoBatchParams) {
+	var request DeleteRepoBatchRequestObject
+
+	request.Repo = repo
+	request.Batch = batch
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRepoBatch(ctx, request.(DeleteRepoBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRepoBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteRepoBatchResponseObject); ok {
+		if err := validResponse.VisitDeleteRepoBatchResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRepoCollections operation middleware
+func (sh *strictHandler) GetRepoCollections(w http.ResponseWriter, r *http.Request, repo string) {
+	var request GetRepoCollectionsRequestObject
+
+	request.Repo = repo
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoCollections(ctx, request.(GetRepoCollectionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoCollections")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoCollectionsResponseObject); ok {
+		if err := validResponse.VisitGetRepoCollectionsResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteRepoCollection operation middleware
+func (sh *strictHandler) DeleteRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params DeleteRepoCollectionParams) {
+	var request DeleteRepoCollectionRequestObject
+
+	request.Repo = repo
+	request.Collection = collection
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRepoCollection(ctx, request.(DeleteRepoCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRepoCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteRepoCollectionResponseObject); ok {
+		if err := validResponse.VisitDeleteRepoCollectionResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+// PutRepoCollection operation middleware
+func (sh *strictHandler) PutRepoCollection(w http.ResponseWriter, r *http.Request, repo string, collection string, params PutRepoCollectionParams) {
+	var request PutRepoCollectionRequestObject
+
+	request.Repo = repo
+	request.Collection = collection
+	request.Params = params
+
+	var body PutRepoCollectionJSONRequestBody
+	dec := json.NewDecoder(r.Body)
+	if sh.options.DisallowUnknownFieldsFunc != nil && sh.options.DisallowUnknownFieldsFunc(r, "PutRepoCollection") {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutRepoCollection(ctx, request.(PutRepoCollectionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutRepoCollection")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutRepoCollectionResponseObject); ok {
+		if err := validResponse.VisitPutRepoCollectionResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+// PostRepoExistsBatch operation middleware
+func (sh *strictHandler) PostRepoExistsBatch(w http.ResponseWriter, r *http.Request, repo string) {
+	var request PostRepoExistsBatchRequestObject
+
+	request.Repo = repo
+
+	var body PostRepoExistsBatchJSONRequestBody
+	dec := json.NewDecoder(r.Body)
+	if sh.options.DisallowUnknownFieldsFunc != nil && sh.options.DisallowUnknownFieldsFunc(r, "PostRepoExistsBatch") {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRepoExistsBatch(ctx, request.(PostRepoExistsBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRepoExistsBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRepoExistsBatchResponseObject); ok {
+		if err := validResponse.VisitPostRepoExistsBatchResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+// GetRepoExportTar operation middleware
+func (sh *strictHandler) GetRepoExportTar(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportTarParams) {
+	var request GetRepoExportTarRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoExportTar(ctx, request.(GetRepoExportTarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoExportTar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoExportTarResponseObject); ok {
+		if err := validResponse.VisitGetRepoExportTarResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+// GetRepoExportZip operation middleware
+func (sh *strictHandler) GetRepoExportZip(w http.ResponseWriter, r *http.Request, repo string, params GetRepoExportZipParams) {
+	var request GetRepoExportZipRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoExportZip(ctx, request.(GetRepoExportZipRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoExportZip")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoExportZipResponseObject); ok {
+		if err := validResponse.VisitGetRepoExportZipResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+// GetRepoFacets operation middleware
+func (sh *strictHandler) GetRepoFacets(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFacetsParams) {
+	var request GetRepoFacetsRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoFacets(ctx, request.(GetRepoFacetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoFacets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoFacetsResponseObject); ok {
+		if err := validResponse.VisitGetRepoFacetsResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+// GetRepoFeedJson operation middleware
+func (sh *strictHandler) GetRepoFeedJson(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedJsonParams) {
+	var request GetRepoFeedJsonRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoFeedJson(ctx, request.(GetRepoFeedJsonRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoFeedJson")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoFeedJsonResponseObject); ok {
+		if err := validResponse.VisitGetRepoFeedJsonResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// GetRepoFeedRss operation middleware
+func (sh *strictHandler) GetRepoFeedRss(w http.ResponseWriter, r *http.Request, repo string, params GetRepoFeedRssParams) {
+	var request GetRepoFeedRssRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoFeedRss(ctx, request.(GetRepoFeedRssRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoFeedRss")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoFeedRssResponseObject); ok {
+		if err := validResponse.VisitGetRepoFeedRssResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+// PostRepoImportTar operation middleware
+func (sh *strictHandler) PostRepoImportTar(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportTarParams) {
+	var request PostRepoImportTarRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRepoImportTar(ctx, request.(PostRepoImportTarRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRepoImportTar")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRepoImportTarResponseObject); ok {
+		if err := validResponse.VisitPostRepoImportTarResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+// PostRepoImportZip operation middleware
+func (sh *strictHandler) PostRepoImportZip(w http.ResponseWriter, r *http.Request, repo string, params PostRepoImportZipParams) {
+	var request PostRepoImportZipRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRepoImportZip(ctx, request.(PostRepoImportZipRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRepoImportZip")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRepoImportZipResponseObject); ok {
+		if err := validResponse.VisitPostRepoImportZipResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRepoManifest operation middleware
+func (sh *strictHandler) GetRepoManifest(w http.ResponseWriter, r *http.Request, repo string, params GetRepoManifestParams) {
+	var request GetRepoManifestRequestObject
+
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoManifest(ctx, request.(GetRepoManifestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoManifest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoManifestResponseObject); ok {
+		if err := validResponse.VisitGetRepoManifestResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetRepoRandom operation middleware
+func (sh *strictHandler) GetRepoRandom(w http.ResponseWriter, r *http.Request, repo string, params GetRepoRandomParams) {
+	var request GetRepoRandomRequestObject
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	request.Repo = repo
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoRandom(ctx, request.(GetRepoRandomRequestObject))
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoRandom")
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/repos/{repo}", wrapper.PostRepo)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/repos/{repo}/{id}", wrapper.DeleteRepoId)
-	})
+	response, err := handler(r.Context(), w, r, request)
 
-	return r
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoRandomResponseObject); ok {
+		if err := validResponse.VisitGetRepoRandomResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRepoRequestObject struct {
-	Repo   string `json:"repo"`
-	Params PostRepoParams
-	Body   *PostRepoJSONRequestBody
-}
+// GetRepoStats operation middleware
+func (sh *strictHandler) GetRepoStats(w http.ResponseWriter, r *http.Request, repo string) {
+	var request GetRepoStatsRequestObject
 
-type PostRepoResponseObject interface {
-	VisitPostRepoResponse(w http.ResponseWriter, r *http.Request) error
-}
+	request.Repo = repo
 
-type PostRepo200JSONResponse Media
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoStats(ctx, request.(GetRepoStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoStats")
+	}
 
-func (response PostRepo200JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoStatsResponseObject); ok {
+		if err := validResponse.VisitGetRepoStatsResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRepo400JSONResponse Error
-
-func (response PostRepo400JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// PostRepoUploadTokens operation middleware
+func (sh *strictHandler) PostRepoUploadTokens(w http.ResponseWriter, r *http.Request, repo string, params PostRepoUploadTokensParams) {
+	var request PostRepoUploadTokensRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Repo = repo
+	request.Params = params
 
-type PostRepo401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRepoUploadTokens(ctx, request.(PostRepoUploadTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRepoUploadTokens")
+	}
 
-func (response PostRepo401JSONResponse) VisitPostRepoResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRepoUploadTokensResponseObject); ok {
+		if err := validResponse.VisitPostRepoUploadTokensResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepoIdRequestObject struct {
-	Repo   string             `json:"repo"`
-	Id     openapi_types.UUID `json:"id"`
-	Params DeleteRepoIdParams
-}
+// DeleteRepoId operation middleware
+func (sh *strictHandler) DeleteRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdParams) {
+	var request DeleteRepoIdRequestObject
 
-type DeleteRepoIdResponseObject interface {
-	VisitDeleteRepoIdResponse(w http.ResponseWriter, r *http.Request) error
-}
+	request.Repo = repo
+	request.Id = id
+	request.Params = params
 
-type DeleteRepoId200JSONResponse Media
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRepoId(ctx, request.(DeleteRepoIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRepoId")
+	}
 
-func (response DeleteRepoId200JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteRepoIdResponseObject); ok {
+		if err := validResponse.VisitDeleteRepoIdResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepoId400JSONResponse Error
+// GetRepoId operation middleware
+func (sh *strictHandler) GetRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdParams) {
+	var request GetRepoIdRequestObject
 
-func (response DeleteRepoId400JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Repo = repo
+	request.Id = id
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoId(ctx, request.(GetRepoIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoIdResponseObject); ok {
+		if err := validResponse.VisitGetRepoIdResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepoId401JSONResponse Error
+// PatchRepoId operation middleware
+func (sh *strictHandler) PatchRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PatchRepoIdParams) {
+	var request PatchRepoIdRequestObject
 
-func (response DeleteRepoId401JSONResponse) VisitDeleteRepoIdResponse(w http.ResponseWriter, _ *http.Request) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	request.Repo = repo
+	request.Id = id
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PatchRepoIdJSONRequestBody
+	dec := json.NewDecoder(r.Body)
+	if sh.options.DisallowUnknownFieldsFunc != nil && sh.options.DisallowUnknownFieldsFunc(r, "PatchRepoId") {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchRepoId(ctx, request.(PatchRepoIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchRepoId")
+	}
 
-	// (POST /repos/{repo})
-	PostRepo(ctx context.Context, request PostRepoRequestObject) (PostRepoResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (DELETE /repos/{repo}/{id})
-	DeleteRepoId(ctx context.Context, request DeleteRepoIdRequestObject) (DeleteRepoIdResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchRepoIdResponseObject); ok {
+		if err := validResponse.VisitPatchRepoIdResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+// GetRepoIdLocation operation middleware
+func (sh *strictHandler) GetRepoIdLocation(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdLocationParams) {
+	var request GetRepoIdLocationRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	request.Repo = repo
+	request.Id = id
+	request.Params = params
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepoIdLocation(ctx, request.(GetRepoIdLocationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepoIdLocation")
+	}
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepoIdLocationResponseObject); ok {
+		if err := validResponse.VisitGetRepoIdLocationResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// PostRepo operation middleware
-func (sh *strictHandler) PostRepo(w http.ResponseWriter, r *http.Request, repo string, params PostRepoParams) {
-	var request PostRepoRequestObject
+// DeleteRepoIdPin operation middleware
+func (sh *strictHandler) DeleteRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdPinParams) {
+	var request DeleteRepoIdPinRequestObject
 
 	request.Repo = repo
+	request.Id = id
 	request.Params = params
 
-	var body PostRepoJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteRepoIdPin(ctx, request.(DeleteRepoIdPinRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteRepoIdPin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteRepoIdPinResponseObject); ok {
+		if err := validResponse.VisitDeleteRepoIdPinResponse(w, r); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRepoIdPin operation middleware
+func (sh *strictHandler) PostRepoIdPin(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params PostRepoIdPinParams) {
+	var request PostRepoIdPinRequestObject
+
+	request.Repo = repo
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostRepo(ctx, request.(PostRepoRequestObject))
+		return sh.ssi.PostRepoIdPin(ctx, request.(PostRepoIdPinRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostRepo")
+		handler = middleware(handler, "PostRepoIdPin")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostRepoResponseObject); ok {
-		if err := validResponse.VisitPostRepoResponse(w, r); err != nil {
+	} else if validResponse, ok := response.(PostRepoIdPinResponseObject); ok {
+		if err := validResponse.VisitPostRepoIdPinResponse(w, r); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -426,27 +3959,27 @@ func (sh *strictHandler) PostRepo(w http.ResponseWriter, r *http.Request, repo s
 	}
 }
 
-// DeleteRepoId operation middleware
-func (sh *strictHandler) DeleteRepoId(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params DeleteRepoIdParams) {
-	var request DeleteRepoIdRequestObject
+// GetRepoIdThumbnail operation middleware
+func (sh *strictHandler) GetRepoIdThumbnail(w http.ResponseWriter, r *http.Request, repo string, id openapi_types.UUID, params GetRepoIdThumbnailParams) {
+	var request GetRepoIdThumbnailRequestObject
 
 	request.Repo = repo
 	request.Id = id
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteRepoId(ctx, request.(DeleteRepoIdRequestObject))
+		return sh.ssi.GetRepoIdThumbnail(ctx, request.(GetRepoIdThumbnailRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteRepoId")
+		handler = middleware(handler, "GetRepoIdThumbnail")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteRepoIdResponseObject); ok {
-		if err := validResponse.VisitDeleteRepoIdResponse(w, r); err != nil {
+	} else if validResponse, ok := response.(GetRepoIdThumbnailResponseObject); ok {
+		if err := validResponse.VisitGetRepoIdThumbnailResponse(w, r); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
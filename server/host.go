@@ -0,0 +1,47 @@
+package server
+
+import (
+	"github.com/cephxdev/nero/server/v1"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// hostRouting creates a middleware serving requests to mapped hosts from their repository,
+// e.g. GET /random on a host mapped to the repository "anime" is served like GET /api/v1/repos/anime/random.
+// Requests under the API base path and requests to other hosts are served as-is.
+func hostRouting(hosts map[string]string) func(http.Handler) http.Handler {
+	hosts0 := make(map[string]string, len(hosts))
+	for host, repoId := range hosts {
+		hosts0[strings.ToLower(host)] = repoId
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			repoId, ok := hosts0[requestHost(r)]
+			if !ok || r.URL.Path == v1.BasePath || strings.HasPrefix(r.URL.Path, v1.BasePath+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			u := *r.URL
+			u.Path = v1.BasePath + "/repos/" + repoId + strings.TrimSuffix(r.URL.Path, "/")
+			u.RawPath = v1.BasePath + "/repos/" + url.PathEscape(repoId) + strings.TrimSuffix(r.URL.EscapedPath(), "/")
+
+			r0 := *r
+			r0.URL = &u
+			next.ServeHTTP(w, &r0)
+		})
+	}
+}
+
+// requestHost returns the lower-case host name of a request, without the port.
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.ToLower(host)
+}
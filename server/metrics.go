@@ -0,0 +1,53 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// labelEscaper escapes label values of the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// NewMetricsHandler creates a handler serving repository metrics in the Prometheus text exposition format.
+func NewMetricsHandler(repos []*repo.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		bw := bufio.NewWriter(w)
+		writeUploadSizes(bw, repos)
+		_ = bw.Flush()
+	})
+}
+
+// writeUploadSizes writes the upload size histograms of repositories, by format.
+func writeUploadSizes(w *bufio.Writer, repos []*repo.Repository) {
+	const name = "nero_upload_size_bytes"
+
+	_, _ = fmt.Fprintf(w, "# HELP %s Content sizes of uploaded media in bytes.\n# TYPE %s histogram\n", name, name)
+	for _, r := range repos {
+		hs := r.UploadSizes()
+
+		formats := make([]media.Format, 0, len(hs))
+		for format := range hs {
+			formats = append(formats, format)
+		}
+		slices.Sort(formats)
+
+		for _, format := range formats {
+			h := hs[format]
+			labels := fmt.Sprintf(`repo="%s",format="%s"`, labelEscaper.Replace(r.ID()), format)
+
+			for i, bound := range repo.UploadSizeBuckets {
+				_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"%d\"} %d\n", name, labels, bound, h.Buckets[i])
+			}
+			_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.Count)
+			_, _ = fmt.Fprintf(w, "%s_sum{%s} %d\n", name, labels, h.Sum)
+			_, _ = fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.Count)
+		}
+	}
+}
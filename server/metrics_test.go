@@ -0,0 +1,59 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"github.com/cephxdev/nero/repo"
+	"go.uber.org/zap"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	dir := t.TempDir()
+	r, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	m, err := r.Create(context.Background(), buf.Bytes(), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	NewMetricsHandler([]*repo.Repository{r, repo.NewMemory("empty", nil, zap.NewNop())}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	b, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	body := string(b)
+	for _, line := range []string{
+		"# TYPE nero_upload_size_bytes histogram",
+		`nero_upload_size_bytes_bucket{repo="test",format="image",le="16384"} 1`,
+		`nero_upload_size_bytes_bucket{repo="test",format="image",le="+Inf"} 1`,
+		`nero_upload_size_bytes_count{repo="test",format="image"} 1`,
+		`nero_upload_size_bytes_sum{repo="test",format="image"} ` + strconv.FormatInt(m.Size, 10),
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics are missing %q:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, `repo="empty"`) {
+		t.Error("metrics contain a repository without uploads")
+	}
+}
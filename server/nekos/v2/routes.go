@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
 	"github.com/cephxdev/nero/repo/media"
 	"github.com/cephxdev/nero/repo/media/meta"
 	"github.com/cephxdev/nero/server/api"
@@ -12,7 +13,6 @@ import (
 	"go.uber.org/multierr"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 )
 
@@ -102,7 +102,7 @@ func (s *Server) GetCategoryFile(_ context.Context, request v2.GetCategoryFileRe
 		return v2.GetCategoryFile404JSONResponse(v2.Error{Code: http.StatusNotFound, Message: "file not found"}), nil
 	}
 
-	return &fileRes{item: m}, nil
+	return &fileRes{repo: r, item: m}, nil
 }
 
 func (s *Server) makeRequestUrl(r *http.Request) *url.URL {
@@ -111,30 +111,23 @@ func (s *Server) makeRequestUrl(r *http.Request) *url.URL {
 	u.RawQuery = ""
 
 	if !u.IsAbs() { // try to make url absolute
-		if s.baseURL != nil {
-			u.Host = s.baseURL.Host
-			u.Scheme = s.baseURL.Scheme
-		} else {
-			u.Host = r.Host
-			u.Scheme = "http"
-
-			if r.TLS != nil {
-				u.Scheme = "https"
-			}
-		}
+		base := api.BaseURL(r, s.baseURL)
+		u.Host = base.Host
+		u.Scheme = base.Scheme
 	}
 
 	return u
 }
 
 type fileRes struct {
+	repo *repo.Repository
 	item *media.Media
 }
 
-func (fr *fileRes) VisitGetCategoryFileResponse(w http.ResponseWriter, r *http.Request) error {
-	f, err := os.Open(fr.item.Path)
+func (fr *fileRes) VisitGetCategoryFileResponse(w http.ResponseWriter, r *http.Request) (err error) {
+	f, name, err := api.OpenFile(w, r, fr.repo, fr.item)
 	if err != nil {
-		return errors.Wrap(err, "failed to open media")
+		return err
 	}
 	defer func() {
 		if err0 := f.Close(); err0 != nil {
@@ -142,14 +135,8 @@ func (fr *fileRes) VisitGetCategoryFileResponse(w http.ResponseWriter, r *http.R
 		}
 	}()
 
-	fi, err := f.Stat()
-	if err != nil {
-		return errors.Wrap(err, "failed to stat media")
-	}
-
 	writeHeaderMeta(w.Header(), fr.item.Meta)
-
-	http.ServeContent(w, r, fi.Name(), fi.ModTime(), f)
+	http.ServeContent(w, r, name, f.ModTime, f)
 	return err
 }
 
@@ -3,6 +3,7 @@ package v2
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo"
 	"github.com/cephxdev/nero/server/api"
 	"github.com/cephxdev/nero/server/api/nekos/v2"
@@ -10,6 +11,7 @@ import (
 	"golang.org/x/exp/maps"
 	"net/http"
 	"net/url"
+	"os"
 )
 
 var (
@@ -25,9 +27,14 @@ var (
 
 	DefaultResponseErrorHandler api.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
 
-		e := v2.Error{Code: http.StatusInternalServerError, Message: err.Error()}
+		code := http.StatusInternalServerError
+		if errors.Is(err, os.ErrNotExist) {
+			code = http.StatusNotFound
+		}
+		w.WriteHeader(code)
+
+		e := v2.Error{Code: code, Message: err.Error()}
 		if err := json.NewEncoder(w).Encode(e); err != nil {
 			_, _ = fmt.Fprintf(w, "{\"code\":\"%d\",\"message\":\"%s\"}", http.StatusInternalServerError, "failed to serialize error")
 		}
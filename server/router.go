@@ -1,8 +1,10 @@
 package server
 
 import (
+	"fmt"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api"
 	"github.com/cephxdev/nero/server/nekos/v2"
 	"github.com/cephxdev/nero/server/v1"
 	"github.com/go-chi/chi/v5"
@@ -11,23 +13,31 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"net/url"
+	"slices"
 )
 
 var corsOpts = cors.Options{
 	AllowedOrigins:   []string{"https://*", "http://*"},
 	AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE"},
-	AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+	AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Content-SHA256", "X-Nero-Key"},
 	ExposedHeaders:   []string{"Link"},
 	AllowCredentials: false,
 	MaxAge:           300,
 }
 
 // NewNeroRouter creates a new nero API router.
-func NewNeroRouter(repos []*repo.Repository, logger *zap.Logger) (http.Handler, error) {
-	srv, err := v1.NewServer(repos, logger)
+// hosts maps host names to IDs of repositories served from outside the API base path on that host, may be nil.
+// New uploads are rejected once drain is started, drain may be nil.
+func NewNeroRouter(repos []*repo.Repository, baseURL *url.URL, hosts map[string]string, drain *api.Drain, logger *zap.Logger) (http.Handler, error) {
+	srv, err := v1.NewServer(repos, baseURL, drain, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create nero v1 api handler")
 	}
+	for host, repoId := range hosts {
+		if !slices.ContainsFunc(repos, func(r *repo.Repository) bool { return r.ID() == repoId }) {
+			return nil, fmt.Errorf("host %s is mapped to unknown repository %s", host, repoId)
+		}
+	}
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
@@ -37,7 +47,10 @@ func NewNeroRouter(repos []*repo.Repository, logger *zap.Logger) (http.Handler,
 	}))
 	r.Use(middleware.Recoverer)
 	r.Use(cors.Handler(corsOpts))
-	r.Mount("/api/v1", v1.NewRouter(srv))
+	if len(hosts) > 0 {
+		r.Use(hostRouting(hosts))
+	}
+	r.Mount(v1.BasePath, v1.NewRouter(srv))
 
 	return r, nil
 }
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"github.com/cephxdev/nero/repo"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCORSAllowedHeaders(t *testing.T) {
+	h, err := NewNeroRouter(nil, nil, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/repos/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type, X-Nero-Key, X-Content-SHA256")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	allowed := strings.ToLower(rec.Header().Get("Access-Control-Allow-Headers"))
+	for _, name := range []string{"x-nero-key", "x-content-sha256"} {
+		if !strings.Contains(allowed, name) {
+			t.Errorf("preflight doesn't allow %s, allowed: %q", name, allowed)
+		}
+	}
+}
+
+func TestHostRouting(t *testing.T) {
+	var repos []*repo.Repository
+	for _, id := range []string{"anime", "fan art", "other"} {
+		r := repo.NewMemory(id, nil, zap.NewNop())
+		repos = append(repos, r)
+	}
+
+	hosts := map[string]string{"anime.example.com": "anime", "Art.example.com": "fan art"}
+	h, err := NewNeroRouter(repos, nil, hosts, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create router: %v", err)
+	}
+
+	tests := []struct {
+		name, host, path string
+		repo             string // empty if not found
+	}{
+		{"mapped host", "anime.example.com", "/stats", "anime"},
+		{"port and case", "ANIME.example.com:8080", "/stats/", "anime"},
+		{"escaped repository", "art.example.com", "/stats", "fan art"},
+		{"base path", "anime.example.com", "/api/v1/repos/other/stats", "other"},
+		{"unmapped host", "example.com", "/stats", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Host = tt.host
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			var info struct {
+				ID string `json:"id"`
+			}
+			if rec.Code == http.StatusOK {
+				if err := json.NewDecoder(rec.Body).Decode(&info); err != nil {
+					t.Fatalf("malformed response: %v", err)
+				}
+			}
+			if info.ID != tt.repo {
+				t.Errorf("served repository %q (status %d), want %q", info.ID, rec.Code, tt.repo)
+			}
+		})
+	}
+
+	if _, err := NewNeroRouter(repos, nil, map[string]string{"a.example.com": "unknown"}, nil, zap.NewNop()); err == nil {
+		t.Error("host mapped to an unknown repository was accepted")
+	}
+}
+
+func TestHostRoutingEscaping(t *testing.T) {
+	var got *url.URL
+	h := hostRouting(map[string]string{"art.example.com": "fan art"})(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.URL
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/tags/a%2Fb", nil)
+	req.Host = "art.example.com"
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if want := "/api/v1/repos/fan art/tags/a/b"; got.Path != want {
+		t.Errorf("path = %q, want %q", got.Path, want)
+	}
+	if want := "/api/v1/repos/fan%20art/tags/a%2Fb"; got.EscapedPath() != want {
+		t.Errorf("escaped path = %q, want %q", got.EscapedPath(), want)
+	}
+}
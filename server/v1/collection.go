@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+)
+
+func (s *Server) GetRepoCollections(_ context.Context, request v1.GetRepoCollectionsRequestObject) (v1.GetRepoCollectionsResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoCollections400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	cs := r.Collections()
+
+	res := make(v1.GetRepoCollections200JSONResponse, len(cs))
+	for i, c := range cs {
+		c0, err := wrapCollection(c)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = c0
+	}
+
+	return res, nil
+}
+
+func (s *Server) PutRepoCollection(ctx context.Context, request v1.PutRepoCollectionRequestObject) (v1.PutRepoCollectionResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PutRepoCollection400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	c := &repo.Collection{ID: request.Collection, Name: api.MakeString(request.Body.Name)}
+	if request.Body.Meta != nil {
+		m, err := parseMetadata(request.Body.Meta, r.Options().MetaParsing)
+		if err != nil {
+			return v1.PutRepoCollection400JSONResponse(v1.Error{Type: v1.BadRequest, Description: err.Error()}), nil
+		}
+
+		c.Meta = m
+	}
+
+	if err := r.PutCollection(ctx, c); err != nil {
+		return nil, err
+	}
+
+	c0, err := wrapCollection(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.PutRepoCollection200JSONResponse(c0), nil
+}
+
+func (s *Server) DeleteRepoCollection(ctx context.Context, request v1.DeleteRepoCollectionRequestObject) (v1.DeleteRepoCollectionResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.DeleteRepoCollection400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	c, err := r.RemoveCollection(ctx, request.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return v1.DeleteRepoCollection400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown collection"}), nil
+	}
+
+	c0, err := wrapCollection(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.DeleteRepoCollection200JSONResponse(c0), nil
+}
+
+func wrapCollection(c *repo.Collection) (v1.Collection, error) {
+	var (
+		m   = &v1.Collection_Meta{}
+		err error
+	)
+	switch v := wrapMetadata(c.Meta).(type) {
+	case v1.GenericMetadata:
+		err = m.FromGenericMetadata(v)
+	case v1.AnimeMetadata:
+		err = m.FromAnimeMetadata(v)
+	}
+
+	if err != nil {
+		return v1.Collection{}, err
+	}
+
+	return v1.Collection{
+		Id:   c.ID,
+		Name: api.MakeOptString(c.Name),
+		Meta: m,
+	}, nil
+}
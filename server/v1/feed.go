@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"encoding/xml"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"mime"
+	"path/filepath"
+	"time"
+)
+
+// feedTitle derives a human-readable title of media from its metadata, falling back to its ID.
+func feedTitle(m *media.Media) string {
+	switch m := m.Meta.(type) {
+	case *meta.GenericMetadata:
+		if m.Artist != "" {
+			return m.Artist
+		}
+	case *meta.AnimeMetadata:
+		if m.Name != "" {
+			return m.Name
+		}
+	}
+
+	return m.ID.String()
+}
+
+// feedType returns the content type of media for feed attachments.
+func feedType(m *media.Media) string {
+	switch {
+	case m.ContentType != "":
+		return m.ContentType
+	case m.MIME != "":
+		return m.MIME
+	}
+
+	if t := mime.TypeByExtension(filepath.Ext(m.Path)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// rss is the root element of an RSS 2.0 document.
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is an RSS 2.0 channel.
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+// rssItem is an RSS 2.0 channel item.
+type rssItem struct {
+	Title     string        `xml:"title"`
+	Link      string        `xml:"link,omitempty"`
+	GUID      rssGUID       `xml:"guid"`
+	PubDate   string        `xml:"pubDate,omitempty"`
+	Enclosure *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+// rssGUID is a globally unique identifier of an RSS 2.0 item.
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// rssEnclosure is a media object attached to an RSS 2.0 item.
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// makeRSSItem converts media to an RSS 2.0 item.
+func makeRSSItem(m *media.Media, url string) rssItem {
+	item := rssItem{
+		Title: feedTitle(m),
+		Link:  url,
+		GUID:  rssGUID{Value: "urn:uuid:" + m.ID.String()},
+	}
+	if !m.CreatedAt.IsZero() {
+		item.PubDate = m.CreatedAt.Format(time.RFC1123Z)
+	}
+	if url != "" {
+		item.Enclosure = &rssEnclosure{URL: url, Length: m.Size, Type: feedType(m)}
+	}
+
+	return item
+}
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeeds(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, _ := newTestServer(t, r)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = addTestMedia(t, r, start.Add(time.Duration(i)*time.Hour)).ID
+	}
+
+	get := func(path, contentType string) []byte {
+		res, err := http.Get(ts.URL + BasePath + "/repos/test/" + path)
+		if err != nil {
+			t.Fatalf("failed to get feed: %v", err)
+		}
+		defer res.Body.Close()
+
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("failed to read feed: %v", err)
+		}
+		if res.StatusCode != http.StatusOK || !strings.HasPrefix(res.Header.Get("Content-Type"), contentType) {
+			t.Fatalf("feed response = %d %s, want 200 %s: %s", res.StatusCode, res.Header.Get("Content-Type"), contentType, b)
+		}
+		return b
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var feed v1.JSONFeed
+		if err := json.Unmarshal(get("feed.json?limit=3", "application/feed+json"), &feed); err != nil {
+			t.Fatalf("malformed JSON feed: %v", err)
+		}
+		if feed.Version != "https://jsonfeed.org/version/1.1" || feed.Title == "" {
+			t.Errorf("JSON feed lacks its required top-level fields: %+v", feed)
+		}
+		if len(feed.Items) != 3 {
+			t.Fatalf("JSON feed has %d items, want 3", len(feed.Items))
+		}
+		for i, item := range feed.Items {
+			if want := ids[len(ids)-1-i]; item.Id != want.String() {
+				t.Errorf("item %d is %s, want the most recent %s", i, item.Id, want)
+			}
+			if item.DatePublished == nil || item.Attachments == nil || len(*item.Attachments) != 1 || (*item.Attachments)[0].Url == "" {
+				t.Errorf("item %d lacks a publication date or attachment", i)
+			}
+		}
+	})
+
+	t.Run("rss", func(t *testing.T) {
+		var doc rss
+		if err := xml.Unmarshal(get("feed.rss?limit=3", "application/rss+xml"), &doc); err != nil {
+			t.Fatalf("malformed RSS feed: %v", err)
+		}
+		if doc.Version != "2.0" || doc.Channel.Title == "" || doc.Channel.Link == "" || doc.Channel.Description == "" {
+			t.Errorf("RSS channel lacks its required elements: %+v", doc.Channel)
+		}
+		if len(doc.Channel.Items) != 3 {
+			t.Fatalf("RSS feed has %d items, want 3", len(doc.Channel.Items))
+		}
+		for i, item := range doc.Channel.Items {
+			if want := "urn:uuid:" + ids[len(ids)-1-i].String(); item.GUID.Value != want {
+				t.Errorf("item %d is %s, want the most recent %s", i, item.GUID.Value, want)
+			}
+			if _, err := time.Parse(time.RFC1123Z, item.PubDate); err != nil || item.Enclosure == nil {
+				t.Errorf("item %d lacks a valid publication date or enclosure", i)
+			}
+		}
+	})
+}
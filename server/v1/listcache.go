@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api/v1"
+	"sync"
+	"time"
+)
+
+// listCacheEntry is a cached media listing.
+type listCacheEntry struct {
+	res       v1.GetRepo200JSONResponse
+	expiresAt time.Time
+}
+
+// listCache is a size-bounded cache of media listings of a repository, cleared on every change of the repository.
+type listCache struct {
+	ttl  time.Duration
+	size int
+
+	entries    map[string]listCacheEntry
+	generation uint64 // incremented on every change, listings computed before a change aren't cached
+	mu         sync.Mutex
+}
+
+// newListCache creates a listCache for a repository, nil if listing caching is disabled for it.
+func newListCache(r *repo.Repository) *listCache {
+	opts := r.Options()
+	if opts.ListCacheTTL <= 0 {
+		return nil
+	}
+
+	size := opts.ListCacheSize
+	if size <= 0 {
+		size = repo.DefaultListCacheSize
+	}
+
+	c := &listCache{
+		ttl:     opts.ListCacheTTL,
+		size:    size,
+		entries: make(map[string]listCacheEntry, size),
+	}
+	r.Subscribe(c.clear)
+
+	return c
+}
+
+// get looks up a cached listing by its key, returns false if it's not cached.
+// The returned generation needs to be passed to put when caching a freshly computed listing.
+func (c *listCache) get(key string) (v1.GetRepo200JSONResponse, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, c.generation, false
+	}
+	return e.res, c.generation, true
+}
+
+// put caches a listing computed in a generation, unless the repository changed in the meantime.
+// The entries closest to expiring are evicted to make room.
+func (c *listCache) put(key string, res v1.GetRepo200JSONResponse, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	now := time.Now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		var (
+			oldestKey string
+			oldest    time.Time
+		)
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			} else if oldest.IsZero() || e.expiresAt.Before(oldest) {
+				oldestKey, oldest = k, e.expiresAt
+			}
+		}
+		if len(c.entries) >= c.size {
+			delete(c.entries, oldestKey)
+		}
+	}
+
+	c.entries[key] = listCacheEntry{res: res, expiresAt: now.Add(c.ttl)}
+}
+
+// clear drops all cached listings.
+func (c *listCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
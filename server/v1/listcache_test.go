@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api/v1"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestGetRepoListCache(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{ListCacheTTL: time.Hour})
+	srv, err := NewServer([]*repo.Repository{r}, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	if _, err := r.Create(context.Background(), testPNG(t, 1, 1), nil, nil); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	list := func() v1.GetRepo200JSONResponse {
+		t.Helper()
+
+		res, err := srv.GetRepo(context.Background(), v1.GetRepoRequestObject{Repo: "test"})
+		if err != nil {
+			t.Fatalf("failed to list media: %v", err)
+		}
+		return res.(v1.GetRepo200JSONResponse)
+	}
+
+	first, second := list(), list()
+	if len(first) != 1 || len(second) != 1 || &first[0] != &second[0] {
+		t.Error("repeated listing wasn't served from the cache")
+	}
+
+	if _, err := r.Create(context.Background(), testPNG(t, 2, 2), nil, nil); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if res := list(); len(res) != 2 {
+		t.Errorf("listed %d items after an upload, want 2", len(res))
+	}
+}
+
+func TestListCache(t *testing.T) {
+	c := &listCache{ttl: time.Hour, size: 2, entries: make(map[string]listCacheEntry)}
+
+	_, gen, _ := c.get("a")
+	c.put("a", v1.GetRepo200JSONResponse{}, gen)
+	c.put("b", v1.GetRepo200JSONResponse{}, gen)
+	c.entries["a"] = listCacheEntry{expiresAt: time.Now().Add(time.Minute)}
+	c.put("c", v1.GetRepo200JSONResponse{}, gen)
+	if len(c.entries) != 2 {
+		t.Errorf("cache holds %d entries, want at most 2", len(c.entries))
+	}
+	if _, _, ok := c.get("a"); ok {
+		t.Error("entry closest to expiring wasn't evicted")
+	}
+
+	// a listing computed before a change isn't cached
+	_, gen, _ = c.get("d")
+	c.clear()
+	c.put("d", v1.GetRepo200JSONResponse{}, gen)
+	if _, _, ok := c.get("d"); ok {
+		t.Error("stale listing was cached")
+	}
+
+	c.ttl = -time.Second
+	_, gen, _ = c.get("e")
+	c.put("e", v1.GetRepo200JSONResponse{}, gen)
+	if _, _, ok := c.get("e"); ok {
+		t.Error("expired listing was served")
+	}
+}
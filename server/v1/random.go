@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"math/rand"
+	"slices"
+)
+
+func (s *Server) GetRandom(ctx context.Context, request v1.GetRandomRequestObject) (v1.GetRandomResponseObject, error) {
+	rs := s.sortedRepos()
+	if ids := api.MakeSlice(request.Params.Repos); len(ids) > 0 {
+		rs = make([]*repo.Repository, 0, len(ids))
+		for _, id := range ids {
+			r, ok := s.repos[id]
+			if !ok {
+				return v1.GetRandom400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository " + id}), nil
+			}
+			if !slices.Contains(rs, r) {
+				rs = append(rs, r)
+			}
+		}
+	}
+
+	count := api.MakeInt(request.Params.Count)
+	if count <= 0 {
+		count = 1
+	} else if count > maxRandom {
+		count = maxRandom
+	}
+
+	var equal bool
+	switch spread := request.Params.Spread; {
+	case spread == nil || *spread == v1.Size:
+	case *spread == v1.Equal:
+		equal = true
+	default:
+		return v1.GetRandom400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown spread"}), nil
+	}
+
+	var (
+		pools = make([][]*media.Media, len(rs))
+		sizes = make([]int, len(rs))
+	)
+	for i, r := range rs {
+		pools[i], sizes[i] = randomPool(r, count)
+	}
+
+	type pick struct {
+		r *repo.Repository
+		m *media.Media
+	}
+
+	var picks []pick
+	for i, n := range spreadRandom(count, sizes, equal) {
+		for _, m := range pools[i][:min(n, len(pools[i]))] { // the repository may have shrunk in the meantime
+			picks = append(picks, pick{r: rs[i], m: m})
+		}
+	}
+	rand.Shuffle(len(picks), func(i, j int) {
+		picks[i], picks[j] = picks[j], picks[i]
+	})
+
+	res := make(v1.GetRandom200JSONResponse, len(picks))
+	for i, p := range picks {
+		m0, err := s.describeMedia(ctx, p.r, p.m)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = m0
+	}
+
+	return res, nil
+}
+
+// randomPool picks up to n random media out of a repository, respecting its unknown format setting.
+// Returns the picks along with the amount of media eligible for picking.
+func randomPool(r *repo.Repository, n int) ([]*media.Media, int) {
+	items := r.Items()
+	if includeUnknown(r, nil) {
+		return r.Random(n), len(items)
+	}
+
+	var size int
+	for _, m := range items {
+		if m.Format != media.FormatUnknown {
+			size++
+		}
+	}
+	return r.WeightedRandom(n, repo.KnownWeight(repo.UniformWeight)), size
+}
+
+// spreadRandom spreads n picks without replacement across pools of the given sizes, returning the picks per pool.
+// Every pick chooses a pool with a probability proportional to its remaining size,
+// or uniformly among the non-exhausted pools if equal is true.
+func spreadRandom(n int, sizes []int, equal bool) []int {
+	var (
+		remaining = slices.Clone(sizes)
+		picks     = make([]int, len(sizes))
+	)
+	weight := func(i int) int {
+		if equal && remaining[i] > 0 {
+			return 1
+		}
+		return remaining[i]
+	}
+
+	for ; n > 0; n-- {
+		var total int
+		for i := range remaining {
+			total += weight(i)
+		}
+		if total == 0 {
+			break // all pools are exhausted
+		}
+
+		x := rand.Intn(total)
+		for i := range remaining {
+			if w := weight(i); x >= w {
+				x -= w
+				continue
+			}
+
+			picks[i]++
+			remaining[i]--
+			break
+		}
+	}
+
+	return picks
+}
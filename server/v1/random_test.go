@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestGetRandomSpread(t *testing.T) {
+	var (
+		a = newTestRepo(t, "a", nil, nil)
+		b = newTestRepo(t, "b", nil, nil)
+		c = newTestRepo(t, "c", nil, nil) // not picked from
+	)
+	for i := 0; i < 30; i++ {
+		addTestMedia(t, a, time.Now())
+		addTestMedia(t, c, time.Now())
+		if i < 10 {
+			addTestMedia(t, b, time.Now())
+		}
+	}
+	_, client := newTestServer(t, a, b, c)
+
+	tests := []struct {
+		spread   v1.RandomSpread
+		min, max float64 // bounds of the share of picks from a
+	}{
+		{v1.Size, 0.65, 0.85}, // 3/4 expected
+		{v1.Equal, 0.4, 0.6},  // 1/2 expected
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.spread), func(t *testing.T) {
+			var (
+				repos  = []string{"a", "b"}
+				count  = 10
+				counts = make(map[string]int)
+			)
+			for i := 0; i < 50; i++ {
+				res, err := client.GetRandomWithResponse(context.Background(), &v1.GetRandomParams{Repos: &repos, Count: &count, Spread: &tt.spread})
+				if err != nil {
+					t.Fatalf("failed to pick media: %v", err)
+				}
+				if res.JSON200 == nil || len(*res.JSON200) != count {
+					t.Fatalf("status = %d, want %d picks: %s", res.StatusCode(), count, res.Body)
+				}
+
+				seen := make(map[uuid.UUID]bool)
+				for _, m := range *res.JSON200 {
+					counts[api.MakeString(m.Repo)]++
+					if seen[m.Id] {
+						t.Errorf("media %s was picked twice", m.Id)
+					}
+					seen[m.Id] = true
+				}
+			}
+
+			if counts["c"] > 0 || counts[""] > 0 {
+				t.Errorf("picks didn't come only from the requested repositories: %v", counts)
+			}
+			if share := float64(counts["a"]) / float64(counts["a"]+counts["b"]); share < tt.min || share > tt.max {
+				t.Errorf("share of picks from a = %.2f, want within [%.2f, %.2f]", share, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -1,15 +1,47 @@
 package v1
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo"
 	"github.com/cephxdev/nero/repo/media"
 	"github.com/cephxdev/nero/repo/media/meta"
 	"github.com/cephxdev/nero/server/api"
 	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+	"hash"
+	"io"
+	"math"
+	"mime"
 	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// maxFacets is the maximum amount of facets returned in a response.
+	maxFacets = 1000
+	// maxRandom is the maximum amount of random media returned in a response.
+	maxRandom = 100
+	// maxExistsBatch is the maximum amount of media IDs checked in a batch existence check.
+	maxExistsBatch = 1000
+	// defaultFeedLimit and maxFeedLimit are the default and maximum amounts of media listed in feeds.
+	defaultFeedLimit, maxFeedLimit = 20, 100
+	// defaultThumbnailSize and maxThumbnailSize are the default and maximum thumbnail box sizes in pixels.
+	defaultThumbnailSize, maxThumbnailSize = 256, 1024
+
+	// recencyScale is the age at which media weighs half as much as new media in recency-weighted selection.
+	recencyScale = 30 * 24 * time.Hour
 )
 
 var (
@@ -20,37 +52,571 @@ var (
 	}
 )
 
-func (s *Server) PostRepo(_ context.Context, request v1.PostRepoRequestObject) (v1.PostRepoResponseObject, error) {
+func (s *Server) GetHealthz(_ context.Context, _ v1.GetHealthzRequestObject) (v1.GetHealthzResponseObject, error) {
+	rs := s.sortedRepos()
+
+	res := v1.Health{Status: v1.Ok, Repos: make([]v1.RepoHealth, len(rs))}
+	for i, r := range rs {
+		status := v1.Ok
+		if r.Degraded() {
+			status, res.Status = v1.Degraded, v1.Degraded
+		}
+
+		var (
+			ls = r.LoadSummary()
+			u  = wrapUsage(r.Usage())
+		)
+		res.Repos[i] = v1.RepoHealth{
+			Id:     r.ID(),
+			Status: status,
+			Usage:  &u,
+			Load: &v1.LoadSummary{
+				Unreadable: ls.Unreadable,
+				Duplicates: ls.Duplicates,
+				Collisions: ls.Collisions,
+				Symlinks:   ls.Symlinks,
+				Missing:    ls.Missing,
+			},
+		}
+	}
+
+	return v1.GetHealthz200JSONResponse(res), nil
+}
+
+func (s *Server) GetRepos(_ context.Context, request v1.GetReposRequestObject) (v1.GetReposResponseObject, error) {
+	var (
+		metaType    meta.Type
+		hasMetaType = request.Params.MetaType != nil
+	)
+	if hasMetaType {
+		var ok bool
+		if metaType, ok = meta.ParseType(string(*request.Params.MetaType)); !ok {
+			return v1.GetRepos400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown metadata type"}), nil
+		}
+	}
+
+	res := make(v1.GetRepos200JSONResponse, 0, len(s.repos))
+	for _, r := range s.sortedRepos() {
+		if request.Params.Writable != nil && r.Writable() != *request.Params.Writable {
+			continue
+		}
+		if hasMetaType && !slices.Contains(r.MetaTypes(), metaType) {
+			continue
+		}
+
+		res = append(res, wrapRepo(r))
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetRepo(ctx context.Context, request v1.GetRepoRequestObject) (v1.GetRepoResponseObject, error) {
 	r, ok := s.repos[request.Repo]
 	if !ok {
-		return v1.PostRepo400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+		return v1.GetRepo400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	q := r.DefaultQuery()
+	if request.Params != (v1.GetRepoParams{}) {
+		q = &repo.Query{
+			CreatedAfter:   api.MakeTime(request.Params.CreatedAfter),
+			CreatedBefore:  api.MakeTime(request.Params.CreatedBefore),
+			Offset:         api.MakeInt(request.Params.Offset),
+			Limit:          api.MakeInt(request.Params.Limit),
+			Pinned:         request.Params.Pinned,
+			ExcludeUnknown: !includeUnknown(r, request.Params.IncludeUnknown),
+			Collection:     api.MakeString(request.Params.Collection),
+			Batch:          api.MakeString(request.Params.Batch),
+			HashPrefix:     strings.ToLower(api.MakeString(request.Params.HashPrefix)),
+		}
+		if !isHex(q.HashPrefix) {
+			return v1.GetRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "hash prefix is not hexadecimal"}), nil
+		}
+		for field, value := range map[repo.FacetField]*string{
+			repo.FacetArtist: request.Params.Artist,
+			repo.FacetSource: request.Params.Source,
+			repo.FacetName:   request.Params.Name,
+		} {
+			if value == nil {
+				continue
+			}
+			if q.Meta == nil {
+				q.Meta = make(map[repo.FacetField]string, 1)
+			}
+
+			q.Meta[field] = *value
+		}
+		switch missing := request.Params.Missing; {
+		case missing == nil:
+		case *missing == v1.Hash || *missing == v1.Dimensions || *missing == v1.Mime:
+			q.Missing = repo.DerivedField(*missing)
+		default:
+			return v1.GetRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown derived field"}), nil
+		}
+		switch sort := request.Params.Sort; {
+		case sort == nil:
+		case *sort == v1.CreatedAsc || *sort == v1.CreatedDesc || *sort == v1.Popular:
+			q.Order = repo.Order(*sort)
+		default:
+			return v1.GetRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown sort order"}), nil
+		}
+	}
+
+	var (
+		c          = s.lists[r.ID()]
+		key        string
+		generation uint64
+	)
+	if c != nil {
+		key = s.listCacheKey(ctx)
+
+		res, gen, ok := c.get(key)
+		if ok {
+			return res, nil
+		}
+		generation = gen
+	}
+
+	ms := r.List(q)
+
+	res := make(v1.GetRepo200JSONResponse, len(ms))
+	for i, m := range ms {
+		m0, err := s.describeMedia(ctx, r, m)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = m0
+	}
+
+	if c != nil {
+		c.put(key, res, generation)
+	}
+	return res, nil
+}
+
+// listCacheKey builds the listing cache key of a request,
+// covering everything the listing depends on, i.e. the query parameters and the base URL of media URLs.
+func (s *Server) listCacheKey(ctx context.Context) string {
+	req := api.Request(ctx)
+
+	key := fmt.Sprintf("%s\x00%t\x00%t", s.apiURL(ctx, ""), api.UnixTimestamps(req), api.OmitMeta(req))
+	if req != nil {
+		key += "\x00" + req.URL.RawQuery
+	}
+	return key
+}
+
+func (s *Server) GetRepoManifest(_ context.Context, request v1.GetRepoManifestRequestObject) (v1.GetRepoManifestResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoManifest400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
 	}
 
 	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
 		return nil, unauthorizedError
 	}
 
-	var m meta.Metadata
-	if request.Body.Meta != nil {
-		m0, err := request.Body.Meta.ValueByDiscriminator()
+	return &manifestRes{items: r.List(&repo.Query{})}, nil
+}
+
+func (s *Server) GetRepoFeedJson(ctx context.Context, request v1.GetRepoFeedJsonRequestObject) (v1.GetRepoFeedJsonResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoFeedJson400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	ms := r.List(&repo.Query{
+		Limit:          feedLimit(request.Params.Limit),
+		Order:          repo.OrderCreatedDesc,
+		ExcludeUnknown: r.Options().HideUnknown,
+	})
+
+	items := make([]v1.JSONFeedItem, len(ms))
+	for i, m := range ms {
+		var (
+			url  = s.mediaURL(ctx, r.ID(), m)
+			size = m.Size
+		)
+		items[i] = v1.JSONFeedItem{
+			Id:            m.ID.String(),
+			Url:           api.MakeOptString(url),
+			Title:         api.MakeOptString(feedTitle(m)),
+			DatePublished: api.MakeOptTime(m.CreatedAt),
+		}
+		if url != "" {
+			items[i].Attachments = &[]v1.JSONFeedAttachment{{Url: url, MimeType: feedType(m), SizeInBytes: &size}}
+		}
+	}
+
+	return v1.GetRepoFeedJson200ApplicationFeedPlusJSONResponse{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       r.ID(),
+		HomePageUrl: api.MakeOptString(s.apiURL(ctx, "/repos/"+r.ID())),
+		FeedUrl:     api.MakeOptString(s.apiURL(ctx, "/repos/"+r.ID()+"/feed.json")),
+		Items:       items,
+	}, nil
+}
+
+func (s *Server) GetRepoFeedRss(ctx context.Context, request v1.GetRepoFeedRssRequestObject) (v1.GetRepoFeedRssResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoFeedRss400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	ms := r.List(&repo.Query{
+		Limit:          feedLimit(request.Params.Limit),
+		Order:          repo.OrderCreatedDesc,
+		ExcludeUnknown: r.Options().HideUnknown,
+	})
+
+	doc := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       r.ID(),
+			Link:        s.apiURL(ctx, "/repos/"+r.ID()),
+			Description: "Recent media in the " + r.ID() + " repository",
+			Items:       make([]rssItem, len(ms)),
+		},
+	}
+	for i, m := range ms {
+		doc.Channel.Items[i] = makeRSSItem(m, s.mediaURL(ctx, r.ID(), m))
+	}
+
+	b, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode feed")
+	}
+
+	b = append([]byte(xml.Header), b...)
+	return v1.GetRepoFeedRss200ApplicationrssXmlResponse{Body: bytes.NewReader(b), ContentLength: int64(len(b))}, nil
+}
+
+// includeUnknown returns whether media of an unknown format should be included in a response,
+// falling back to the repository setting if the request doesn't specify it.
+func includeUnknown(r *repo.Repository, include *bool) bool {
+	if include != nil {
+		return *include
+	}
+	return !r.Options().HideUnknown
+}
+
+// feedLimit clamps the requested amount of feed items, defaulting to defaultFeedLimit.
+func feedLimit(limit *int) int {
+	switch n := api.MakeInt(limit); {
+	case n <= 0:
+		return defaultFeedLimit
+	case n > maxFeedLimit:
+		return maxFeedLimit
+	default:
+		return n
+	}
+}
+
+func (s *Server) GetRepoExportTar(_ context.Context, request v1.GetRepoExportTarRequestObject) (v1.GetRepoExportTarResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoExportTar400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkAdminKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	return &exportRes{
+		repo:   r,
+		format: repo.ArchiveTar,
+		items: r.List(&repo.Query{
+			CreatedAfter:  api.MakeTime(request.Params.CreatedAfter),
+			CreatedBefore: api.MakeTime(request.Params.CreatedBefore),
+			Offset:        api.MakeInt(request.Params.Offset),
+			Limit:         api.MakeInt(request.Params.Limit),
+		}),
+	}, nil
+}
+
+func (s *Server) GetRepoExportZip(_ context.Context, request v1.GetRepoExportZipRequestObject) (v1.GetRepoExportZipResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoExportZip400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkAdminKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	return &exportRes{
+		repo:   r,
+		format: repo.ArchiveZip,
+		items: r.List(&repo.Query{
+			CreatedAfter:  api.MakeTime(request.Params.CreatedAfter),
+			CreatedBefore: api.MakeTime(request.Params.CreatedBefore),
+			Offset:        api.MakeInt(request.Params.Offset),
+			Limit:         api.MakeInt(request.Params.Limit),
+		}),
+	}, nil
+}
+
+func (s *Server) PostRepoImportTar(ctx context.Context, request v1.PostRepoImportTarRequestObject) (v1.PostRepoImportTarResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepoImportTar400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+	if !s.drain.Begin() {
+		return v1.PostRepoImportTar503JSONResponse(v1.Error{Type: v1.Unavailable, Description: "server is shutting down"}), nil
+	}
+	defer s.drain.Done()
+
+	var policy repo.ImportPolicy
+	if request.Params.Collision != nil {
+		switch *request.Params.Collision {
+		case v1.Skip, v1.Overwrite, v1.NewId:
+			policy = repo.ImportPolicy(*request.Params.Collision)
+		default:
+			return v1.PostRepoImportTar400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown collision policy"}), nil
+		}
+	}
+
+	imported, skipped, err := r.Import(ctx, request.Body, repo.ArchiveTar, policy)
+	if err != nil {
+		var eim *repo.ErrInvalidMedia
+		if errors.As(err, &eim) {
+			return v1.PostRepoImportTar422JSONResponse(v1.Error{Type: v1.InvalidMedia, Description: eim.Error()}), nil
+		}
+
+		var eqe *repo.ErrQuotaExceeded
+		if errors.As(err, &eqe) {
+			return v1.PostRepoImportTar507JSONResponse(v1.Error{Type: v1.QuotaExceeded, Description: eqe.Error()}), nil
+		}
+
+		return nil, err
+	}
+
+	return v1.PostRepoImportTar200JSONResponse(v1.ImportResult{Imported: imported, Skipped: skipped}), nil
+}
+
+func (s *Server) PostRepoImportZip(ctx context.Context, request v1.PostRepoImportZipRequestObject) (v1.PostRepoImportZipResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepoImportZip400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+	if !s.drain.Begin() {
+		return v1.PostRepoImportZip503JSONResponse(v1.Error{Type: v1.Unavailable, Description: "server is shutting down"}), nil
+	}
+	defer s.drain.Done()
+
+	var policy repo.ImportPolicy
+	if request.Params.Collision != nil {
+		switch *request.Params.Collision {
+		case v1.Skip, v1.Overwrite, v1.NewId:
+			policy = repo.ImportPolicy(*request.Params.Collision)
+		default:
+			return v1.PostRepoImportZip400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown collision policy"}), nil
+		}
+	}
+
+	imported, skipped, err := r.Import(ctx, request.Body, repo.ArchiveZip, policy)
+	if err != nil {
+		var eim *repo.ErrInvalidMedia
+		if errors.As(err, &eim) {
+			return v1.PostRepoImportZip422JSONResponse(v1.Error{Type: v1.InvalidMedia, Description: eim.Error()}), nil
+		}
+
+		var eqe *repo.ErrQuotaExceeded
+		if errors.As(err, &eqe) {
+			return v1.PostRepoImportZip507JSONResponse(v1.Error{Type: v1.QuotaExceeded, Description: eqe.Error()}), nil
+		}
+
+		return nil, err
+	}
+
+	return v1.PostRepoImportZip200JSONResponse(v1.ImportResult{Imported: imported, Skipped: skipped}), nil
+}
+
+func (s *Server) GetRepoFacets(_ context.Context, request v1.GetRepoFacetsRequestObject) (v1.GetRepoFacetsResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoFacets400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	switch request.Params.Field {
+	case v1.Artist, v1.Source, v1.Name:
+	default:
+		return v1.GetRepoFacets400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown facet field"}), nil
+	}
+
+	limit := api.MakeInt(request.Params.Limit)
+	if limit <= 0 || limit > maxFacets {
+		limit = maxFacets
+	}
+
+	fs := r.Facets(repo.FacetField(request.Params.Field), limit)
+
+	res := make(v1.GetRepoFacets200JSONResponse, len(fs))
+	for i, f := range fs {
+		res[i] = v1.Facet{Value: f.Value, Count: f.Count}
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetRepoRandom(ctx context.Context, request v1.GetRepoRandomRequestObject) (v1.GetRepoRandomResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoRandom400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	count := api.MakeInt(request.Params.Count)
+	if count <= 0 {
+		count = 1
+	} else if count > maxRandom {
+		count = maxRandom
+	}
+
+	var (
+		ms      []*media.Media
+		exclude = api.MakeSlice(request.Params.Exclude)
+	)
+	switch weight := request.Params.Weight; {
+	case (weight == nil || *weight == v1.Uniform) && includeUnknown(r, request.Params.IncludeUnknown):
+		ms = r.ExcludeRandom(count, exclude)
+	case weight == nil || *weight == v1.Uniform:
+		ms = excludeWeightedRandom(r, count, repo.KnownWeight(repo.UniformWeight), exclude)
+	case *weight == v1.Recency:
+		weight := repo.RecencyWeight(recencyScale)
+		if !includeUnknown(r, request.Params.IncludeUnknown) {
+			weight = repo.KnownWeight(weight)
+		}
+
+		ms = excludeWeightedRandom(r, count, weight, exclude)
+	default:
+		return v1.GetRepoRandom400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown weight"}), nil
+	}
+	if len(ms) == 0 && r.Options().EmptyRandomNotFound {
+		return v1.GetRepoRandom404JSONResponse(v1.Error{Type: v1.NotFound, Description: "no media to pick"}), nil
+	}
+
+	res := make(v1.GetRepoRandom200JSONResponse, len(ms))
+	for i, m := range ms {
+		m0, err := s.describeMedia(ctx, r, m)
 		if err != nil {
 			return nil, err
 		}
 
-		m = unwrapMetadata(m0)
+		res[i] = m0
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetRepoStats(_ context.Context, request v1.GetRepoStatsRequestObject) (v1.GetRepoStatsResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoStats400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	return v1.GetRepoStats200JSONResponse(wrapRepo(r)), nil
+}
+
+func (s *Server) PostRepo(ctx context.Context, request v1.PostRepoRequestObject) (v1.PostRepoResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepo400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	var (
+		token     = api.MakeString(request.Params.Token)
+		nonce     string
+		expiresAt time.Time
+	)
+	if token != "" {
+		if nonce, expiresAt, ok = verifyUploadToken(r, token); !ok {
+			return nil, unauthorizedError
+		}
+	} else if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+	if !s.drain.Begin() {
+		return v1.PostRepo503JSONResponse(v1.Error{Type: v1.Unavailable, Description: "server is shutting down"}), nil
+	}
+	defer s.drain.Done()
+
+	release, ok := s.acquireUpload(ctx, r)
+	if !ok {
+		return v1.PostRepo429JSONResponse(v1.Error{Type: v1.TooManyRequests, Description: "too many concurrent uploads"}), nil
+	}
+	defer release()
+
+	// used up only once the upload is attempted, so it can be retried if the server is busy
+	if token != "" && !s.tokens.use(nonce, expiresAt) {
+		return nil, unauthorizedError
+	}
+
+	var m meta.Metadata
+	if request.Body.Meta != nil {
+		var err error
+		if m, err = parseMetadata(request.Body.Meta, r.Options().MetaParsing); err != nil {
+			return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: err.Error()}), nil
+		}
 	}
 
-	d, err := base64.StdEncoding.DecodeString(request.Body.Data)
+	expiry, err := parseExpiry(request.Body)
 	if err != nil {
-		return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "failed to decode data"}), nil
+		return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: err.Error()}), nil
 	}
 
-	m0, err := r.Create(d, m)
+	// decode while streaming to disk, saving a whole decoded copy next to the encoded data,
+	// except for images, which CreateFrom reads back whole for processing
+	dv := newDigestVerifier(request.Params)
+	m0, err := r.CreateFrom(ctx, dv.reader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(request.Body.Data))), m, &repo.CreateOptions{
+		Filename:   api.MakeString(request.Body.Filename),
+		Uploader:   api.Uploader(api.Request(ctx)),
+		Collection: api.MakeString(request.Body.Collection),
+		Batch:      api.MakeString(request.Body.Batch),
+		Expiry:     expiry,
+		Verify:     dv.verify,
+	})
 	if err != nil {
+		var cie base64.CorruptInputError
+		if errors.As(err, &cie) {
+			return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "failed to decode data"}), nil
+		}
+		if errors.Is(err, errDigestMismatch) {
+			return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "data checksum mismatch"}), nil
+		}
+
+		var eim *repo.ErrInvalidMedia
+		if errors.As(err, &eim) {
+			return v1.PostRepo422JSONResponse(v1.Error{Type: v1.InvalidMedia, Description: eim.Error()}), nil
+		}
+
+		var emt *repo.ErrMetaTypeNotAllowed
+		if errors.As(err, &emt) {
+			return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: emt.Error()}), nil
+		}
+
+		var euc *repo.ErrUnknownCollection
+		if errors.As(err, &euc) {
+			return v1.PostRepo400JSONResponse(v1.Error{Type: v1.BadRequest, Description: euc.Error()}), nil
+		}
+
+		var eqe *repo.ErrQuotaExceeded
+		if errors.As(err, &eqe) {
+			return v1.PostRepo507JSONResponse(v1.Error{Type: v1.QuotaExceeded, Description: eqe.Error()}), nil
+		}
+
 		return nil, err
 	}
 
-	m1, err := wrapMedia(m0)
+	m1, err := s.describeMedia(ctx, r, m0)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +624,236 @@ func (s *Server) PostRepo(_ context.Context, request v1.PostRepoRequestObject) (
 	return v1.PostRepo200JSONResponse(m1), nil
 }
 
-func (s *Server) DeleteRepoId(_ context.Context, request v1.DeleteRepoIdRequestObject) (v1.DeleteRepoIdResponseObject, error) {
+func (s *Server) GetRepoId(_ context.Context, request v1.GetRepoIdRequestObject) (v1.GetRepoIdResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	m := r.Get(request.Id)
+	if m == nil {
+		return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	var order repo.Order
+	switch sort := request.Params.Sort; {
+	case sort == nil:
+	case *sort == v1.CreatedAsc || *sort == v1.CreatedDesc || *sort == v1.Popular:
+		order = repo.Order(*sort)
+	default:
+		return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "unknown sort order"}), nil
+	}
+
+	if request.Params.W == nil && request.Params.H == nil && request.Params.Fit == nil {
+		r.Prefetch(m, order)
+		return &fileRes{repo: r, item: m}, nil
+	}
+
+	ro := &repo.ResizeOptions{Width: api.MakeInt(request.Params.W), Height: api.MakeInt(request.Params.H)}
+	if request.Params.Fit != nil {
+		ro.Fit = repo.Fit(*request.Params.Fit)
+	}
+	if err := r.CheckResize(ro); err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "resizing is disabled"}), nil
+		}
+
+		return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: err.Error()}), nil
+	}
+	if !r.Resizable(m) {
+		return v1.GetRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "resizing is unsupported for this media"}), nil
+	}
+
+	r.Prefetch(m, order)
+	return &fileRes{repo: r, item: m, resize: ro}, nil
+}
+
+func (s *Server) PostRepoExistsBatch(_ context.Context, request v1.PostRepoExistsBatchRequestObject) (v1.PostRepoExistsBatchResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepoExistsBatch400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	var ids []uuid.UUID
+	if request.Body != nil {
+		ids = *request.Body
+	}
+	if len(ids) > maxExistsBatch {
+		return v1.PostRepoExistsBatch400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "too many ids"}), nil
+	}
+
+	res := make(v1.PostRepoExistsBatch200JSONResponse, len(ids))
+	for i, ok := range r.Exists(ids) {
+		res[ids[i].String()] = ok
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetRepoIdThumbnail(ctx context.Context, request v1.GetRepoIdThumbnailRequestObject) (v1.GetRepoIdThumbnailResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoIdThumbnail400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	m := r.Get(request.Id)
+	if m == nil {
+		return v1.GetRepoIdThumbnail400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	size := api.MakeInt(request.Params.Size)
+	if size <= 0 {
+		size = defaultThumbnailSize
+	} else if size > maxThumbnailSize {
+		size = maxThumbnailSize
+	}
+
+	b, err := r.Thumbnail(ctx, m, size)
+	if err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			return v1.GetRepoIdThumbnail400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "thumbnails are unsupported for this media"}), nil
+		}
+
+		return nil, err
+	}
+
+	return v1.GetRepoIdThumbnail200ImagejpegResponse{Body: bytes.NewReader(b), ContentLength: int64(len(b))}, nil
+}
+
+func (s *Server) GetRepoIdLocation(_ context.Context, request v1.GetRepoIdLocationRequestObject) (v1.GetRepoIdLocationResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.GetRepoIdLocation400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkAdminKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	m := r.Get(request.Id)
+	if m == nil {
+		return v1.GetRepoIdLocation400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	fi, err := os.Stat(m.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat media")
+	}
+
+	return v1.GetRepoIdLocation200JSONResponse(v1.Location{
+		Path:     m.Path,
+		Size:     fi.Size(),
+		Original: api.MakeOptString(m.Original),
+	}), nil
+}
+
+func (s *Server) PatchRepoId(ctx context.Context, request v1.PatchRepoIdRequestObject) (v1.PatchRepoIdResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	key := api.MakeString(request.Params.XNeroKey)
+	if !checkKey(r, key) || (request.Body.ContentType != nil && !checkAdminKey(r, key)) {
+		return nil, unauthorizedError
+	}
+
+	u := &repo.Update{ContentType: request.Body.ContentType, Collection: request.Body.Collection}
+	if u.ContentType != nil && *u.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(*u.ContentType); err != nil {
+			return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "malformed content type"}), nil
+		}
+	}
+	if request.Body.Meta != nil {
+		m, err := parseMetadata(request.Body.Meta, r.Options().MetaParsing)
+		if err != nil {
+			return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: err.Error()}), nil
+		}
+
+		u.Meta = m
+	}
+
+	m, err := r.Update(ctx, request.Id, u)
+	if err != nil {
+		var emt *repo.ErrMetaTypeNotAllowed
+		if errors.As(err, &emt) {
+			return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: emt.Error()}), nil
+		}
+
+		var euc *repo.ErrUnknownCollection
+		if errors.As(err, &euc) {
+			return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.BadRequest, Description: euc.Error()}), nil
+		}
+
+		return nil, err
+	}
+	if m == nil {
+		return v1.PatchRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	m0, err := s.describeMedia(ctx, r, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.PatchRepoId200JSONResponse(m0), nil
+}
+
+func (s *Server) PostRepoIdPin(ctx context.Context, request v1.PostRepoIdPinRequestObject) (v1.PostRepoIdPinResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepoIdPin400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	pinned := true
+	m, err := r.Update(ctx, request.Id, &repo.Update{Pinned: &pinned})
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return v1.PostRepoIdPin400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	m0, err := s.describeMedia(ctx, r, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.PostRepoIdPin200JSONResponse(m0), nil
+}
+
+func (s *Server) DeleteRepoIdPin(ctx context.Context, request v1.DeleteRepoIdPinRequestObject) (v1.DeleteRepoIdPinResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.DeleteRepoIdPin400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	pinned := false
+	m, err := r.Update(ctx, request.Id, &repo.Update{Pinned: &pinned})
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return v1.DeleteRepoIdPin400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
+	}
+
+	m0, err := s.describeMedia(ctx, r, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1.DeleteRepoIdPin200JSONResponse(m0), nil
+}
+
+func (s *Server) DeleteRepoId(ctx context.Context, request v1.DeleteRepoIdRequestObject) (v1.DeleteRepoIdResponseObject, error) {
 	r, ok := s.repos[request.Repo]
 	if !ok {
 		return v1.DeleteRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
@@ -73,11 +868,11 @@ func (s *Server) DeleteRepoId(_ context.Context, request v1.DeleteRepoIdRequestO
 		return v1.DeleteRepoId400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown item id"}), nil
 	}
 
-	if err := r.Remove(request.Id); err != nil {
+	if err := r.Remove(ctx, request.Id); err != nil {
 		return nil, err
 	}
 
-	m0, err := wrapMedia(m)
+	m0, err := s.describeMedia(ctx, r, m)
 	if err != nil {
 		return nil, err
 	}
@@ -85,26 +880,251 @@ func (s *Server) DeleteRepoId(_ context.Context, request v1.DeleteRepoIdRequestO
 	return v1.DeleteRepoId200JSONResponse(m0), nil
 }
 
-func wrapMedia(m *media.Media) (v1.Media, error) {
+func (s *Server) DeleteRepoBatch(ctx context.Context, request v1.DeleteRepoBatchRequestObject) (v1.DeleteRepoBatchResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.DeleteRepoBatch400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	ms, err := r.RemoveBatch(ctx, request.Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(v1.DeleteRepoBatch200JSONResponse, len(ms))
+	for i, m := range ms {
+		if res[i], err = s.describeMedia(ctx, r, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+type fileRes struct {
+	repo   *repo.Repository
+	item   *media.Media
+	resize *repo.ResizeOptions // nil if the content is served as is
+}
+
+func (fr *fileRes) VisitGetRepoIdResponse(w http.ResponseWriter, r *http.Request) (err error) {
 	var (
-		m0  = &v1.Media_Meta{}
-		err error
+		f    *repo.File
+		name string
 	)
-	switch v := wrapMetadata(m.Meta).(type) {
-	case v1.GenericMetadata:
-		err = m0.FromGenericMetadata(v)
-	case v1.AnimeMetadata:
-		err = m0.FromAnimeMetadata(v)
+	if fr.resize != nil {
+		f, name, err = api.OpenResized(w, r, fr.repo, fr.item, *fr.resize)
+	} else {
+		f, name, err = api.OpenFile(w, r, fr.repo, fr.item)
 	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err0 := f.Close(); err0 != nil {
+			err = multierr.Append(err, errors.Wrap(err0, "failed to close file"))
+		}
+	}()
+
+	http.ServeContent(w, r, name, f.ModTime, f)
+	return err
+}
+
+type exportRes struct {
+	repo   *repo.Repository
+	format repo.ArchiveFormat
+	items  []*media.Media
+}
+
+func (er *exportRes) VisitGetRepoExportTarResponse(w http.ResponseWriter, _ *http.Request) error {
+	return er.write(w, "application/x-tar")
+}
+
+func (er *exportRes) VisitGetRepoExportZipResponse(w http.ResponseWriter, _ *http.Request) error {
+	return er.write(w, "application/zip")
+}
 
+func (er *exportRes) write(w http.ResponseWriter, contentType string) error {
+	w.Header().Set("Content-Type", contentType)
+	api.SetAttachment(w.Header(), er.repo.ID()+"."+string(er.format))
+	w.WriteHeader(200)
+
+	return er.repo.Export(w, er.format, er.items)
+}
+
+type manifestRes struct {
+	items []*media.Media
+}
+
+func (mr *manifestRes) VisitGetRepoManifestResponse(w http.ResponseWriter, _ *http.Request) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(200)
+
+	enc := json.NewEncoder(w)
+	for _, m := range mr.items {
+		err := enc.Encode(v1.ManifestEntry{
+			Id:     m.ID,
+			Format: wrapFormat(m.Format),
+			Hash:   api.MakeOptString(m.Hash),
+			Size:   m.Size,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to write manifest entry")
+		}
+	}
+
+	return nil
+}
+
+// excludeWeightedRandom picks N weighted random media, avoiding the excluded IDs like repo.Repository.ExcludeRandom.
+// If there isn't enough other media, excluded media is picked to fill up the result.
+func excludeWeightedRandom(r *repo.Repository, n int, weight repo.WeightFunc, exclude []uuid.UUID) []*media.Media {
+	if len(exclude) == 0 {
+		return r.WeightedRandom(n, weight)
+	}
+
+	excluded := make(map[uuid.UUID]struct{}, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+
+	res := r.WeightedRandom(n, func(m *media.Media) float64 {
+		if _, ok := excluded[m.ID]; ok {
+			return 0
+		}
+		return weight(m)
+	})
+	if missing := n - len(res); missing > 0 {
+		res = append(res, r.WeightedRandom(missing, func(m *media.Media) float64 {
+			if _, ok := excluded[m.ID]; !ok {
+				return 0
+			}
+			return weight(m)
+		})...)
+	}
+
+	return res
+}
+
+func wrapRepo(r *repo.Repository) v1.RepoInfo {
+	return v1.RepoInfo{Id: r.ID(), Usage: wrapUsage(r.Usage()), Capabilities: wrapCapabilities(r)}
+}
+
+func wrapCapabilities(r *repo.Repository) v1.RepoCapabilities {
+	types := r.MetaTypes()
+
+	metaTypes := make([]v1.MetadataType, len(types))
+	for i, t := range types {
+		metaTypes[i] = v1.MetadataType(t.String())
+	}
+
+	return v1.RepoCapabilities{Writable: r.Writable(), MetaTypes: metaTypes}
+}
+
+func wrapUsage(u repo.Usage) v1.Usage {
+	return v1.Usage{
+		Bytes:         u.Bytes,
+		Items:         u.Items,
+		Capacity:      api.MakeOptInt64(u.Capacity),
+		SoftCapacity:  api.MakeOptInt64(u.SoftCapacity),
+		SoftItemLimit: api.MakeOptInt(u.SoftItemLimit),
+		Warning:       u.Warning,
+	}
+}
+
+// describeMedia converts media to its API representation, including data tracked by the repository.
+func (s *Server) describeMedia(ctx context.Context, r *repo.Repository, m *media.Media) (v1.Media, error) {
+	var (
+		req  = api.Request(ctx)
+		opts = describeOptions{unixTimestamps: api.UnixTimestamps(req), omitMeta: api.OmitMeta(req)}
+	)
+	if m.Collection != "" && !opts.omitMeta {
+		resolved := *m
+		resolved.Meta = r.ResolveMeta(m)
+		m = &resolved
+	}
+
+	m0, err := wrapMedia(m, s.mediaURL(ctx, r.ID(), m), opts)
 	if err != nil {
 		return v1.Media{}, err
 	}
 
+	m0.Repo = api.MakeOptString(r.ID())
+	m0.Downloads = api.MakeOptInt64(r.Downloads(m.ID))
+	m0.LastAccessed = api.MakeOptTimestamp(r.LastAccessed(m.ID), opts.unixTimestamps)
+	m0.ExpiresAt = api.MakeOptTimestamp(r.ExpiresAt(m), opts.unixTimestamps)
+	return m0, nil
+}
+
+// parseExpiry converts the per-item expiry of uploaded media to its repository representation (CreateOptions.Expiry),
+// nil if it isn't overridden.
+func parseExpiry(pm *v1.ProtoMedia) (*time.Time, error) {
+	switch {
+	case pm.ExpiresIn != nil && pm.ExpiresAt != nil:
+		return nil, errors.New("expires_in and expires_at are mutually exclusive")
+	case pm.ExpiresIn != nil:
+		secs := *pm.ExpiresIn
+		if secs < 0 || secs > math.MaxInt64/int64(time.Second) {
+			return nil, errors.New("expiry out of range")
+		}
+		if secs == 0 {
+			return &time.Time{}, nil // never expires
+		}
+
+		at := time.Now().Add(time.Duration(secs) * time.Second)
+		return &at, nil
+	case pm.ExpiresAt != nil:
+		if !pm.ExpiresAt.After(time.Now()) {
+			return nil, errors.New("expiry time is in the past")
+		}
+		return pm.ExpiresAt, nil
+	}
+
+	return nil, nil
+}
+
+// describeOptions is a set of client preferences for media descriptors.
+type describeOptions struct {
+	unixTimestamps bool // see api.UnixTimestamps
+	omitMeta       bool // see api.OmitMeta
+}
+
+func wrapMedia(m *media.Media, url string, opts describeOptions) (v1.Media, error) {
+	var m0 *v1.Media_Meta
+	if !opts.omitMeta {
+		m0 = &v1.Media_Meta{}
+
+		var err error
+		switch v := wrapMetadata(m.Meta).(type) {
+		case v1.GenericMetadata:
+			err = m0.FromGenericMetadata(v)
+		case v1.AnimeMetadata:
+			err = m0.FromAnimeMetadata(v)
+		}
+
+		if err != nil {
+			return v1.Media{}, err
+		}
+	}
+
+	size := m.Size
 	return v1.Media{
-		Format: wrapFormat(m.Format),
-		Id:     m.ID,
-		Meta:   m0,
+		Format:     wrapFormat(m.Format),
+		Id:         m.ID,
+		Meta:       m0,
+		CreatedAt:  api.MakeOptTimestamp(m.CreatedAt, opts.unixTimestamps),
+		Hash:       api.MakeOptString(m.Hash),
+		Size:       &size,
+		Width:      api.MakeOptInt(m.Width),
+		Height:     api.MakeOptInt(m.Height),
+		Url:        api.MakeOptString(url),
+		Pinned:     api.MakeOptBool(m.Pinned),
+		Collection: api.MakeOptString(m.Collection),
+		Batch:      api.MakeOptString(m.Batch),
 	}, nil
 }
 
@@ -114,11 +1134,61 @@ func wrapFormat(f media.Format) v1.MediaFormat {
 		return v1.Image
 	case media.FormatAnimatedImage:
 		return v1.AnimatedImage
+	case media.FormatVideo:
+		return v1.Video
 	default:
 		return v1.Unknown
 	}
 }
 
+// discriminatedMetadata is a metadata union, discriminated by its type field.
+type discriminatedMetadata interface {
+	Discriminator() (string, error)
+	MarshalJSON() ([]byte, error)
+}
+
+// parseMetadata decodes a metadata union according to a parsing policy, strictly unless it's lenient.
+// Strict parsing rejects unknown types and fields not belonging to the type,
+// lenient parsing ignores unknown fields and decodes unknown types as generic metadata.
+// The returned error describes the problem and is meant to be shown to the client.
+func parseMetadata(v discriminatedMetadata, policy repo.MetaParsing) (meta.Metadata, error) {
+	type_, err := v.Discriminator()
+	if err != nil {
+		return nil, fmt.Errorf("malformed metadata type: %w", err)
+	}
+
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("malformed metadata: %w", err)
+	}
+
+	lenient := policy == repo.MetaParsingLenient
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	if !lenient {
+		d.DisallowUnknownFields()
+	}
+
+	switch {
+	case type_ == string(v1.Generic) || (lenient && type_ != "" && type_ != string(v1.Anime)):
+		var m v1.GenericMetadata
+		if err := d.Decode(&m); err != nil {
+			return nil, fmt.Errorf("malformed %s metadata: %w", type_, err)
+		}
+		return unwrapMetadata(m), nil
+	case type_ == string(v1.Anime):
+		var m v1.AnimeMetadata
+		if err := d.Decode(&m); err != nil {
+			return nil, fmt.Errorf("malformed %s metadata: %w", type_, err)
+		}
+		return unwrapMetadata(m), nil
+	case type_ == "":
+		return nil, errors.New("missing metadata type")
+	}
+
+	return nil, fmt.Errorf("unknown metadata type %q", type_)
+}
+
 func unwrapMetadata(v interface{}) meta.Metadata {
 	switch m := v.(type) {
 	case v1.GenericMetadata:
@@ -153,9 +1223,52 @@ func wrapMetadata(v meta.Metadata) interface{} {
 	return nil
 }
 
+// errDigestMismatch is an error about uploaded content not matching the digest supplied in the request headers.
+var errDigestMismatch = errors.New("data checksum mismatch")
+
+// digestVerifier verifies decoded content against the digest supplied in the request headers, if any.
+type digestVerifier struct {
+	params v1.PostRepoParams
+	sha256 hash.Hash
+}
+
+// newDigestVerifier creates a digestVerifier for the request headers.
+func newDigestVerifier(params v1.PostRepoParams) *digestVerifier {
+	return &digestVerifier{params: params, sha256: sha256.New()}
+}
+
+// reader returns a reader digesting the content read through it.
+func (dv *digestVerifier) reader(rd io.Reader) io.Reader {
+	return io.TeeReader(rd, dv.sha256)
+}
+
+// verify verifies the content read so far, returns errDigestMismatch if it doesn't match.
+func (dv *digestVerifier) verify() error {
+	if dv.params.XContentSHA256 != nil && !strings.EqualFold(*dv.params.XContentSHA256, hex.EncodeToString(dv.sha256.Sum(nil))) {
+		return errDigestMismatch
+	}
+
+	return nil
+}
+
+func checkAdminKey(r *repo.Repository, key string) bool {
+	expectedKey, ok := r.Meta().Value(repo.AdminKey)
+	return ok && key == expectedKey // no admin key, no administration
+}
+
 func checkKey(r *repo.Repository, key string) bool {
 	if expectedKey, ok := r.Meta().Value(repo.AuthKey); ok {
 		return key == expectedKey
 	}
 	return true // no required key, no authentication needed
 }
+
+// isHex checks whether a string only consists of hexadecimal digits.
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
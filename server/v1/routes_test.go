@@ -0,0 +1,1526 @@
+package v1
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPostRepoChecksum(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	var (
+		b      = testPNG(t, 4, 4)
+		shaSum = sha256.Sum256(b)
+		other  = sha256.Sum256([]byte("other"))
+	)
+	tests := []struct {
+		name   string
+		params v1.PostRepoParams
+		status int
+	}{
+		{"none", v1.PostRepoParams{}, http.StatusOK},
+		{"sha256", v1.PostRepoParams{XContentSHA256: api.MakeOptString(hex.EncodeToString(shaSum[:]))}, http.StatusOK},
+		{"sha256 mismatch", v1.PostRepoParams{XContentSHA256: api.MakeOptString(hex.EncodeToString(other[:]))}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.PostRepoWithResponse(context.Background(), "test", &tt.params, v1.ProtoMedia{
+				Data: base64.StdEncoding.EncodeToString(b),
+			})
+			if err != nil {
+				t.Fatalf("failed to upload: %v", err)
+			}
+			if res.StatusCode() != tt.status {
+				t.Errorf("status = %d, want %d: %s", res.StatusCode(), tt.status, res.Body)
+			}
+		})
+	}
+
+	if n := r.Usage().Items; n != 2 {
+		t.Errorf("stored %d items, want only the 2 matching uploads", n)
+	}
+}
+
+func TestGetRepoCreatedRange(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, c := newTestServer(t, r)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 3)
+	for i := range ids {
+		ids[i] = addTestMedia(t, r, start.Add(time.Duration(i)*time.Hour)).ID
+	}
+
+	after, before := start, start.Add(2*time.Hour)
+	res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{CreatedAfter: &after, CreatedBefore: &before})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if res.JSON200 == nil || len(*res.JSON200) != 1 || (*res.JSON200)[0].Id != ids[1] {
+		t.Errorf("range listing = %s, want only %s", res.Body, ids[1])
+	}
+
+	res0, err := http.Get(ts.URL + BasePath + "/repos/test?createdAfter=yesterday")
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	_ = res0.Body.Close()
+	if res0.StatusCode != http.StatusBadRequest {
+		t.Errorf("malformed timestamp status = %d, want 400", res0.StatusCode)
+	}
+}
+
+func TestGetRepoManifest(t *testing.T) {
+	r := newTestRepo(t, "test", repo.Metadata{repo.AuthKey: "secret"}, nil)
+	_, c := newTestServer(t, r)
+
+	ids := make(map[uuid.UUID]bool, 3)
+	for i := 0; i < 3; i++ {
+		ids[addTestMedia(t, r, time.Now()).ID] = true
+	}
+
+	res, err := c.GetRepoManifestWithResponse(context.Background(), "test", &v1.GetRepoManifestParams{})
+	if err != nil {
+		t.Fatalf("failed to fetch manifest: %v", err)
+	}
+	if res.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("status without key = %d, want 401", res.StatusCode())
+	}
+
+	res, err = c.GetRepoManifestWithResponse(context.Background(), "test", &v1.GetRepoManifestParams{XNeroKey: api.MakeOptString("secret")})
+	if err != nil {
+		t.Fatalf("failed to fetch manifest: %v", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(res.Body), "\n"), "\n")
+	if len(lines) != len(ids) {
+		t.Fatalf("manifest has %d lines, want one per item (%d)", len(lines), len(ids))
+	}
+	for _, line := range lines {
+		var e v1.ManifestEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("malformed manifest line %q: %v", line, err)
+		}
+		if !ids[e.Id] {
+			t.Errorf("manifest lists unknown or repeated item %s", e.Id)
+		}
+		delete(ids, e.Id)
+	}
+}
+
+func TestGetRepoStatsUsage(t *testing.T) {
+	var (
+		limited   = newTestRepo(t, "limited", nil, &repo.Options{Quota: 1 << 20})
+		unlimited = newTestRepo(t, "unlimited", nil, nil)
+	)
+	_, c := newTestServer(t, limited, unlimited)
+
+	b := testPNG(t, 4, 4)
+	if _, err := limited.Create(context.Background(), b, nil, nil); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	res, err := c.GetRepoStatsWithResponse(context.Background(), "limited")
+	if err != nil {
+		t.Fatalf("failed to fetch stats: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+	if u := res.JSON200.Usage; u.Bytes != int64(len(b)) || u.Items != 1 || u.Capacity == nil || *u.Capacity != 1<<20 {
+		t.Errorf("usage = %s, want %d bytes in 1 item with a capacity of %d", res.Body, len(b), 1<<20)
+	}
+
+	res, err = c.GetRepoStatsWithResponse(context.Background(), "unlimited")
+	if err != nil {
+		t.Fatalf("failed to fetch stats: %v", err)
+	}
+	if res.JSON200 == nil || res.JSON200.Usage.Capacity != nil {
+		t.Errorf("stats without a quota = %s, want a null capacity", res.Body)
+	}
+}
+
+func TestGetRepoStatsSoftLimits(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{SoftItemLimit: 2})
+	_, c := newTestServer(t, r)
+
+	warning := func() bool {
+		t.Helper()
+
+		res, err := c.GetRepoStatsWithResponse(context.Background(), "test")
+		if err != nil {
+			t.Fatalf("failed to fetch stats: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+		if u := res.JSON200.Usage; u.SoftItemLimit == nil || *u.SoftItemLimit != 2 || u.SoftCapacity != nil {
+			t.Errorf("usage = %s, want a soft item limit of 2 and no soft capacity", res.Body)
+		}
+		return res.JSON200.Usage.Warning
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Create(context.Background(), testPNG(t, i+1, 1), nil, nil); err != nil {
+			t.Fatalf("upload beyond the soft limit failed: %v", err)
+		}
+		if got, want := warning(), i >= 1; got != want {
+			t.Errorf("warning = %t with %d items, want %t", got, i+1, want)
+		}
+	}
+
+	res, err := c.GetHealthzWithResponse(context.Background())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+	if res.JSON200 == nil || res.JSON200.Status != v1.Ok {
+		t.Fatalf("health check = %s, want ok, soft limits are informational", res.Body)
+	}
+	if u := res.JSON200.Repos[0].Usage; u == nil || !u.Warning {
+		t.Error("health check doesn't report the soft limit warning")
+	}
+}
+
+func TestGetRepoExport(t *testing.T) {
+	r := newTestRepo(t, "test", repo.Metadata{repo.AdminKey: "admin"}, nil)
+	_, c := newTestServer(t, r)
+
+	ids := make(map[string]bool, 3)
+	for i := 1; i <= 3; i++ {
+		m, err := r.Create(context.Background(), testPNG(t, i, i), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+		ids[m.ID.String()] = true
+	}
+
+	// checkEntries checks archive entry names, the manifest first and one entry named by the ID of each item
+	checkEntries := func(t *testing.T, names []string) {
+		if len(names) != len(ids)+1 || names[0] != "index.ndjson" {
+			t.Fatalf("archive entries = %v, want index.ndjson and one per item", names)
+		}
+		for _, name := range names[1:] {
+			id, _, _ := strings.Cut(name, ".")
+			if !ids[id] {
+				t.Errorf("unexpected archive entry %s", name)
+			}
+		}
+	}
+
+	t.Run("tar", func(t *testing.T) {
+		res, err := c.GetRepoExportTarWithResponse(context.Background(), "test", &v1.GetRepoExportTarParams{})
+		if err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if res.StatusCode() != http.StatusUnauthorized {
+			t.Errorf("status without the admin key = %d, want 401", res.StatusCode())
+		}
+
+		res, err = c.GetRepoExportTarWithResponse(context.Background(), "test", &v1.GetRepoExportTarParams{XNeroKey: api.MakeOptString("admin")})
+		if err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		var (
+			names []string
+			tr    = tar.NewReader(bytes.NewReader(res.Body))
+		)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("malformed tar archive: %v", err)
+			}
+			names = append(names, h.Name)
+		}
+		checkEntries(t, names)
+	})
+	t.Run("zip", func(t *testing.T) {
+		res, err := c.GetRepoExportZipWithResponse(context.Background(), "test", &v1.GetRepoExportZipParams{XNeroKey: api.MakeOptString("wrong")})
+		if err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if res.StatusCode() != http.StatusUnauthorized {
+			t.Errorf("status with a wrong admin key = %d, want 401", res.StatusCode())
+		}
+
+		res, err = c.GetRepoExportZipWithResponse(context.Background(), "test", &v1.GetRepoExportZipParams{XNeroKey: api.MakeOptString("admin")})
+		if err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(res.Body), int64(len(res.Body)))
+		if err != nil {
+			t.Fatalf("malformed zip archive: %v", err)
+		}
+
+		names := make([]string, len(zr.File))
+		for i, f := range zr.File {
+			names[i] = f.Name
+		}
+		checkEntries(t, names)
+	})
+}
+
+func TestPostRepoImportZip(t *testing.T) {
+	var (
+		src = newTestRepo(t, "src", repo.Metadata{repo.AdminKey: "admin"}, nil)
+		dst = newTestRepo(t, "dst", nil, nil)
+	)
+	_, c := newTestServer(t, src, dst)
+
+	m, err := src.Create(context.Background(), testPNG(t, 4, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	res, err := c.GetRepoExportZipWithResponse(context.Background(), "src", &v1.GetRepoExportZipParams{XNeroKey: api.MakeOptString("admin")})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		t.Fatalf("export status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+
+	imp, err := c.PostRepoImportZipWithBodyWithResponse(context.Background(), "dst", &v1.PostRepoImportZipParams{}, "application/zip", bytes.NewReader(res.Body))
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if imp.JSON200 == nil || imp.JSON200.Imported != 1 {
+		t.Fatalf("import status = %d, want 200 with 1 imported item: %s", imp.StatusCode(), imp.Body)
+	}
+	if m0 := dst.Get(m.ID); m0 == nil || m0.Hash != m.Hash {
+		t.Errorf("imported media = %+v, want the content of %s", m0, m.ID)
+	}
+
+	imp, err = c.PostRepoImportZipWithBodyWithResponse(context.Background(), "dst", &v1.PostRepoImportZipParams{}, "application/zip", strings.NewReader("not a zip archive"))
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if imp.StatusCode() != http.StatusUnprocessableEntity {
+		t.Errorf("malformed archive status = %d, want 422", imp.StatusCode())
+	}
+}
+
+func TestGetRepoIdLocation(t *testing.T) {
+	r := newTestRepo(t, "test", repo.Metadata{repo.AdminKey: "admin"}, nil)
+	_, c := newTestServer(t, r)
+
+	m, err := r.Create(context.Background(), testPNG(t, 4, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	admin := &v1.GetRepoIdLocationParams{XNeroKey: api.MakeOptString("admin")}
+	res, err := c.GetRepoIdLocationWithResponse(context.Background(), "test", m.ID, admin)
+	if err != nil {
+		t.Fatalf("failed to locate media: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+	if res.JSON200.Path != m.Path || res.JSON200.Size != m.Size {
+		t.Errorf("location = %s, want %s with %d bytes", res.Body, m.Path, m.Size)
+	}
+
+	res, err = c.GetRepoIdLocationWithResponse(context.Background(), "test", m.ID, &v1.GetRepoIdLocationParams{})
+	if err != nil {
+		t.Fatalf("failed to locate media: %v", err)
+	}
+	if res.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("status without the admin key = %d, want 401", res.StatusCode())
+	}
+
+	res, err = c.GetRepoIdLocationWithResponse(context.Background(), "test", uuid.New(), admin)
+	if err != nil {
+		t.Fatalf("failed to locate media: %v", err)
+	}
+	if res.JSON400 == nil || res.JSON400.Type != v1.NotFound {
+		t.Errorf("unknown item = %d %s, want 400 with a not found error", res.StatusCode(), res.Body)
+	}
+}
+
+func TestGetHealthzDegraded(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "nero.lock"), []byte("{\"id\": \"corrupt\n"), 0644); err != nil {
+		t.Fatalf("failed to write index file: %v", err)
+	}
+
+	degraded, err := repo.NewFile("degraded", dir, filepath.Join(dir, "nero.lock"), nil, &repo.Options{AllowDegraded: true}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to load repository: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = degraded.Close()
+	})
+	_, c := newTestServer(t, degraded, newTestRepo(t, "healthy", nil, nil))
+
+	res, err := c.GetHealthzWithResponse(context.Background())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+	if res.JSON200.Status != v1.Degraded {
+		t.Errorf("status = %s, want degraded", res.JSON200.Status)
+	}
+	for _, rh := range res.JSON200.Repos {
+		if want := map[string]v1.HealthStatus{"degraded": v1.Degraded, "healthy": v1.Ok}[rh.Id]; rh.Status != want {
+			t.Errorf("repository %s is %s, want %s", rh.Id, rh.Status, want)
+		}
+	}
+}
+
+func TestPatchRepoIdContentType(t *testing.T) {
+	r := newTestRepo(t, "test", repo.Metadata{repo.AdminKey: "admin"}, nil)
+	ts, c := newTestServer(t, r)
+
+	m, err := r.Create(context.Background(), testPNG(t, 4, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	contentType := func() string {
+		res, err := http.Get(ts.URL + BasePath + "/repos/test/" + m.ID.String())
+		if err != nil {
+			t.Fatalf("failed to get media: %v", err)
+		}
+		defer res.Body.Close()
+
+		return res.Header.Get("Content-Type")
+	}
+	if got := contentType(); got != "image/png" {
+		t.Fatalf("detected content type = %q, want image/png", got)
+	}
+
+	override := "image/x-custom"
+	res, err := c.PatchRepoIdWithResponse(context.Background(), "test", m.ID, &v1.PatchRepoIdParams{}, v1.MediaUpdate{ContentType: &override})
+	if err != nil {
+		t.Fatalf("failed to update media: %v", err)
+	}
+	if res.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("status without the admin key = %d, want 401", res.StatusCode())
+	}
+
+	res, err = c.PatchRepoIdWithResponse(context.Background(), "test", m.ID, &v1.PatchRepoIdParams{XNeroKey: api.MakeOptString("admin")}, v1.MediaUpdate{ContentType: &override})
+	if err != nil {
+		t.Fatalf("failed to update media: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+	if got := contentType(); got != override {
+		t.Errorf("served content type = %q, want the override %q", got, override)
+	}
+
+	reset := ""
+	if _, err := c.PatchRepoIdWithResponse(context.Background(), "test", m.ID, &v1.PatchRepoIdParams{XNeroKey: api.MakeOptString("admin")}, v1.MediaUpdate{ContentType: &reset}); err != nil {
+		t.Fatalf("failed to update media: %v", err)
+	}
+	if got := contentType(); got != "image/png" {
+		t.Errorf("content type after removing the override = %q, want image/png", got)
+	}
+}
+
+// fakeWebPEncoder is a repo.WebPEncoder returning fixed content.
+type fakeWebPEncoder []byte
+
+func (fe fakeWebPEncoder) EncodeWebP(context.Context, []byte) ([]byte, error) {
+	return fe, nil
+}
+
+func TestGetRepoIdWebP(t *testing.T) {
+	var (
+		webp  = fakeWebPEncoder("RIFF\x00\x00\x00\x00WEBP")
+		r     = newTestRepo(t, "test", nil, &repo.Options{WebP: webp})
+		large = newTestRepo(t, "large", nil, &repo.Options{WebP: webp, MaxDecodePixels: 15})
+	)
+	ts, _ := newTestServer(t, r, large)
+
+	get := func(repoID string, id uuid.UUID, accept string) (string, []byte) {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+BasePath+"/repos/"+repoID+"/"+id.String(), nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Accept", accept)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get media: %v", err)
+		}
+		defer res.Body.Close()
+
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("failed to read media: %v", err)
+		}
+		if !slices.Contains(res.Header.Values("Vary"), "Accept") {
+			t.Error("negotiated response doesn't vary by Accept")
+		}
+		return res.Header.Get("Content-Type"), b
+	}
+
+	content := testPNG(t, 4, 4)
+	m, err := r.Create(context.Background(), content, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if typ, b := get("test", m.ID, "image/webp,image/*;q=0.8"); typ != "image/webp" || !bytes.Equal(b, webp) {
+		t.Errorf("WebP-accepting client got %s, want image/webp", typ)
+	}
+	if typ, b := get("test", m.ID, "image/png,image/*;q=0.8"); typ != "image/png" || !bytes.Equal(b, content) {
+		t.Errorf("other client got %s, want the original image/png", typ)
+	}
+
+	// images above the pixel limit are served as is
+	m, err = large.Create(context.Background(), content, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if typ, b := get("large", m.ID, "image/webp"); typ != "image/png" || !bytes.Equal(b, content) {
+		t.Errorf("WebP-accepting client got %s for an image above the pixel limit, want the original image/png", typ)
+	}
+}
+
+func TestGetRepoIdResize(t *testing.T) {
+	var (
+		r     = newTestRepo(t, "test", nil, &repo.Options{ResizeMaxSize: 8, ResizeSizes: []int{2, 4}})
+		plain = newTestRepo(t, "plain", nil, nil)
+	)
+	ts, _ := newTestServer(t, r, plain)
+
+	get := func(repoID string, id uuid.UUID, query string) (int, string, []byte) {
+		res, err := http.Get(ts.URL + BasePath + "/repos/" + repoID + "/" + id.String() + "?" + query)
+		if err != nil {
+			t.Fatalf("failed to get media: %v", err)
+		}
+		defer res.Body.Close()
+
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("failed to read media: %v", err)
+		}
+		return res.StatusCode, res.Header.Get("Content-Type"), b
+	}
+
+	m, err := r.Create(context.Background(), testPNG(t, 8, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	status, typ, b := get("test", m.ID, "w=4&h=4&fit=cover")
+	if status != http.StatusOK || typ != "image/png" {
+		t.Fatalf("resized media status = %d, content type = %q, want 200 image/png", status, typ)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("failed to decode resized media: %v", err)
+	}
+	if cfg.Width != 4 || cfg.Height != 4 {
+		t.Errorf("resized to %dx%d, want 4x4", cfg.Width, cfg.Height)
+	}
+	if _, _, again := get("test", m.ID, "w=4&h=4&fit=cover"); !bytes.Equal(again, b) {
+		t.Error("cached resized media differs")
+	}
+
+	if status, _, _ := get("test", m.ID, "w=3"); status != http.StatusBadRequest {
+		t.Errorf("size outside the allowed ones status = %d, want 400", status)
+	}
+	m, err = plain.Create(context.Background(), testPNG(t, 8, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if status, _, _ := get("plain", m.ID, "w=4"); status != http.StatusBadRequest {
+		t.Errorf("resizing with it disabled status = %d, want 400", status)
+	}
+}
+
+func TestGetRepoDefaultPage(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{DefaultLimit: 2, DefaultOrder: repo.OrderCreatedDesc})
+	_, c := newTestServer(t, r)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 3)
+	for i := range ids {
+		ids[i] = addTestMedia(t, r, start.Add(time.Duration(i)*time.Hour)).ID
+	}
+
+	list := func(params *v1.GetRepoParams) []uuid.UUID {
+		res, err := c.GetRepoWithResponse(context.Background(), "test", params)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		var got []uuid.UUID
+		for _, m := range *res.JSON200 {
+			got = append(got, m.Id)
+		}
+		return got
+	}
+
+	if got, want := list(&v1.GetRepoParams{}), []uuid.UUID{ids[2], ids[1]}; !slices.Equal(got, want) {
+		t.Errorf("parameterless listing = %v, want the most recent %v", got, want)
+	}
+
+	// any parameter replaces the default page
+	limit := 10
+	if got := list(&v1.GetRepoParams{Limit: &limit}); !slices.Equal(got, ids) {
+		t.Errorf("listing with a limit = %v, want all media oldest first %v", got, ids)
+	}
+}
+
+func TestGetRepoByArtist(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{IndexedFields: []repo.FacetField{repo.FacetArtist}})
+	_, c := newTestServer(t, r)
+
+	var ids []uuid.UUID
+	for _, artist := range []string{"a", "b"} {
+		m, err := r.Create(context.Background(), testPNG(t, len(ids)+1, 1), &meta.GenericMetadata{Artist: artist}, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+
+	artist := "b"
+	res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{Artist: &artist})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if res.JSON200 == nil || len(*res.JSON200) != 1 || (*res.JSON200)[0].Id != ids[1] {
+		t.Errorf("listing by artist = %s, want only %s", res.Body, ids[1])
+	}
+}
+
+func TestGetRepoIdStoredMIME(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{StoreMIME: true})
+	ts, _ := newTestServer(t, r)
+
+	m, err := r.Create(context.Background(), testPNG(t, 2, 2), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if m.MIME != "image/png" {
+		t.Errorf("stored MIME type = %q, want image/png", m.MIME)
+	}
+
+	// the stored type is served without detecting it again, even if the content disagrees
+	id := uuid.New()
+	stored := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), MIME: "image/x-stored"}
+	if err := os.WriteFile(stored.Path, testPNG(t, 3, 3), 0644); err != nil {
+		t.Fatalf("failed to write media file: %v", err)
+	}
+	if err := r.Add(context.Background(), stored); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+
+	res, err := http.Get(ts.URL + BasePath + "/repos/test/" + id.String())
+	if err != nil {
+		t.Fatalf("failed to get media: %v", err)
+	}
+	_ = res.Body.Close()
+	if got := res.Header.Get("Content-Type"); got != stored.MIME {
+		t.Errorf("served content type = %q, want the stored %q", got, stored.MIME)
+	}
+}
+
+func TestGetRepoIdDownloads(t *testing.T) {
+	dir := t.TempDir()
+	r, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, &repo.Options{CountDownloads: true}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	ts, c := newTestServer(t, r)
+
+	m, err := r.Create(context.Background(), testPNG(t, 4, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	serve := func(method string, header http.Header) {
+		req, err := http.NewRequest(method, ts.URL+BasePath+"/repos/test/"+m.ID.String(), nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header = header
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get media: %v", err)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+	serve(http.MethodGet, nil)
+	serve(http.MethodGet, nil)
+	serve(http.MethodHead, nil)                                // not a download
+	serve(http.MethodGet, http.Header{"Range": {"bytes=0-9"}}) // neither
+
+	res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if res.JSON200 == nil || len(*res.JSON200) != 1 || (*res.JSON200)[0].Downloads == nil || *(*res.JSON200)[0].Downloads != 2 {
+		t.Errorf("listing = %s, want 2 downloads", res.Body)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("failed to close repository: %v", err)
+	}
+	r0, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, &repo.Options{CountDownloads: true}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if n := r0.Downloads(m.ID); n != 2 {
+		t.Errorf("downloads after a reload = %d, want 2", n)
+	}
+}
+
+func TestHideUnknown(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{HideUnknown: true})
+	_, c := newTestServer(t, r)
+
+	image := addTestMedia(t, r, time.Now())
+	id := uuid.New()
+	unknown := &media.Media{ID: id, Format: media.FormatUnknown, Path: filepath.Join(r.Path(), id.String()+".bin"), CreatedAt: time.Now()}
+	if err := r.Add(context.Background(), unknown); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+
+	list := func(include *bool) []uuid.UUID {
+		res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{IncludeUnknown: include})
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		var ids []uuid.UUID
+		for _, m := range *res.JSON200 {
+			ids = append(ids, m.Id)
+		}
+		return ids
+	}
+
+	if got := list(nil); !slices.Equal(got, []uuid.UUID{image.ID}) {
+		t.Errorf("default listing = %v, want only the image %s", got, image.ID)
+	}
+	include := true
+	if got := list(&include); len(got) != 2 {
+		t.Errorf("listing including unknown media = %v, want both items", got)
+	}
+
+	count := 10
+	for i := 0; i < 10; i++ {
+		res, err := c.GetRepoRandomWithResponse(context.Background(), "test", &v1.GetRepoRandomParams{Count: &count})
+		if err != nil {
+			t.Fatalf("failed to pick random media: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+		for _, m := range *res.JSON200 {
+			if m.Id == unknown.ID {
+				t.Fatal("random pick returned media of an unknown format")
+			}
+		}
+	}
+}
+
+func TestPostRepoMetaTypes(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{MetaTypes: []meta.Type{meta.TypeAnime}})
+	_, c := newTestServer(t, r)
+
+	var generic, anime v1.ProtoMedia_Meta
+	if err := generic.FromGenericMetadata(v1.GenericMetadata{Type: v1.Generic, Artist: api.MakeOptString("artist")}); err != nil {
+		t.Fatalf("failed to build metadata: %v", err)
+	}
+	if err := anime.FromAnimeMetadata(v1.AnimeMetadata{Type: v1.Anime, Name: api.MakeOptString("name")}); err != nil {
+		t.Fatalf("failed to build metadata: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		meta   *v1.ProtoMedia_Meta
+		status int
+	}{
+		{"generic", &generic, http.StatusBadRequest},
+		{"anime", &anime, http.StatusOK},
+		{"none", nil, http.StatusOK},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.PostRepoWithResponse(context.Background(), "test", &v1.PostRepoParams{}, v1.ProtoMedia{
+				Data: base64.StdEncoding.EncodeToString(testPNG(t, i+1, 1)),
+				Meta: tt.meta,
+			})
+			if err != nil {
+				t.Fatalf("failed to upload: %v", err)
+			}
+			if res.StatusCode() != tt.status {
+				t.Errorf("status = %d, want %d: %s", res.StatusCode(), tt.status, res.Body)
+			}
+		})
+	}
+
+	if n := r.Usage().Items; n != 2 {
+		t.Errorf("stored %d items, want only the 2 allowed uploads", n)
+	}
+}
+
+func TestGetRepoRandomEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *repo.Options
+		status int
+	}{
+		{"empty list", nil, http.StatusOK},
+		{"not found", &repo.Options{EmptyRandomNotFound: true}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newTestServer(t, newTestRepo(t, "test", nil, tt.opts))
+
+			res, err := c.GetRepoRandomWithResponse(context.Background(), "test", &v1.GetRepoRandomParams{})
+			if err != nil {
+				t.Fatalf("failed to pick media: %v", err)
+			}
+			if res.StatusCode() != tt.status {
+				t.Fatalf("status = %d, want %d: %s", res.StatusCode(), tt.status, res.Body)
+			}
+			if tt.status == http.StatusOK && (res.JSON200 == nil || len(*res.JSON200) != 0) {
+				t.Errorf("response = %s, want an empty list", res.Body)
+			}
+		})
+	}
+}
+
+func TestGetRepoRandomExcludeWeighted(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		m, err := r.Create(context.Background(), testPNG(t, i+1, 1), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+
+	// pick picks count random media by recency, excluding the first item
+	pick := func(count int) []uuid.UUID {
+		var (
+			weight  = v1.Recency
+			exclude = ids[:1]
+		)
+		res, err := c.GetRepoRandomWithResponse(context.Background(), "test", &v1.GetRepoRandomParams{
+			Count:   &count,
+			Weight:  &weight,
+			Exclude: &exclude,
+		})
+		if err != nil {
+			t.Fatalf("failed to pick random media: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		var picked []uuid.UUID
+		for _, m := range *res.JSON200 {
+			picked = append(picked, m.Id)
+		}
+		return picked
+	}
+
+	for i := 0; i < 10; i++ {
+		if got := pick(2); len(got) != 2 || slices.Contains(got, ids[0]) {
+			t.Fatalf("picked %v, want 2 items other than the excluded %s", got, ids[0])
+		}
+	}
+	if got := pick(3); len(got) != 3 || !slices.Contains(got, ids[0]) {
+		t.Errorf("picked %v, want the excluded %s to fill up the result", got, ids[0])
+	}
+}
+
+func TestGetRepoCollectionMeta(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	if err := r.PutCollection(context.Background(), &repo.Collection{ID: "album", Meta: &meta.GenericMetadata{Artist: "album artist"}}); err != nil {
+		t.Fatalf("failed to put collection: %v", err)
+	}
+	m, err := r.Create(context.Background(), testPNG(t, 1, 1), &meta.GenericMetadata{Source: "own source"}, &repo.CreateOptions{Collection: "album"})
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{})
+	if err != nil {
+		t.Fatalf("failed to list media: %v", err)
+	}
+	if res.JSON200 == nil || len(*res.JSON200) != 1 || (*res.JSON200)[0].Id != m.ID || (*res.JSON200)[0].Meta == nil {
+		t.Fatalf("status = %d, want the media with metadata: %s", res.StatusCode(), res.Body)
+	}
+
+	gm, err := (*res.JSON200)[0].Meta.AsGenericMetadata()
+	if err != nil {
+		t.Fatalf("malformed metadata: %v", err)
+	}
+	if api.MakeString(gm.Artist) != "album artist" || api.MakeString(gm.Source) != "own source" {
+		t.Errorf("described metadata = %s, want the inherited artist and own source", res.Body)
+	}
+}
+
+func TestGetRepoUnixTimestamps(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, _ := newTestServer(t, r)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	addTestMedia(t, r, createdAt)
+
+	tests := []struct {
+		name   string
+		query  string
+		accept string
+		want   string
+	}{
+		{"default", "", "", `"2024-01-01T00:00:00Z"`},
+		{"query", "?timestamps=unix", "", "1704067200"},
+		{"accept", "", "application/json; timestamps=unix", "1704067200"},
+		{"query overrides accept", "?timestamps=rfc3339", "application/json; timestamps=unix", `"2024-01-01T00:00:00Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+BasePath+"/repos/test"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to list media: %v", err)
+			}
+			defer res.Body.Close()
+
+			var items []map[string]json.RawMessage
+			if err := json.NewDecoder(res.Body).Decode(&items); err != nil {
+				t.Fatalf("malformed listing (status %d): %v", res.StatusCode, err)
+			}
+			if len(items) != 1 {
+				t.Fatalf("listed %d items, want 1", len(items))
+			}
+			if string(items[0]["created_at"]) != tt.want {
+				t.Errorf("created_at = %s, want %s", items[0]["created_at"], tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRepoIdLastAccessed(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		opts = &repo.Options{TrackAccess: true, AccessSaveInterval: time.Hour}
+	)
+	r, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	ts, _ := newTestServer(t, r)
+
+	m, err := r.Create(context.Background(), testPNG(t, 4, 4), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	if !r.LastAccessed(m.ID).IsZero() {
+		t.Error("media was accessed before being served")
+	}
+
+	before := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		res, err := http.Get(ts.URL + BasePath + "/repos/test/" + m.ID.String())
+		if err != nil {
+			t.Fatalf("failed to get media: %v", err)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+
+	lastAccessed := r.LastAccessed(m.ID)
+	if lastAccessed.Before(before) {
+		t.Errorf("last access time = %s, want after %s", lastAccessed, before)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nero.lock.access")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("access times were written per request, before the save interval")
+	}
+
+	if err := r.Close(); err != nil { // flushes the access times
+		t.Fatalf("failed to close repository: %v", err)
+	}
+	r0, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to reload repository: %v", err)
+	}
+	defer r0.Close()
+
+	if got := r0.LastAccessed(m.ID); !got.Equal(lastAccessed) {
+		t.Errorf("last access time after a reload = %s, want %s", got, lastAccessed)
+	}
+}
+
+func TestPostRepoMalformedMeta(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, _ := newTestServer(t, r)
+
+	tests := []struct {
+		name   string
+		meta   string
+		status int
+	}{
+		{"valid", `{"type": "anime", "name": "name"}`, http.StatusOK},
+		{"mismatched fields", `{"type": "anime", "artist": "artist"}`, http.StatusBadRequest},
+		{"unknown type", `{"type": "manga", "name": "name"}`, http.StatusBadRequest},
+		{"missing type", `{"name": "name"}`, http.StatusBadRequest},
+		{"malformed type", `{"type": 1}`, http.StatusBadRequest},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"data": "` + base64.StdEncoding.EncodeToString(testPNG(t, i+1, 1)) + `", "meta": ` + tt.meta + `}`
+
+			res, err := http.Post(ts.URL+BasePath+"/repos/test", "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to upload: %v", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.status {
+				b, _ := io.ReadAll(res.Body)
+				t.Errorf("status = %d, want %d: %s", res.StatusCode, tt.status, b)
+			}
+		})
+	}
+
+	if n := r.Usage().Items; n != 1 {
+		t.Errorf("stored %d items, want only the valid upload", n)
+	}
+}
+
+func TestPostRepoLenientMeta(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{MetaParsing: repo.MetaParsingLenient})
+	ts, _ := newTestServer(t, r)
+
+	tests := []struct {
+		name   string
+		meta   string
+		status int
+		want   meta.Metadata
+	}{
+		{"unknown type", `{"type": "manga", "artist": "artist", "source": "source", "chapter": 3}`, http.StatusOK, &meta.GenericMetadata{Artist: "artist", Source: "source"}},
+		{"unknown fields", `{"type": "anime", "name": "name", "season": 2}`, http.StatusOK, &meta.AnimeMetadata{Name: "name"}},
+		{"missing type", `{"name": "name"}`, http.StatusBadRequest, nil},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"data": "` + base64.StdEncoding.EncodeToString(testPNG(t, i+1, 1)) + `", "meta": ` + tt.meta + `}`
+
+			res, err := http.Post(ts.URL+BasePath+"/repos/test", "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to upload: %v", err)
+			}
+			defer res.Body.Close()
+
+			b, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d: %s", res.StatusCode, tt.status, b)
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var m0 v1.Media
+			if err := json.Unmarshal(b, &m0); err != nil {
+				t.Fatalf("malformed response: %v", err)
+			}
+			m := r.Get(m0.Id)
+			if m == nil {
+				t.Fatal("uploaded media wasn't stored")
+			}
+			// compared serialized, metadata may carry transient caches
+			got, _ := json.Marshal(m.Meta)
+			if want, _ := json.Marshal(tt.want); string(got) != string(want) {
+				t.Errorf("stored metadata = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestGetHealthzLoadWarnings(t *testing.T) {
+	dir := t.TempDir()
+	r, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		m, err := r.Create(context.Background(), testPNG(t, i+1, 1), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+		if err := os.Remove(m.Path); err != nil {
+			t.Fatalf("failed to remove media file: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("failed to close repository: %v", err)
+	}
+
+	tests := []struct {
+		threshold int
+		status    v1.HealthStatus
+	}{
+		{0, v1.Ok}, // disabled
+		{2, v1.Degraded},
+		{3, v1.Ok},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.threshold), func(t *testing.T) {
+			r, err := repo.NewFile("test", dir, filepath.Join(dir, "nero.lock"), nil, &repo.Options{LoadWarningThreshold: tt.threshold}, zap.NewNop())
+			if err != nil {
+				t.Fatalf("failed to load repository: %v", err)
+			}
+			t.Cleanup(func() {
+				_ = r.Close()
+			})
+			_, c := newTestServer(t, r)
+
+			res, err := c.GetHealthzWithResponse(context.Background())
+			if err != nil {
+				t.Fatalf("failed to check health: %v", err)
+			}
+			if res.JSON200 == nil || len(res.JSON200.Repos) != 1 {
+				t.Fatalf("status = %d, want the health of 1 repository: %s", res.StatusCode(), res.Body)
+			}
+
+			rh := res.JSON200.Repos[0]
+			if rh.Status != tt.status || res.JSON200.Status != tt.status {
+				t.Errorf("status = %s, want %s", rh.Status, tt.status)
+			}
+			if rh.Load == nil || rh.Load.Missing != 3 {
+				t.Errorf("load summary = %+v, want 3 missing items", rh.Load)
+			}
+		})
+	}
+}
+
+func TestGetRepoOmitMeta(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, _ := newTestServer(t, r)
+
+	if _, err := r.Create(context.Background(), testPNG(t, 1, 1), &meta.GenericMetadata{Artist: "artist"}, nil); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	for _, omit := range []bool{false, true} {
+		url := ts.URL + BasePath + "/repos/test"
+		if omit {
+			url += "?meta=omit"
+		}
+
+		res, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("failed to list media: %v", err)
+		}
+		defer res.Body.Close()
+
+		var items []map[string]json.RawMessage
+		if err := json.NewDecoder(res.Body).Decode(&items); err != nil {
+			t.Fatalf("malformed listing (status %d): %v", res.StatusCode, err)
+		}
+		if len(items) != 1 {
+			t.Fatalf("listed %d items, want 1", len(items))
+		}
+		if _, ok := items[0]["meta"]; ok == omit {
+			t.Errorf("meta field present = %t with omit = %t", ok, omit)
+		}
+		for _, key := range []string{"id", "format", "size"} {
+			if _, ok := items[0][key]; !ok {
+				t.Errorf("descriptor is missing the %s field with omit = %t", key, omit)
+			}
+		}
+	}
+}
+
+func BenchmarkWrapMedia(b *testing.B) {
+	m := &media.Media{ID: uuid.New(), Format: media.FormatImage, Meta: &meta.AnimeMetadata{Name: "name"}}
+	for _, omit := range []bool{false, true} {
+		b.Run("omit="+strconv.FormatBool(omit), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m0, err := wrapMedia(m, "", describeOptions{omitMeta: omit})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if (m0.Meta == nil) != omit {
+					b.Fatal("metadata wasn't converted exactly when requested")
+				}
+			}
+		})
+	}
+}
+
+func TestGetRepoHashPrefix(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	var ms []*media.Media
+	for i := 0; i < 32; i++ { // more items than digits, some hashes share their first one
+		m, err := r.Create(context.Background(), testPNG(t, i+1, 1), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create media: %v", err)
+		}
+		ms = append(ms, m)
+	}
+
+	list := func(prefix string) ([]uuid.UUID, int) {
+		t.Helper()
+
+		res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{HashPrefix: &prefix})
+		if err != nil {
+			t.Fatalf("failed to list media: %v", err)
+		}
+		if res.JSON200 == nil {
+			return nil, res.StatusCode()
+		}
+
+		ids := make([]uuid.UUID, len(*res.JSON200))
+		for i, m := range *res.JSON200 {
+			ids[i] = m.Id
+		}
+		slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+		return ids, res.StatusCode()
+	}
+
+	byDigit := make(map[string][]uuid.UUID)
+	for _, m := range ms {
+		byDigit[m.Hash[:1]] = append(byDigit[m.Hash[:1]], m.ID)
+	}
+	var digit string
+	for d, ids := range byDigit {
+		if len(ids) > len(byDigit[digit]) {
+			digit = d
+		}
+	}
+	want := byDigit[digit]
+	slices.SortFunc(want, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []uuid.UUID
+	}{
+		{"unique", ms[0].Hash[:12], []uuid.UUID{ms[0].ID}},
+		{"uppercase", strings.ToUpper(ms[0].Hash[:12]), []uuid.UUID{ms[0].ID}},
+		{"ambiguous", digit, want},
+		{"full", ms[1].Hash, []uuid.UUID{ms[1].ID}},
+		{"no match", ms[0].Hash + "0", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, status := list(tt.prefix)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, want 200", status)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("listed %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	if _, status := list("xyz"); status != http.StatusBadRequest {
+		t.Errorf("non-hexadecimal prefix status = %d, want 400", status)
+	}
+}
+
+func TestPostRepoExistsBatch(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	var (
+		a       = addTestMedia(t, r, time.Now())
+		b       = addTestMedia(t, r, time.Now())
+		missing = uuid.New()
+	)
+	res, err := c.PostRepoExistsBatchWithResponse(context.Background(), "test", []uuid.UUID{a.ID, missing, b.ID, a.ID})
+	if err != nil {
+		t.Fatalf("failed to check existence: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+	}
+
+	want := map[string]bool{a.ID.String(): true, b.ID.String(): true, missing.String(): false}
+	if got := *res.JSON200; len(got) != len(want) {
+		t.Errorf("existence = %v, want %v", got, want)
+	}
+	for id, ok := range want {
+		if got, found := (*res.JSON200)[id]; !found || got != ok {
+			t.Errorf("existence of %s = %t (reported %t), want %t", id, got, found, ok)
+		}
+	}
+
+	ids := make([]uuid.UUID, maxExistsBatch+1)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	if res, err := c.PostRepoExistsBatchWithResponse(context.Background(), "test", ids); err != nil || res.StatusCode() != http.StatusBadRequest {
+		t.Errorf("oversized batch didn't fail with 400: %v", err)
+	}
+}
+
+func TestGetReposCapabilities(t *testing.T) {
+	var (
+		writable = newTestRepo(t, "writable", nil, nil)
+		anime    = newTestRepo(t, "anime", nil, &repo.Options{MetaTypes: []meta.Type{meta.TypeAnime}})
+		readOnly = repo.NewMemory("readonly", nil, zap.NewNop())
+	)
+	_, c := newTestServer(t, writable, anime, readOnly)
+
+	list := func(params *v1.GetReposParams) []string {
+		t.Helper()
+
+		res, err := c.GetReposWithResponse(context.Background(), params)
+		if err != nil {
+			t.Fatalf("failed to list repositories: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		ids := make([]string, len(*res.JSON200))
+		for i, r := range *res.JSON200 {
+			ids[i] = r.Id
+			if want := r.Id != "readonly"; r.Capabilities.Writable != want {
+				t.Errorf("repository %s writable = %t, want %t", r.Id, r.Capabilities.Writable, want)
+			}
+		}
+		return ids
+	}
+
+	var (
+		yes, no   = true, false
+		animeType = v1.MetadataType(v1.Anime)
+		generic   = v1.MetadataType(v1.Generic)
+	)
+	tests := []struct {
+		name   string
+		params *v1.GetReposParams
+		want   []string
+	}{
+		{"unfiltered", &v1.GetReposParams{}, []string{"anime", "readonly", "writable"}},
+		{"writable", &v1.GetReposParams{Writable: &yes}, []string{"anime", "writable"}},
+		{"read-only", &v1.GetReposParams{Writable: &no}, []string{"readonly"}},
+		{"metadata type", &v1.GetReposParams{Writable: &yes, MetaType: &generic}, []string{"writable"}},
+		{"anime metadata type", &v1.GetReposParams{Writable: &yes, MetaType: &animeType}, []string{"anime", "writable"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := list(tt.params); !slices.Equal(got, tt.want) {
+				t.Errorf("listed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostRepoStrictUploads(t *testing.T) {
+	var (
+		strict  = newTestRepo(t, "strict", nil, &repo.Options{StrictUploads: true})
+		lenient = newTestRepo(t, "lenient", nil, nil)
+	)
+	ts, _ := newTestServer(t, strict, lenient)
+
+	tests := []struct {
+		name   string
+		repo   string
+		extra  string
+		status int
+	}{
+		{"strict", "strict", "", http.StatusOK},
+		{"strict unknown field", "strict", `, "filname": "a.png"`, http.StatusBadRequest},
+		{"lenient unknown field", "lenient", `, "filname": "a.png"`, http.StatusOK},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"data": "` + base64.StdEncoding.EncodeToString(testPNG(t, i+1, 1)) + `"` + tt.extra + `}`
+
+			res, err := http.Post(ts.URL+BasePath+"/repos/"+tt.repo, "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to upload: %v", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.status {
+				b, _ := io.ReadAll(res.Body)
+				t.Errorf("status = %d, want %d: %s", res.StatusCode, tt.status, b)
+			}
+		})
+	}
+
+	if n := strict.Usage().Items; n != 1 {
+		t.Errorf("strict repository stored %d items, want only the valid upload", n)
+	}
+}
+
+func TestDeleteRepoBatch(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	_, c := newTestServer(t, r)
+
+	upload := func(i int, batch string) uuid.UUID {
+		t.Helper()
+
+		body := v1.ProtoMedia{Data: base64.StdEncoding.EncodeToString(testPNG(t, i+1, 1))}
+		if batch != "" {
+			body.Batch = &batch
+		}
+		res, err := c.PostRepoWithResponse(context.Background(), "test", &v1.PostRepoParams{}, body)
+		if err != nil {
+			t.Fatalf("failed to upload: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+		return res.JSON200.Id
+	}
+
+	var (
+		batch = []uuid.UUID{upload(0, "import"), upload(1, "import")}
+		other = []uuid.UUID{upload(2, "other"), upload(3, "")}
+		sort  = func(ids []uuid.UUID) []uuid.UUID {
+			slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			return ids
+		}
+	)
+
+	name := "import"
+	listed, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{Batch: &name})
+	if err != nil {
+		t.Fatalf("failed to list media: %v", err)
+	}
+	if listed.JSON200 == nil {
+		t.Fatalf("status = %d, want 200: %s", listed.StatusCode(), listed.Body)
+	}
+	var ids []uuid.UUID
+	for _, m := range *listed.JSON200 {
+		if m.Batch == nil || *m.Batch != "import" {
+			t.Errorf("listed media %s has batch %v, want import", m.Id, m.Batch)
+		}
+		ids = append(ids, m.Id)
+	}
+	if !slices.Equal(sort(ids), sort(batch)) {
+		t.Errorf("listed %v, want the batch %v", ids, batch)
+	}
+
+	res, err := c.DeleteRepoBatchWithResponse(context.Background(), "test", "import", &v1.DeleteRepoBatchParams{})
+	if err != nil {
+		t.Fatalf("failed to delete batch: %v", err)
+	}
+	if res.JSON200 == nil || len(*res.JSON200) != len(batch) {
+		t.Fatalf("batch deletion = %s, want the %d removed items", res.Body, len(batch))
+	}
+	for _, id := range batch {
+		if r.Get(id) != nil {
+			t.Errorf("media %s of the batch wasn't removed", id)
+		}
+	}
+	for _, id := range other {
+		if r.Get(id) == nil {
+			t.Errorf("media %s outside of the batch was removed", id)
+		}
+	}
+}
+
+func TestGetRepoMissing(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	ts, c := newTestServer(t, r)
+
+	var (
+		unhashed = []uuid.UUID{addTestMedia(t, r, time.Now()).ID, addTestMedia(t, r, time.Now()).ID}
+		sort     = func(ids []uuid.UUID) []uuid.UUID {
+			slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			return ids
+		}
+	)
+	if _, err := r.Create(context.Background(), testPNG(t, 2, 2), nil, nil); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+
+	for _, field := range []v1.DerivedField{v1.Hash, v1.Dimensions} {
+		res, err := c.GetRepoWithResponse(context.Background(), "test", &v1.GetRepoParams{Missing: &field})
+		if err != nil {
+			t.Fatalf("failed to list media: %v", err)
+		}
+		if res.JSON200 == nil {
+			t.Fatalf("status = %d, want 200: %s", res.StatusCode(), res.Body)
+		}
+
+		var ids []uuid.UUID
+		for _, m := range *res.JSON200 {
+			ids = append(ids, m.Id)
+		}
+		if !slices.Equal(sort(ids), sort(unhashed)) {
+			t.Errorf("media missing %s = %v, want %v", field, ids, unhashed)
+		}
+	}
+
+	res, err := http.Get(ts.URL + BasePath + "/repos/test?missing=blurhash")
+	if err != nil {
+		t.Fatalf("failed to list media: %v", err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown derived field status = %d, want 400", res.StatusCode)
+	}
+}
@@ -1,15 +1,22 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/cephxdev/nero/internal/errors"
 	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
 	"github.com/cephxdev/nero/server/api"
 	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
+	"golang.org/x/sync/semaphore"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 )
 
 var (
@@ -31,6 +38,7 @@ var (
 			type_  = v1.InternalError
 
 			httpErr *api.HTTPError
+			edf     *repo.ErrDiskFull
 		)
 		if errors.As(err, &httpErr) {
 			status = httpErr.Status
@@ -38,6 +46,12 @@ var (
 			if httpErr.Type != "" {
 				type_ = v1.ErrorType(httpErr.Type)
 			}
+		} else if errors.As(err, &edf) {
+			status = http.StatusInsufficientStorage
+			type_ = v1.InsufficientStorage
+		} else if errors.Is(err, repo.ErrUpstreamNotFound) {
+			status = http.StatusNotFound
+			type_ = v1.NotFound
 		}
 
 		w.WriteHeader(status)
@@ -49,15 +63,29 @@ var (
 	}
 )
 
+// BasePath is the path the nero v1 API is served under.
+const BasePath = "/api/v1"
+
 // Server is a REST server for the nero v1 API.
 type Server struct {
-	repos  map[string]*repo.Repository
-	logger *zap.Logger
+	repos   map[string]*repo.Repository
+	uploads map[string]*semaphore.Weighted // upload concurrency limits, keyed by repository ID
+	lists   map[string]*listCache          // listing caches, keyed by repository ID
+	tokens  usedTokens                     // used pre-signed upload tokens
+	baseURL *url.URL
+	drain   *api.Drain
+	logger  *zap.Logger
 }
 
 // NewServer creates a new server with pre-defined repositories.
-func NewServer(repos []*repo.Repository, logger *zap.Logger) (*Server, error) {
-	reposById := make(map[string]*repo.Repository, len(repos))
+// baseURL is the public base URL of the server, guessed from requests if nil.
+// New uploads are rejected once drain is started, drain may be nil.
+func NewServer(repos []*repo.Repository, baseURL *url.URL, drain *api.Drain, logger *zap.Logger) (*Server, error) {
+	var (
+		reposById = make(map[string]*repo.Repository, len(repos))
+		uploads   = make(map[string]*semaphore.Weighted)
+		lists     = make(map[string]*listCache)
+	)
 	for _, r := range repos {
 		repoId := r.ID()
 		if _, ok := reposById[repoId]; ok {
@@ -65,25 +93,115 @@ func NewServer(repos []*repo.Repository, logger *zap.Logger) (*Server, error) {
 		}
 
 		reposById[repoId] = r
+		if n := r.Options().UploadConcurrency; n > 0 {
+			uploads[repoId] = semaphore.NewWeighted(int64(n))
+		}
+		if c := newListCache(r); c != nil {
+			lists[repoId] = c
+		}
 	}
 
 	return &Server{
-		repos:  reposById,
-		logger: logger,
+		repos:   reposById,
+		uploads: uploads,
+		lists:   lists,
+		baseURL: baseURL,
+		drain:   drain,
+		logger:  logger,
 	}, nil
 }
 
 // NewRouter creates a new nero v1 API router.
 func NewRouter(handler v1.StrictServerInterface) http.Handler {
-	h := v1.NewStrictHandlerWithOptions(handler, nil, v1.StrictHTTPServerOptions{
+	opts := v1.StrictHTTPServerOptions{
 		RequestErrorHandlerFunc:  DefaultRequestErrorHandler,
 		ResponseErrorHandlerFunc: DefaultResponseErrorHandler,
-	})
+	}
+	if s, ok := handler.(*Server); ok {
+		opts.DisallowUnknownFieldsFunc = s.disallowUnknownFields
+	}
+
+	h := v1.NewStrictHandlerWithOptions(handler, []v1.StrictMiddlewareFunc{requestMiddleware}, opts)
 
 	return v1.HandlerWithOptions(h, v1.ChiServerOptions{ErrorHandlerFunc: DefaultRequestErrorHandler})
 }
 
+// disallowUnknownFields reports whether a request body with unknown fields should be rejected,
+// i.e. for uploads to repositories with Options.StrictUploads.
+func (s *Server) disallowUnknownFields(req *http.Request, operationID string) bool {
+	if operationID != "PostRepo" {
+		return false
+	}
+
+	r, ok := s.repos[chi.URLParam(req, "repo")]
+	return ok && r.Options().StrictUploads
+}
+
+// requestMiddleware makes the HTTP request available to handlers through their context.
+func requestMiddleware(f v1.StrictHandlerFunc, _ string) v1.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return f(api.WithRequest(ctx, r), w, r, request)
+	}
+}
+
 // Repos returns all repositories available to the server.
 func (s *Server) Repos() []*repo.Repository {
 	return maps.Values(s.repos)
 }
+
+// sortedRepos returns all repositories available to the server, ordered by their ID.
+func (s *Server) sortedRepos() []*repo.Repository {
+	rs := s.Repos()
+	slices.SortFunc(rs, func(a, b *repo.Repository) int {
+		return strings.Compare(a.ID(), b.ID())
+	})
+
+	return rs
+}
+
+// BaseURL returns the configured base URL of the server, may be nil.
+func (s *Server) BaseURL() *url.URL {
+	return s.baseURL
+}
+
+// mediaURL builds the absolute URL of the media serving endpoint, returns an empty string if it cannot be determined.
+func (s *Server) mediaURL(ctx context.Context, repoId string, m *media.Media) string {
+	return s.apiURL(ctx, "/repos/"+repoId+"/"+m.ID.String())
+}
+
+// apiURL builds the absolute URL of an API path, returns an empty string if it cannot be determined.
+func (s *Server) apiURL(ctx context.Context, path string) string {
+	r := api.Request(ctx)
+	if r == nil && s.baseURL == nil {
+		return ""
+	}
+
+	u := api.BaseURL(r, s.baseURL)
+	u.Path += BasePath + path
+	return u.String()
+}
+
+// acquireUpload acquires an upload slot of a repository, returns false if none was available in time.
+// The returned function releases the slot.
+func (s *Server) acquireUpload(ctx context.Context, r *repo.Repository) (func(), bool) {
+	sem, ok := s.uploads[r.ID()]
+	if !ok {
+		return func() {}, true
+	}
+
+	timeout := r.Options().UploadQueueTimeout
+	if timeout <= 0 {
+		if !sem.TryAcquire(1) {
+			return nil, false
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return nil, false
+		}
+	}
+
+	return func() { sem.Release(1) }, true
+}
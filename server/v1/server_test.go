@@ -0,0 +1,336 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/repo/media"
+	"github.com/cephxdev/nero/repo/media/meta"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newTestRepo creates a file-backed repository in a temporary directory, opts may be nil.
+// The repository is closed when the test finishes.
+func newTestRepo(t *testing.T, id string, meta repo.Metadata, opts *repo.Options) *repo.Repository {
+	t.Helper()
+
+	dir := t.TempDir()
+	r, err := repo.NewFile(id, dir, filepath.Join(dir, "nero.lock"), meta, opts, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Close(); err != nil {
+			t.Errorf("failed to close repository: %v", err)
+		}
+	})
+
+	return r
+}
+
+// newTestServer serves the v1 API of repositories over HTTP until the test finishes.
+func newTestServer(t *testing.T, repos ...*repo.Repository) (*httptest.Server, *v1.ClientWithResponses) {
+	t.Helper()
+
+	srv, err := NewServer(repos, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Mount(BasePath, NewRouter(srv))
+
+	ts := httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+
+	c, err := v1.NewClientWithResponses(ts.URL + BasePath)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return ts, c
+}
+
+// testPNG encodes a width×height PNG image.
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: uint8(x), A: 0xff}) // keeps differently sized images distinct
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// addTestMedia inserts media without content created at a time.
+func addTestMedia(t *testing.T, r *repo.Repository, createdAt time.Time) *media.Media {
+	t.Helper()
+
+	id := uuid.New()
+	m := &media.Media{ID: id, Format: media.FormatImage, Path: filepath.Join(r.Path(), id.String()+".png"), CreatedAt: createdAt}
+	if err := r.Add(context.Background(), m); err != nil {
+		t.Fatalf("failed to add media: %v", err)
+	}
+	return m
+}
+
+func TestAcquireUpload(t *testing.T) {
+	var (
+		rejecting = newTestRepo(t, "rejecting", nil, &repo.Options{UploadConcurrency: 1})
+		queueing  = newTestRepo(t, "queueing", nil, &repo.Options{UploadConcurrency: 1, UploadQueueTimeout: 50 * time.Millisecond})
+	)
+	srv, err := NewServer([]*repo.Repository{rejecting, queueing}, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	release, ok := srv.acquireUpload(context.Background(), rejecting)
+	if !ok {
+		t.Fatal("failed to acquire a free upload slot")
+	}
+	if _, ok := srv.acquireUpload(context.Background(), rejecting); ok {
+		t.Error("upload beyond the limit wasn't rejected")
+	}
+	release()
+	if release, ok := srv.acquireUpload(context.Background(), rejecting); !ok {
+		t.Error("released upload slot isn't available")
+	} else {
+		release()
+	}
+
+	release, ok = srv.acquireUpload(context.Background(), queueing)
+	if !ok {
+		t.Fatal("failed to acquire a free upload slot")
+	}
+	start := time.Now()
+	if _, ok := srv.acquireUpload(context.Background(), queueing); ok {
+		t.Error("queued upload acquired a taken slot")
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("queued upload gave up after %s, want the queue timeout", d)
+	}
+
+	time.AfterFunc(10*time.Millisecond, release)
+	if release, ok := srv.acquireUpload(context.Background(), queueing); !ok {
+		t.Error("queued upload didn't get the slot released while waiting")
+	} else {
+		release()
+	}
+}
+
+func TestPostRepoConcurrencyLimit(t *testing.T) {
+	r := newTestRepo(t, "test", nil, &repo.Options{UploadConcurrency: 1})
+	srv, err := NewServer([]*repo.Repository{r}, nil, nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	ts := httptest.NewServer(NewRouter(srv))
+	t.Cleanup(ts.Close)
+
+	c, err := v1.NewClientWithResponses(ts.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	release, ok := srv.acquireUpload(context.Background(), r)
+	if !ok {
+		t.Fatal("failed to acquire a free upload slot")
+	}
+	defer release()
+
+	res, err := c.PostRepoWithResponse(context.Background(), "test", &v1.PostRepoParams{}, v1.ProtoMedia{
+		Data: base64.StdEncoding.EncodeToString(testPNG(t, 1, 1)),
+	})
+	if err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+	if res.StatusCode() != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429: %s", res.StatusCode(), res.Body)
+	}
+}
+
+func TestResponseErrorDiskFull(t *testing.T) {
+	err := &repo.ErrDiskFull{Err: &fs.PathError{Op: "write", Path: "a.png", Err: syscall.ENOSPC}}
+
+	w := httptest.NewRecorder()
+	DefaultResponseErrorHandler(w, httptest.NewRequest(http.MethodPost, "/repos/test", nil), err)
+
+	if w.Code != http.StatusInsufficientStorage {
+		t.Errorf("status = %d, want 507", w.Code)
+	}
+
+	var e v1.Error
+	if err := json.NewDecoder(w.Body).Decode(&e); err != nil {
+		t.Fatalf("malformed error body: %v", err)
+	}
+	if e.Type != v1.InsufficientStorage {
+		t.Errorf("error type = %s, want %s", e.Type, v1.InsufficientStorage)
+	}
+}
+
+func TestResponseErrorUpstreamNotFound(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"upstream", fmt.Errorf("%w: %s", repo.ErrUpstreamNotFound, uuid.New()), http.StatusNotFound},
+		{"local", &fs.PathError{Op: "open", Path: "a.png", Err: fs.ErrNotExist}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			DefaultResponseErrorHandler(w, httptest.NewRequest(http.MethodGet, "/repos/test", nil), tt.err)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestMediaURL(t *testing.T) {
+	r := newTestRepo(t, "test", nil, nil)
+	m := addTestMedia(t, r, time.Now())
+
+	// listURL lists the repository with a server and returns the URL of the only item
+	listURL := func(t *testing.T, baseURL *url.URL, header http.Header) string {
+		srv, err := NewServer([]*repo.Repository{r}, baseURL, nil, zap.NewNop())
+		if err != nil {
+			t.Fatalf("failed to create server: %v", err)
+		}
+
+		router := chi.NewRouter()
+		router.Mount(BasePath, NewRouter(srv))
+
+		req := httptest.NewRequest(http.MethodGet, "http://internal:8080"+BasePath+"/repos/test", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var res []v1.Media
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil || len(res) != 1 {
+			t.Fatalf("malformed listing (%v): %v", err, res)
+		}
+		return api.MakeString(res[0].Url)
+	}
+
+	base, _ := url.Parse("https://cdn.example.com/nero/")
+	if got, want := listURL(t, base, nil), "https://cdn.example.com/nero/api/v1/repos/test/"+m.ID.String(); got != want {
+		t.Errorf("URL with a configured base = %q, want %q", got, want)
+	}
+
+	header := http.Header{"X-Forwarded-Proto": {"https"}, "X-Forwarded-Host": {"media.example.com, proxy.internal"}}
+	if got, want := listURL(t, nil, header), "https://media.example.com/api/v1/repos/test/"+m.ID.String(); got != want {
+		t.Errorf("URL behind a proxy = %q, want %q", got, want)
+	}
+	if got, want := listURL(t, base, header), "https://cdn.example.com/nero/api/v1/repos/test/"+m.ID.String(); got != want {
+		t.Errorf("configured base doesn't take precedence over forwarded headers, got %q", got)
+	}
+}
+
+func TestPostRepoDrain(t *testing.T) {
+	var (
+		entered = make(chan struct{})
+		proceed = make(chan struct{})
+		blocked atomic.Bool
+	)
+	r := newTestRepo(t, "test", nil, &repo.Options{
+		Enrichers: []repo.MetaEnricher{repo.EnricherFunc(func(_ context.Context, _ media.Format, _ []byte, m meta.Metadata) (meta.Metadata, error) {
+			if blocked.CompareAndSwap(false, true) { // holds up only the first upload
+				close(entered)
+				<-proceed
+			}
+			return m, nil
+		})},
+	})
+
+	drain := &api.Drain{}
+	srv, err := NewServer([]*repo.Repository{r}, nil, drain, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	ts := httptest.NewServer(NewRouter(srv))
+	t.Cleanup(ts.Close)
+
+	c, err := v1.NewClientWithResponses(ts.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	upload := func(width int) (*v1.PostRepoResponse, error) {
+		return c.PostRepoWithResponse(context.Background(), "test", &v1.PostRepoParams{}, v1.ProtoMedia{
+			Data: base64.StdEncoding.EncodeToString(testPNG(t, width, 1)),
+		})
+	}
+
+	inFlight := make(chan int, 1)
+	go func() {
+		res, err := upload(1)
+		if err != nil {
+			inFlight <- 0
+			return
+		}
+		inFlight <- res.StatusCode()
+	}()
+
+	<-entered
+	drain.Start()
+
+	res, err := upload(2)
+	if err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+	if res.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503: %s", res.StatusCode(), res.Body)
+	}
+
+	done := drain.Wait()
+	select {
+	case <-done:
+		t.Error("drain finished with an upload in flight")
+	default:
+	}
+
+	close(proceed)
+	if status := <-inFlight; status != http.StatusOK {
+		t.Errorf("in-flight upload status = %d, want 200", status)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("drain didn't finish after the in-flight upload")
+	}
+	if n := r.Usage().Items; n != 1 {
+		t.Errorf("stored %d items, want only the in-flight upload", n)
+	}
+}
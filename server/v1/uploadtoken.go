@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cephxdev/nero/internal/errors"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api"
+	"github.com/cephxdev/nero/server/api/v1"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultUploadTokenTTL and maxUploadTokenTTL are the default and maximum lifetimes of pre-signed upload tokens.
+const defaultUploadTokenTTL, maxUploadTokenTTL = 5 * time.Minute, time.Hour
+
+func (s *Server) PostRepoUploadTokens(ctx context.Context, request v1.PostRepoUploadTokensRequestObject) (v1.PostRepoUploadTokensResponseObject, error) {
+	r, ok := s.repos[request.Repo]
+	if !ok {
+		return v1.PostRepoUploadTokens400JSONResponse(v1.Error{Type: v1.NotFound, Description: "unknown repository"}), nil
+	}
+
+	if !checkKey(r, api.MakeString(request.Params.XNeroKey)) {
+		return nil, unauthorizedError
+	}
+
+	key, ok := r.Meta().Value(repo.UploadSigningKey)
+	if !ok {
+		return v1.PostRepoUploadTokens400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "pre-signed uploads are disabled"}), nil
+	}
+
+	ttl := defaultUploadTokenTTL
+	if request.Params.Ttl != nil {
+		if secs := *request.Params.Ttl; secs <= 0 || secs > int(maxUploadTokenTTL/time.Second) {
+			return v1.PostRepoUploadTokens400JSONResponse(v1.Error{Type: v1.BadRequest, Description: "token lifetime out of range"}), nil
+		}
+		ttl = time.Duration(*request.Params.Ttl) * time.Second
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	token, err := signUploadToken(key, r.ID(), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	res := v1.UploadToken{Token: token, ExpiresAt: expiresAt.UTC()}
+	if u := s.apiURL(ctx, "/repos/"+r.ID()); u != "" {
+		res.Url = api.MakeOptString(u + "?token=" + url.QueryEscape(token))
+	}
+
+	return v1.PostRepoUploadTokens200JSONResponse(res), nil
+}
+
+// signUploadToken mints a token authorizing a single upload to a repository until it expires.
+// The token consists of the expiry time, a random nonce and an HMAC-SHA256 signature of both and the repository ID.
+func signUploadToken(key, repoId string, expiresAt time.Time) (string, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", errors.Wrap(err, "failed to generate token nonce")
+	}
+
+	payload := strconv.FormatInt(expiresAt.Unix(), 10) + "." + hex.EncodeToString(nonce[:])
+	return payload + "." + uploadTokenSignature(key, repoId, payload), nil
+}
+
+// uploadTokenSignature computes the hex-encoded signature of an upload token payload.
+func uploadTokenSignature(key, repoId, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(repoId + "." + payload))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyUploadToken checks the signature and expiry of an upload token for a repository.
+// Returns the token nonce and expiry time, false if the token is invalid or expired.
+func verifyUploadToken(r *repo.Repository, token string) (string, time.Time, bool) {
+	key, ok := r.Meta().Value(repo.UploadSigningKey)
+	if !ok {
+		return "", time.Time{}, false
+	}
+
+	payload, sig, ok := cutLast(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(uploadTokenSignature(key, r.ID(), payload))) {
+		return "", time.Time{}, false
+	}
+
+	expiry, nonce, _ := strings.Cut(payload, ".")
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	expiresAt := time.Unix(unix, 0)
+	if time.Now().After(expiresAt) {
+		return "", time.Time{}, false
+	}
+	return nonce, expiresAt, true
+}
+
+// cutLast slices s around the last instance of sep, see strings.Cut.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// usedTokens is a set of nonces of used upload tokens, kept until the tokens expire.
+// It's held in memory only, unexpired tokens can be used again after a restart.
+type usedTokens struct {
+	nonces map[string]time.Time // nonce to expiry time
+	mu     sync.Mutex
+}
+
+// use marks a token nonce as used, returns false if it was used already.
+func (ut *usedTokens) use(nonce string, expiresAt time.Time) bool {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+
+	now := time.Now()
+	for n, exp := range ut.nonces {
+		if now.After(exp) {
+			delete(ut.nonces, n)
+		}
+	}
+
+	if _, ok := ut.nonces[nonce]; ok {
+		return false
+	}
+	if ut.nonces == nil {
+		ut.nonces = make(map[string]time.Time)
+	}
+
+	ut.nonces[nonce] = expiresAt
+	return true
+}
@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/cephxdev/nero/repo"
+	"github.com/cephxdev/nero/server/api/v1"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPostRepoUploadToken(t *testing.T) {
+	var (
+		r     = newTestRepo(t, "test", repo.Metadata{repo.AuthKey: "key", repo.UploadSigningKey: "secret"}, nil)
+		other = newTestRepo(t, "other", repo.Metadata{repo.AuthKey: "key", repo.UploadSigningKey: "secret"}, nil)
+		_, c  = newTestServer(t, r, other)
+		key   = "key"
+		n     int
+	)
+	upload := func(token string) int {
+		t.Helper()
+
+		n++
+		res, err := c.PostRepoWithResponse(context.Background(), "test", &v1.PostRepoParams{Token: &token}, v1.ProtoMedia{
+			Data: base64.StdEncoding.EncodeToString(testPNG(t, n, 1)),
+		})
+		if err != nil {
+			t.Fatalf("failed to upload: %v", err)
+		}
+		return res.StatusCode()
+	}
+
+	if res, err := c.PostRepoUploadTokensWithResponse(context.Background(), "test", &v1.PostRepoUploadTokensParams{}); err != nil || res.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("minting a token without the key didn't fail with 401: %v", err)
+	}
+
+	res, err := c.PostRepoUploadTokensWithResponse(context.Background(), "test", &v1.PostRepoUploadTokensParams{XNeroKey: &key})
+	if err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+	if res.JSON200 == nil {
+		t.Fatalf("minting a token completed with status code %d", res.StatusCode())
+	}
+	token := res.JSON200.Token
+
+	if code := upload(token); code != http.StatusOK {
+		t.Fatalf("upload with a valid token completed with status code %d, want 200", code)
+	}
+	if code := upload(token); code != http.StatusUnauthorized {
+		t.Errorf("upload with a reused token completed with status code %d, want 401", code)
+	}
+
+	expired, err := signUploadToken("secret", "test", time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	forged, err := signUploadToken("other secret", "test", time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	foreign, err := signUploadToken("secret", "other", time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	for name, token := range map[string]string{"expired": expired, "forged": forged, "other repository": foreign, "malformed": "token"} {
+		if code := upload(token); code != http.StatusUnauthorized {
+			t.Errorf("upload with a %s token completed with status code %d, want 401", name, code)
+		}
+	}
+
+	if n := r.Usage().Items; n != 1 {
+		t.Errorf("repository has %d items, want only the one uploaded with the valid token", n)
+	}
+}